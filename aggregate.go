@@ -0,0 +1,301 @@
+package gocnab
+
+import (
+	"math"
+	"reflect"
+	"strings"
+)
+
+// aggregateOption computes a field from the other records of the file, counting
+// the records of some types or summing a field of them. Many record types can
+// be combined with "|" (e.g. count=Header|Detail|Trailer or
+// sum=Debit.Amount|Credit.Amount). The record types are identified by the name
+// of the struct type, optionally qualified by the package name (e.g.
+// febraban240.SegmentP), so anonymous structs can't be counted or summed.
+type aggregateOption struct {
+	count   bool
+	sources []aggregateSource
+}
+
+// aggregateSource is a record type, and the field summed from it, that
+// contributes to an aggregate field.
+type aggregateSource struct {
+	recordType string
+	field      string
+}
+
+func parseAggregateOption(name, value string) (*aggregateOption, bool) {
+	option := aggregateOption{
+		count: name == "count",
+	}
+
+	for _, item := range strings.Split(value, "|") {
+		if option.count {
+			if item == "" {
+				return nil, false
+			}
+
+			option.sources = append(option.sources, aggregateSource{
+				recordType: item,
+			})
+			continue
+		}
+
+		parts := strings.SplitN(item, ".", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, false
+		}
+
+		option.sources = append(option.sources, aggregateSource{
+			recordType: parts[0],
+			field:      parts[1],
+		})
+	}
+
+	return &option, true
+}
+
+// matches checks if the source refers to the record type, by its name or by
+// its name qualified by the package name.
+func (a aggregateSource) matches(recordType reflect.Type) bool {
+	return recordType.Name() != "" &&
+		(a.recordType == recordType.Name() || a.recordType == recordType.String())
+}
+
+// compute returns the aggregated value converted to the type of the field.
+// The sum is computed in the unit of the CNAB representation, where float
+// fields are written in cents: an integer field summing float fields receives
+// the total in cents, and a float field summing integer fields receives the
+// total divided by 100, so the digits of the total always match the digits of
+// the summed fields.
+func (a aggregateOption) compute(fieldType reflect.Type, records []reflect.Value) (reflect.Value, error) {
+	var total int64
+	matched := make([]reflect.Type, len(a.sources))
+
+	for _, record := range records {
+		for i, source := range a.sources {
+			if !source.matches(record.Type()) {
+				continue
+			}
+
+			// the same name can't refer to different types (from different
+			// packages, for example)
+			if matched[i] != nil && matched[i] != record.Type() {
+				return reflect.Value{}, ErrAmbiguousRecordType
+			}
+			matched[i] = record.Type()
+
+			if a.count {
+				total++
+				continue
+			}
+
+			if record.Kind() != reflect.Struct {
+				return reflect.Value{}, ErrInvalidFieldTagOption
+			}
+
+			field := record.FieldByName(source.field)
+			switch field.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				total += field.Int()
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				total += int64(field.Uint())
+			case reflect.Float32, reflect.Float64:
+				total += int64(math.Round(field.Float() * 100))
+			default:
+				return reflect.Value{}, ErrInvalidFieldTagOption
+			}
+		}
+	}
+
+	value := reflect.New(fieldType).Elem()
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(total)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value.SetUint(uint64(total))
+	case reflect.Float32, reflect.Float64:
+		if a.count {
+			value.SetFloat(float64(total))
+		} else {
+			value.SetFloat(float64(total) / 100)
+		}
+	}
+
+	return value, nil
+}
+
+// flattenRecords returns all the records that will be marshaled, expanding
+// the slices.
+func flattenRecords(vs []interface{}) []reflect.Value {
+	var records []reflect.Value
+	for _, v := range vs {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Slice && !rv.Type().Implements(lineMarshalerType) {
+			for i := 0; i < rv.Len(); i++ {
+				records = append(records, rv.Index(i))
+			}
+		} else if rv.IsValid() {
+			records = append(records, rv)
+		}
+	}
+	return records
+}
+
+// expandComposites adds the records of each line of the composite records,
+// so they can also be counted and summed.
+func expandComposites(records []reflect.Value) []reflect.Value {
+	var expanded []reflect.Value
+	for _, record := range records {
+		expanded = append(expanded, record)
+		if isComposite(record.Type()) {
+			// problems in the composite record are reported when marshaling
+			lines, _ := compositeLines(record, false)
+			expanded = append(expanded, lines...)
+		}
+	}
+	return expanded
+}
+
+// aggregateFields returns the fields of the record type with aggregate
+// options.
+func aggregateFields(recordType reflect.Type, lineSize int) ([]fieldLayout, error) {
+	// composite records don't map positions by themselves
+	if recordType.Kind() != reflect.Struct || hasCompositeTag(recordType) {
+		return nil, nil
+	}
+
+	fields, err := structLayout(recordType, lineSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var aggregates []fieldLayout
+	for _, field := range fields {
+		if field.options.aggregate != nil && field.exported {
+			aggregates = append(aggregates, field)
+		}
+	}
+
+	return aggregates, nil
+}
+
+// applyAggregates returns a copy of the records to be marshaled with the
+// aggregate fields filled, computed from all the records.
+func applyAggregates(lineSize int, vs []interface{}) ([]interface{}, error) {
+	records := expandComposites(flattenRecords(vs))
+	result := make([]interface{}, len(vs))
+
+	apply := func(record reflect.Value) (reflect.Value, error) {
+		fields, err := aggregateFields(record.Type(), lineSize)
+		if err != nil || len(fields) == 0 {
+			// layout errors are reported when marshaling the record
+			return record, nil
+		}
+
+		recordCopy := reflect.New(record.Type()).Elem()
+		recordCopy.Set(record)
+
+		for _, field := range fields {
+			fieldValue := recordCopy.Field(field.index)
+			value, err := field.options.aggregate.compute(fieldValue.Type(), records)
+			if err != nil {
+				return reflect.Value{}, FieldError{
+					Field: field.name,
+					Err:   err,
+				}
+			}
+			fieldValue.Set(value)
+		}
+
+		return recordCopy, nil
+	}
+
+	for i, v := range vs {
+		rv := reflect.ValueOf(v)
+
+		switch {
+		case rv.Kind() == reflect.Struct:
+			record, err := apply(rv)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = record.Interface()
+
+		case rv.Kind() == reflect.Slice && !rv.Type().Implements(lineMarshalerType):
+			if fields, _ := aggregateFields(rv.Type().Elem(), lineSize); len(fields) == 0 {
+				result[i] = v
+				continue
+			}
+
+			slice := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+			for j := 0; j < rv.Len(); j++ {
+				record, err := apply(rv.Index(j))
+				if err != nil {
+					return nil, err
+				}
+				slice.Index(j).Set(record)
+			}
+			result[i] = slice.Interface()
+
+		default:
+			result[i] = v
+		}
+	}
+
+	return result, nil
+}
+
+// verifyAggregates checks the aggregate fields of the decoded records, where
+// lineSizes has the size of the line each record was decoded from. When
+// there's a mismatch, the index of the record is returned with the error.
+func verifyAggregates(records []reflect.Value, lineSizes []int) (int, error) {
+	allRecords := expandComposites(records)
+
+	for i, record := range records {
+		fields, err := aggregateFields(record.Type(), lineSizes[i])
+		if err != nil {
+			return i, err
+		}
+
+		for _, field := range fields {
+			fieldValue := record.Field(field.index)
+			expected, err := field.options.aggregate.compute(fieldValue.Type(), allRecords)
+			if err != nil {
+				return i, FieldError{
+					Field: field.name,
+					Err:   err,
+				}
+			}
+
+			// compare the CNAB representation, so the same precision is used
+			size := field.end - field.begin
+			expectedData := []byte(strings.Repeat(" ", size))
+			currentData := []byte(strings.Repeat(" ", size))
+
+			if err = marshalField(expectedData, expected, 0, size); err != nil {
+				return i, FieldError{
+					Field: field.name,
+					Err:   err,
+				}
+			}
+
+			if err = marshalField(currentData, fieldValue, 0, size); err != nil {
+				return i, FieldError{
+					Field: field.name,
+					Err:   err,
+				}
+			}
+
+			if string(expectedData) != string(currentData) {
+				return i, UnmarshalFieldError{
+					Field: field.name,
+					Data:  currentData,
+					Err:   ErrAggregateMismatch,
+				}
+			}
+		}
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,360 @@
+package gocnab_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+)
+
+type aggregateDetail struct {
+	Identifier string  `cnab:"0,1,const=1"`
+	Amount     float64 `cnab:"1,11"`
+}
+
+type aggregateTrailer struct {
+	Identifier string  `cnab:"0,1,const=9"`
+	Count      int     `cnab:"1,7,count=aggregateDetail"`
+	Total      float64 `cnab:"7,22,sum=aggregateDetail.Amount"`
+}
+
+type aggregateCredit struct {
+	Identifier string  `cnab:"0,1,const=2"`
+	Value      float64 `cnab:"1,11"`
+}
+
+type aggregateFileTrailer struct {
+	Identifier string  `cnab:"0,1,const=9"`
+	Count      int     `cnab:"1,7,count=aggregateDetail|aggregateCredit|aggregateFileTrailer"`
+	Total      float64 `cnab:"7,22,sum=aggregateDetail.Amount|aggregateCredit.Value"`
+}
+
+func TestMarshal_aggregates(t *testing.T) {
+	t.Parallel()
+
+	details := []aggregateDetail{
+		{Amount: 10.5},
+		{Amount: 20.75},
+	}
+
+	data, err := gocnab.Marshal150(details, aggregateTrailer{Count: 99})
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	expected := fmt.Sprintf("1%010d%139s\r\n1%010d%139s\r\n9%06d%015d%128s\x1a", 1050, "", 2075, "", 2, 3125, "")
+	if expected != string(data) {
+		t.Errorf("expected data “%s” and got “%s”", expected, string(data))
+	}
+
+	// the given records must not be modified
+	if details[0].Amount != 10.5 {
+		t.Errorf("unexpected change in the records: %#v", details)
+	}
+}
+
+func TestMarshal_combinedAggregates(t *testing.T) {
+	t.Parallel()
+
+	data, err := gocnab.Marshal150(
+		[]aggregateDetail{{Amount: 10.5}},
+		[]aggregateCredit{{Value: 20.75}, {Value: 1}},
+		aggregateFileTrailer{},
+	)
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	expected := fmt.Sprintf("1%010d%139s\r\n2%010d%139s\r\n2%010d%139s\r\n9%06d%015d%128s\x1a", 1050, "", 2075, "", 100, "", 4, 3225, "")
+	if expected != string(data) {
+		t.Errorf("expected data “%s” and got “%s”", expected, string(data))
+	}
+
+	var details []aggregateDetail
+	var credits []aggregateCredit
+	var trailer aggregateFileTrailer
+
+	err = gocnab.Unmarshal(data, map[string]interface{}{
+		"1": &details,
+		"2": &credits,
+		"9": &trailer,
+	})
+	if err != nil {
+		t.Errorf("unexpected error. details: %s", err)
+	}
+}
+
+func TestMarshal_aggregatesUnit(t *testing.T) {
+	t.Parallel()
+
+	type intDetail struct {
+		Identifier string `cnab:"0,1,const=2"`
+		Amount     int    `cnab:"1,11"`
+	}
+
+	type trailer struct {
+		Identifier string  `cnab:"0,1,const=9"`
+		Count      int     `cnab:"1,7,count=gocnab_test.aggregateDetail"`
+		Cents      int     `cnab:"7,22,sum=aggregateDetail.Amount"`
+		Total      float64 `cnab:"22,37,sum=intDetail.Amount"`
+	}
+
+	data, err := gocnab.Marshal150(
+		[]aggregateDetail{{Amount: 10.5}, {Amount: 20.75}},
+		intDetail{Amount: 3125},
+		trailer{},
+	)
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	// the totals have the same digits of the summed fields
+	expected := fmt.Sprintf("1%010d%139s\r\n1%010d%139s\r\n2%010d%139s\r\n9%06d%015d%015d%113s\x1a",
+		1050, "", 2075, "", 3125, "", 2, 3125, 3125, "")
+
+	if expected != string(data) {
+		t.Errorf("expected data “%s” and got “%s”", expected, string(data))
+	}
+}
+
+func TestMarshal_ambiguousAggregate(t *testing.T) {
+	t.Parallel()
+
+	detail := aggregateDetail{Amount: 2}
+
+	// a different type with the same name of the package type
+	type aggregateDetail struct {
+		Identifier string  `cnab:"0,1,const=3"`
+		Amount     float64 `cnab:"1,11"`
+	}
+
+	_, err := gocnab.Marshal150(
+		detail,
+		aggregateDetail{Amount: 1},
+		aggregateFileTrailer{},
+	)
+
+	expectedError := gocnab.FieldError{
+		Field: "Count",
+		Err:   gocnab.ErrAmbiguousRecordType,
+	}
+
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected error “%v” and got “%v”", expectedError, err)
+	}
+}
+
+func TestUnmarshal_aggregates(t *testing.T) {
+	t.Parallel()
+
+	line := func(count, total int) string {
+		return fmt.Sprintf("1%010d%139s\r\n1%010d%139s\r\n9%06d%015d%128s\x1a", 1050, "", 2075, "", count, total, "")
+	}
+
+	scenarios := []struct {
+		description   string
+		data          string
+		mapper        bool
+		expectedError error
+	}{
+		{
+			description: "it should accept matching totals in a map",
+			data:        line(2, 3125),
+		},
+		{
+			description: "it should accept matching totals in a mapper",
+			data:        line(2, 3125),
+			mapper:      true,
+		},
+		{
+			description: "it should detect a wrong counter in a map",
+			data:        line(3, 3125),
+			expectedError: gocnab.UnmarshalFieldError{
+				Field: "Count",
+				Data:  []byte("000003"),
+				Err:   gocnab.ErrAggregateMismatch,
+			},
+		},
+		{
+			description: "it should detect a wrong sum in a mapper",
+			data:        line(2, 3000),
+			mapper:      true,
+			expectedError: gocnab.LineError{
+				Line: 3,
+				Err: gocnab.UnmarshalFieldError{
+					Field: "Total",
+					Data:  []byte("000000000003000"),
+					Err:   gocnab.ErrAggregateMismatch,
+				},
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			var details []aggregateDetail
+			var trailer aggregateTrailer
+
+			var err error
+			if scenario.mapper {
+				mapper := gocnab.NewMapper()
+				mapper.Register(&details, gocnab.MatchPrefix("1"))
+				mapper.Register(&trailer, gocnab.MatchPrefix("9"))
+				err = gocnab.Unmarshal([]byte(scenario.data), mapper)
+
+				if err == nil {
+					_, err = mapper.Decode([]byte(scenario.data))
+				}
+			} else {
+				err = gocnab.Unmarshal([]byte(scenario.data), map[string]interface{}{
+					"1": &details,
+					"9": &trailer,
+				})
+			}
+
+			if !reflect.DeepEqual(scenario.expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestMapper_Decode_aggregates(t *testing.T) {
+	t.Parallel()
+
+	data := fmt.Sprintf("1%010d%139s\r\n9%06d%015d%128s", 1050, "", 2, 1050, "")
+
+	mapper := gocnab.NewMapper()
+	mapper.Register((*aggregateDetail)(nil), gocnab.MatchPrefix("1"))
+	mapper.Register((*aggregateTrailer)(nil), gocnab.MatchPrefix("9"))
+
+	_, err := mapper.Decode([]byte(data))
+
+	expectedError := gocnab.LineError{
+		Line: 2,
+		Err: gocnab.UnmarshalFieldError{
+			Field: "Count",
+			Data:  []byte("000002"),
+			Err:   gocnab.ErrAggregateMismatch,
+		},
+	}
+
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected error “%v” and got “%v”", expectedError, err)
+	}
+}
+
+func TestValidateLayout_aggregates(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description   string
+		v             interface{}
+		expectedError error
+	}{
+		{
+			description: "it should accept count and sum options",
+			v: struct {
+				Count int     `cnab:"0,6,count=aggregateDetail"`
+				Total float64 `cnab:"6,21,sum=aggregateDetail.Amount"`
+			}{},
+		},
+		{
+			description: "it should detect an aggregate in a non-numeric field",
+			v: struct {
+				Count string `cnab:"0,6,count=aggregateDetail"`
+			}{},
+			expectedError: gocnab.FieldError{
+				Field: "Count",
+				Err:   gocnab.ErrInvalidFieldTagOption,
+			},
+		},
+		{
+			description: "it should detect a count without record type",
+			v: struct {
+				Count int `cnab:"0,6,count="`
+			}{},
+			expectedError: gocnab.FieldError{
+				Field: "Count",
+				Err:   gocnab.ErrInvalidFieldTagOption,
+			},
+		},
+		{
+			description: "it should accept combined count and sum options",
+			v: struct {
+				Count int     `cnab:"0,6,count=aggregateDetail|aggregateCredit"`
+				Total float64 `cnab:"6,21,sum=aggregateDetail.Amount|aggregateCredit.Value"`
+			}{},
+		},
+		{
+			description: "it should detect an empty record type in a combined count",
+			v: struct {
+				Count int `cnab:"0,6,count=aggregateDetail|"`
+			}{},
+			expectedError: gocnab.FieldError{
+				Field: "Count",
+				Err:   gocnab.ErrInvalidFieldTagOption,
+			},
+		},
+		{
+			description: "it should detect a sum without field",
+			v: struct {
+				Total int `cnab:"0,6,sum=aggregateDetail"`
+			}{},
+			expectedError: gocnab.FieldError{
+				Field: "Total",
+				Err:   gocnab.ErrInvalidFieldTagOption,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			err := gocnab.ValidateLayout(scenario.v, 240)
+
+			if !reflect.DeepEqual(scenario.expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+type aggregateCompositeTrailer struct {
+	Segment string `cnab:"0,1,const=T"`
+	Count   int    `cnab:"1,7,count=compositeP"`
+}
+
+type aggregateComposite struct {
+	P compositeP                `cnab:"line"`
+	T aggregateCompositeTrailer `cnab:"line"`
+}
+
+func TestComposite_aggregates(t *testing.T) {
+	t.Parallel()
+
+	expectedError := gocnab.FieldError{
+		Field: "Count",
+		Err:   gocnab.ErrInvalidFieldTagOption,
+	}
+
+	if err := gocnab.ValidateLayout(aggregateComposite{}, 150); !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected validation error “%v” and got “%v”", expectedError, err)
+	}
+
+	record := aggregateComposite{
+		P: compositeP{Amount: 10},
+		T: aggregateCompositeTrailer{Count: 1},
+	}
+
+	if _, err := gocnab.Marshal150(record); !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected marshal error “%v” and got “%v”", expectedError, err)
+	}
+
+	data := fmt.Sprintf("P%010d%139s\r\nT%06d%143s", 10, "", 1, "")
+
+	var decoded aggregateComposite
+	if err := gocnab.Unmarshal([]byte(data), &decoded); !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected unmarshal error “%v” and got “%v”", expectedError, err)
+	}
+}
@@ -0,0 +1,383 @@
+// Package cnab240 implements the hierarchical structure of a CNAB 240 file, as
+// defined by FEBRABAN. A CNAB 240 file contains a file header, one or more
+// batches (lotes) and a file trailer, where each batch contains a batch
+// header, detail segments and a batch trailer.
+//
+// The records are encoded with gocnab.Marshal240 and decoded with a
+// gocnab.Mapper, so any record type supported by gocnab can be used. The
+// control fields shared by all CNAB 240 records (batch number, record type,
+// sequence number in the batch and the trailer counters) are automatically
+// filled when marshaling and verified when unmarshaling, so the record types
+// don't need to fill them.
+package cnab240
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rafaeljusto/gocnab"
+)
+
+// LineSize number of characters of each CNAB 240 line.
+const LineSize = 240
+
+// Record types, stored in the position [7,8) of every line.
+const (
+	RecordTypeFileHeader   = "0"
+	RecordTypeBatchHeader  = "1"
+	RecordTypeInitial      = "2"
+	RecordTypeDetail       = "3"
+	RecordTypeFinal        = "4"
+	RecordTypeBatchTrailer = "5"
+	RecordTypeFileTrailer  = "9"
+)
+
+// Positions of the control fields, following the same convention of the CNAB
+// tag.
+const (
+	batchNumberBegin       = 3
+	batchNumberEnd         = 7
+	recordTypeBegin        = 7
+	recordTypeEnd          = 8
+	sequenceNumberBegin    = 8
+	sequenceNumberEnd      = 13
+	segmentCodeBegin       = 13
+	segmentCodeEnd         = 14
+	batchRecordsBegin      = 17
+	batchRecordsEnd        = 23
+	fileBatchesBegin       = 17
+	fileBatchesEnd         = 23
+	fileRecordsBegin       = 23
+	fileRecordsEnd         = 29
+	fileHeaderBatchNumber  = 0
+	fileTrailerBatchNumber = 9999
+)
+
+var (
+	// ErrMissingRecord raised when the file or a batch doesn't have the header or
+	// the trailer.
+	ErrMissingRecord = errors.New("missing header or trailer record")
+
+	// ErrUnexpectedRecord record type isn't expected in the current position of
+	// the file (e.g. a detail segment outside of a batch).
+	ErrUnexpectedRecord = errors.New("unexpected record type")
+
+	// ErrRecordCountMismatch counter in a trailer doesn't match the number of
+	// records in the file or batch.
+	ErrRecordCountMismatch = errors.New("trailer counter doesn't match the number of records")
+
+	// ErrSequenceMismatch batch number or sequence number of a record doesn't
+	// match its position in the file.
+	ErrSequenceMismatch = errors.New("batch or sequence number doesn't match the record position")
+)
+
+// File is a CNAB 240 file.
+type File struct {
+	Header  interface{}
+	Batches []Batch
+	Trailer interface{}
+}
+
+// Batch is a group of detail segments (lote) of a CNAB 240 file.
+type Batch struct {
+	Header   interface{}
+	Segments []Segment
+	Trailer  interface{}
+}
+
+// Segment is a detail record of a batch. Any type supported by
+// gocnab.Marshal240 can be used, including composite records with
+// consecutive segments (like a boleto with the segments P and Q), where each
+// line receives its own sequence number. To decode composite records register
+// them in the mapper with the discriminator of the first segment.
+//
+// The batch initial (2) and final (4) records are also segments, identified
+// by the record type they write in the position [7,8) (e.g. with a
+// `cnab:"7,8,const=2"` field). Only the detail records (3) receive sequence
+// numbers.
+type Segment interface{}
+
+// BatchRecordsPositioner is implemented by batch trailers that store the
+// number of records of the batch in a position different from the default
+// [17,23), like the trailer of the bank statement batches (extrato). The
+// range follows the same convention of the CNAB tag.
+type BatchRecordsPositioner interface {
+	BatchRecordsPosition() (begin, end int)
+}
+
+// batchRecordsPosition returns the position of the number of records in the
+// batch trailer.
+func batchRecordsPosition(trailer interface{}) (begin, end int) {
+	if positioner, ok := trailer.(BatchRecordsPositioner); ok {
+		return positioner.BatchRecordsPosition()
+	}
+	return batchRecordsBegin, batchRecordsEnd
+}
+
+// MatchRecordType detects lines of a record type, to be used when registering
+// the file and batch headers and trailers in the mapper.
+func MatchRecordType(recordType string) gocnab.Discriminator {
+	return gocnab.MatchRange(recordTypeBegin, recordTypeEnd, recordType)
+}
+
+// MatchSegment detects detail lines of a segment code (e.g. "P", "Q").
+func MatchSegment(code string) gocnab.Discriminator {
+	return gocnab.MatchAll(
+		MatchRecordType(RecordTypeDetail),
+		gocnab.MatchRange(segmentCodeBegin, segmentCodeEnd, code),
+	)
+}
+
+// Marshal returns the CNAB 240 encoding of the file. The batch number, record
+// type and sequence number of each line are automatically filled, as the
+// number of records in the batch trailers and the number of batches and
+// records in the file trailer. Segments without a record type are written as
+// detail records, and the batch initial and final records keep their own
+// record type.
+func Marshal(file File, options ...gocnab.MarshalOptionFunc) ([]byte, error) {
+	if file.Header == nil || file.Trailer == nil {
+		return nil, ErrMissingRecord
+	}
+
+	vs := []interface{}{file.Header}
+	batchSegments := make([]int, len(file.Batches))
+	for i, batch := range file.Batches {
+		if batch.Header == nil || batch.Trailer == nil {
+			return nil, ErrMissingRecord
+		}
+
+		vs = append(vs, batch.Header)
+		for _, segment := range batch.Segments {
+			// each line of a composite record is a segment of the batch
+			lines, err := gocnab.CompositeRecords(segment)
+			if err != nil {
+				return nil, err
+			}
+
+			vs = append(vs, lines...)
+			batchSegments[i] += len(lines)
+		}
+		vs = append(vs, batch.Trailer)
+	}
+	vs = append(vs, file.Trailer)
+
+	records := len(vs)
+	for _, option := range options {
+		vs = append(vs, option)
+	}
+
+	data, err := gocnab.Marshal240(vs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the lines share the same memory of data, so the control fields can be
+	// changed directly in the lines
+	lines := bytes.Split(bytes.TrimSuffix(data, []byte(gocnab.FinalControlCharacter)), []byte(gocnab.LineBreak))
+	if len(lines) != records {
+		return nil, gocnab.ErrUnsupportedType
+	}
+
+	line := lines[0]
+	setNumber(line, batchNumberBegin, batchNumberEnd, fileHeaderBatchNumber)
+	copy(line[recordTypeBegin:], RecordTypeFileHeader)
+	lines = lines[1:]
+
+	for i := range file.Batches {
+		batchNumber := i + 1
+
+		line = lines[0]
+		setNumber(line, batchNumberBegin, batchNumberEnd, batchNumber)
+		copy(line[recordTypeBegin:], RecordTypeBatchHeader)
+		lines = lines[1:]
+
+		var details int
+		for j := 0; j < batchSegments[i]; j++ {
+			line = lines[0]
+			setNumber(line, batchNumberBegin, batchNumberEnd, batchNumber)
+
+			switch string(line[recordTypeBegin:recordTypeEnd]) {
+			case " ", RecordTypeDetail:
+				details++
+				copy(line[recordTypeBegin:], RecordTypeDetail)
+				setNumber(line, sequenceNumberBegin, sequenceNumberEnd, details)
+			case RecordTypeInitial, RecordTypeFinal:
+				// the batch initial and final records keep their record type
+			default:
+				return nil, lineError(records-len(lines)+1, ErrUnexpectedRecord)
+			}
+			lines = lines[1:]
+		}
+
+		line = lines[0]
+		setNumber(line, batchNumberBegin, batchNumberEnd, batchNumber)
+		copy(line[recordTypeBegin:], RecordTypeBatchTrailer)
+		begin, end := batchRecordsPosition(file.Batches[i].Trailer)
+		setNumber(line, begin, end, batchSegments[i]+2)
+		lines = lines[1:]
+	}
+
+	line = lines[0]
+	setNumber(line, batchNumberBegin, batchNumberEnd, fileTrailerBatchNumber)
+	copy(line[recordTypeBegin:], RecordTypeFileTrailer)
+	setNumber(line, fileBatchesBegin, fileBatchesEnd, len(file.Batches))
+	setNumber(line, fileRecordsBegin, fileRecordsEnd, records)
+
+	return data, nil
+}
+
+// Unmarshal parses the CNAB 240 data into a file, using the mapper to decode
+// each line. The mapper must have all the record types registered (file and
+// batch headers and trailers, and the detail segments), and the registered
+// pointers are only used to detect the record types, as in
+// gocnab.Mapper.Decode.
+//
+// The structure of the file is built from the record type of each line, and
+// the batch numbers, sequence numbers and trailer counters are verified.
+// Records in the positions of the batch initial (2) and final (4) records are
+// added to the batch segments.
+func Unmarshal(data []byte, mapper *gocnab.Mapper, options ...gocnab.UnmarshalOptionFunc) (File, error) {
+	records, err := mapper.Decode(data, options...)
+	if err != nil {
+		return File{}, err
+	}
+
+	decoded := make(map[int]interface{}, len(records))
+	for _, record := range records {
+		decoded[record.Line] = record.Value
+	}
+
+	var file File
+	var batch *Batch
+	var batchRecords, batchDetails, fileRecords int
+	var hasHeader, hasTrailer bool
+
+	lines := bytes.Split(bytes.TrimSuffix(data, []byte(gocnab.FinalControlCharacter)), []byte(gocnab.LineBreak))
+	for i, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+
+		lineNumber := i + 1
+		if len(line) < recordTypeEnd || hasTrailer {
+			return File{}, lineError(lineNumber, ErrUnexpectedRecord)
+		}
+
+		fileRecords++
+		batchNumber := len(file.Batches)
+		if batch != nil {
+			batchNumber++
+		}
+
+		switch recordType := string(line[recordTypeBegin:recordTypeEnd]); {
+		case !hasHeader:
+			if recordType != RecordTypeFileHeader {
+				return File{}, lineError(lineNumber, ErrUnexpectedRecord)
+			}
+
+			hasHeader = true
+			file.Header = decoded[lineNumber]
+
+		case recordType == RecordTypeBatchHeader:
+			if batch != nil {
+				return File{}, lineError(lineNumber, ErrUnexpectedRecord)
+			}
+
+			batch = &Batch{
+				Header: decoded[lineNumber],
+			}
+			batchRecords = 1
+			batchDetails = 0
+
+			if !checkNumber(line, batchNumberBegin, batchNumberEnd, batchNumber+1) {
+				return File{}, lineError(lineNumber, ErrSequenceMismatch)
+			}
+
+		case recordType == RecordTypeInitial || recordType == RecordTypeDetail || recordType == RecordTypeFinal:
+			if batch == nil {
+				return File{}, lineError(lineNumber, ErrUnexpectedRecord)
+			}
+
+			batchRecords++
+			if value, ok := decoded[lineNumber]; ok {
+				batch.Segments = append(batch.Segments, value)
+			}
+
+			// only the detail records are numbered
+			if recordType == RecordTypeDetail {
+				batchDetails++
+			}
+
+			if !checkNumber(line, batchNumberBegin, batchNumberEnd, batchNumber) ||
+				(recordType == RecordTypeDetail && !checkNumber(line, sequenceNumberBegin, sequenceNumberEnd, batchDetails)) {
+				return File{}, lineError(lineNumber, ErrSequenceMismatch)
+			}
+
+		case recordType == RecordTypeBatchTrailer:
+			if batch == nil {
+				return File{}, lineError(lineNumber, ErrUnexpectedRecord)
+			}
+
+			batchRecords++
+			batch.Trailer = decoded[lineNumber]
+
+			if !checkNumber(line, batchNumberBegin, batchNumberEnd, batchNumber) {
+				return File{}, lineError(lineNumber, ErrSequenceMismatch)
+			}
+
+			begin, end := batchRecordsPosition(batch.Trailer)
+			if !checkNumber(line, begin, end, batchRecords) {
+				return File{}, lineError(lineNumber, ErrRecordCountMismatch)
+			}
+
+			file.Batches = append(file.Batches, *batch)
+			batch = nil
+
+		case recordType == RecordTypeFileTrailer:
+			if batch != nil {
+				return File{}, lineError(lineNumber, ErrUnexpectedRecord)
+			}
+
+			hasTrailer = true
+			file.Trailer = decoded[lineNumber]
+
+			if !checkNumber(line, fileBatchesBegin, fileBatchesEnd, len(file.Batches)) ||
+				!checkNumber(line, fileRecordsBegin, fileRecordsEnd, fileRecords) {
+				return File{}, lineError(lineNumber, ErrRecordCountMismatch)
+			}
+
+		default:
+			return File{}, lineError(lineNumber, ErrUnexpectedRecord)
+		}
+	}
+
+	if !hasHeader || batch != nil || !hasTrailer {
+		return File{}, ErrMissingRecord
+	}
+
+	return file, nil
+}
+
+// setNumber writes the number right aligned with zeros in the range.
+func setNumber(line []byte, begin, end int, number int) {
+	copy(line[begin:end], fmt.Sprintf("%0*d", end-begin, number))
+}
+
+// checkNumber verifies if the range contains the expected number.
+func checkNumber(line []byte, begin, end int, expected int) bool {
+	if len(line) < end {
+		return false
+	}
+
+	number, err := strconv.Atoi(string(line[begin:end]))
+	return err == nil && number == expected
+}
+
+func lineError(line int, err error) error {
+	return gocnab.LineError{
+		Line: line,
+		Err:  err,
+	}
+}
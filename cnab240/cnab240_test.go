@@ -0,0 +1,518 @@
+package cnab240_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+)
+
+type fileHeader struct {
+	Bank    int    `cnab:"0,3"`
+	Company string `cnab:"72,102"`
+}
+
+type batchHeader struct {
+	Bank      int    `cnab:"0,3"`
+	Operation string `cnab:"8,9"`
+}
+
+type segmentP struct {
+	Bank    int     `cnab:"0,3"`
+	Segment string  `cnab:"13,14,const=P"`
+	Amount  float64 `cnab:"85,100"`
+}
+
+type segmentQ struct {
+	Bank    int    `cnab:"0,3"`
+	Segment string `cnab:"13,14,const=Q"`
+	Name    string `cnab:"33,73"`
+}
+
+type batchTrailer struct {
+	Bank  int `cnab:"0,3"`
+	Count int `cnab:"17,23"`
+}
+
+type fileTrailer struct {
+	Bank int `cnab:"0,3"`
+}
+
+func newMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*fileHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileHeader))
+	mapper.Register((*batchHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchHeader))
+	mapper.Register((*segmentP)(nil), cnab240.MatchSegment("P"))
+	mapper.Register((*segmentQ)(nil), cnab240.MatchSegment("Q"))
+	mapper.Register((*batchTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchTrailer))
+	mapper.Register((*fileTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileTrailer))
+	return mapper
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	file := cnab240.File{
+		Header: fileHeader{Bank: 237, Company: "COMPANY"},
+		Batches: []cnab240.Batch{
+			{
+				Header: batchHeader{Bank: 237, Operation: "R"},
+				Segments: []cnab240.Segment{
+					segmentP{Bank: 237, Segment: "P", Amount: 10.5},
+					segmentQ{Bank: 237, Segment: "Q", Name: "PAYER 1"},
+				},
+				Trailer: batchTrailer{Bank: 237},
+			},
+			{
+				Header: batchHeader{Bank: 237, Operation: "R"},
+				Segments: []cnab240.Segment{
+					segmentP{Bank: 237, Segment: "P", Amount: 20.5},
+				},
+				Trailer: batchTrailer{Bank: 237},
+			},
+		},
+		Trailer: fileTrailer{Bank: 237},
+	}
+
+	data, err := cnab240.Marshal(file)
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), gocnab.FinalControlCharacter), gocnab.LineBreak)
+	expectedControls := []string{
+		"23700000",
+		"23700011",
+		"2370001300001P",
+		"2370001300002Q",
+		"23700015         000004",
+		"23700021",
+		"2370002300001P",
+		"23700025         000003",
+		"23799999         000002000009",
+	}
+
+	if len(expectedControls) != len(lines) {
+		t.Fatalf("expected %d lines and got %d", len(expectedControls), len(lines))
+	}
+
+	for i, line := range lines {
+		if !strings.HasPrefix(line, expectedControls[i]) {
+			t.Errorf("expected line %d to start with “%s” and got “%s”", i+1, expectedControls[i], line[:30])
+		}
+	}
+
+	decoded, err := cnab240.Unmarshal(data, newMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	// the batch trailer counter is filled on marshal
+	file.Batches[0].Trailer = batchTrailer{Bank: 237, Count: 4}
+	file.Batches[1].Trailer = batchTrailer{Bank: 237, Count: 3}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+}
+
+type boleto struct {
+	P segmentP  `cnab:"line"`
+	Q *segmentQ `cnab:"line"`
+}
+
+func TestMarshalUnmarshal_composite(t *testing.T) {
+	t.Parallel()
+
+	file := cnab240.File{
+		Header: fileHeader{Bank: 237, Company: "COMPANY"},
+		Batches: []cnab240.Batch{
+			{
+				Header: batchHeader{Bank: 237, Operation: "R"},
+				Segments: []cnab240.Segment{
+					boleto{
+						P: segmentP{Bank: 237, Segment: "P", Amount: 10.5},
+						Q: &segmentQ{Bank: 237, Segment: "Q", Name: "PAYER 1"},
+					},
+					boleto{
+						P: segmentP{Bank: 237, Segment: "P", Amount: 20.5},
+					},
+				},
+				Trailer: batchTrailer{Bank: 237},
+			},
+		},
+		Trailer: fileTrailer{Bank: 237},
+	}
+
+	data, err := cnab240.Marshal(file)
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), gocnab.FinalControlCharacter), gocnab.LineBreak)
+	expectedControls := []string{
+		"23700000",
+		"23700011",
+		"2370001300001P",
+		"2370001300002Q",
+		"2370001300003P",
+		"23700015         000005",
+		"23799999         000001000007",
+	}
+
+	if len(expectedControls) != len(lines) {
+		t.Fatalf("expected %d lines and got %d", len(expectedControls), len(lines))
+	}
+
+	for i, line := range lines {
+		if !strings.HasPrefix(line, expectedControls[i]) {
+			t.Errorf("expected line %d to start with “%s” and got “%s”", i+1, expectedControls[i], line[:30])
+		}
+	}
+
+	mapper := gocnab.NewMapper()
+	mapper.Register((*fileHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileHeader))
+	mapper.Register((*batchHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchHeader))
+	mapper.Register((*boleto)(nil), cnab240.MatchSegment("P"))
+	mapper.Register((*batchTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchTrailer))
+	mapper.Register((*fileTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileTrailer))
+
+	decoded, err := cnab240.Unmarshal(data, mapper)
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	// the batch trailer counter is filled on marshal
+	file.Batches[0].Trailer = batchTrailer{Bank: 237, Count: 5}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+}
+
+type positionedBatchTrailer struct {
+	Bank     int    `cnab:"0,3"`
+	Document string `cnab:"17,32"`
+	Count    int    `cnab:"170,176"`
+}
+
+func (positionedBatchTrailer) BatchRecordsPosition() (begin, end int) {
+	return 170, 176
+}
+
+func TestMarshalUnmarshal_batchRecordsPosition(t *testing.T) {
+	t.Parallel()
+
+	file := cnab240.File{
+		Header: fileHeader{Bank: 237, Company: "COMPANY"},
+		Batches: []cnab240.Batch{
+			{
+				Header: batchHeader{Bank: 237, Operation: "E"},
+				Segments: []cnab240.Segment{
+					segmentP{Bank: 237, Segment: "P", Amount: 10.5},
+				},
+				Trailer: positionedBatchTrailer{Bank: 237, Document: "212345678000195"},
+			},
+		},
+		Trailer: fileTrailer{Bank: 237},
+	}
+
+	data, err := cnab240.Marshal(file)
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), gocnab.FinalControlCharacter), gocnab.LineBreak)
+	if trailer := lines[3]; trailer[17:32] != "212345678000195" || trailer[170:176] != "000003" {
+		t.Errorf("unexpected batch trailer “%s”", trailer)
+	}
+
+	mapper := gocnab.NewMapper()
+	mapper.Register((*fileHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileHeader))
+	mapper.Register((*batchHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchHeader))
+	mapper.Register((*segmentP)(nil), cnab240.MatchSegment("P"))
+	mapper.Register((*positionedBatchTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchTrailer))
+	mapper.Register((*fileTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileTrailer))
+
+	decoded, err := cnab240.Unmarshal(data, mapper)
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	// the batch trailer counter is filled on marshal
+	file.Batches[0].Trailer = positionedBatchTrailer{Bank: 237, Document: "212345678000195", Count: 3}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+
+	// a wrong counter in the custom position is detected
+	wrong := []byte(strings.Replace(string(data), "000003", "000004", 1))
+	if _, err = cnab240.Unmarshal(wrong, mapper); !errors.Is(err, cnab240.ErrRecordCountMismatch) {
+		t.Errorf("expected a record count mismatch and got “%v”", err)
+	}
+}
+
+type initialRecord struct {
+	Bank int    `cnab:"0,3"`
+	_    string `cnab:"7,8,const=2"`
+	Info string `cnab:"17,57"`
+}
+
+type finalRecord struct {
+	Bank  int     `cnab:"0,3"`
+	_     string  `cnab:"7,8,const=4"`
+	Total float64 `cnab:"17,35"`
+}
+
+func TestMarshalUnmarshal_initialAndFinalRecords(t *testing.T) {
+	t.Parallel()
+
+	file := cnab240.File{
+		Header: fileHeader{Bank: 237, Company: "COMPANY"},
+		Batches: []cnab240.Batch{
+			{
+				Header: batchHeader{Bank: 237, Operation: "R"},
+				Segments: []cnab240.Segment{
+					initialRecord{Bank: 237, Info: "INITIAL"},
+					segmentP{Bank: 237, Segment: "P", Amount: 10.5},
+					segmentQ{Bank: 237, Segment: "Q", Name: "PAYER 1"},
+					finalRecord{Bank: 237, Total: 10.5},
+				},
+				Trailer: batchTrailer{Bank: 237},
+			},
+		},
+		Trailer: fileTrailer{Bank: 237},
+	}
+
+	data, err := cnab240.Marshal(file)
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), gocnab.FinalControlCharacter), gocnab.LineBreak)
+	expectedControls := []string{
+		"23700000",
+		"23700011",
+		"23700012     ",
+		"2370001300001P",
+		"2370001300002Q",
+		"23700014     ",
+		"23700015         000006",
+		"23799999         000001000008",
+	}
+
+	if len(expectedControls) != len(lines) {
+		t.Fatalf("expected %d lines and got %d", len(expectedControls), len(lines))
+	}
+
+	for i, line := range lines {
+		if !strings.HasPrefix(line, expectedControls[i]) {
+			t.Errorf("expected line %d to start with “%s” and got “%s”", i+1, expectedControls[i], line[:30])
+		}
+	}
+
+	mapper := newMapper()
+	mapper.Register((*initialRecord)(nil), cnab240.MatchRecordType(cnab240.RecordTypeInitial))
+	mapper.Register((*finalRecord)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFinal))
+
+	decoded, err := cnab240.Unmarshal(data, mapper)
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	// the batch trailer counter is filled on marshal
+	file.Batches[0].Trailer = batchTrailer{Bank: 237, Count: 6}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+}
+
+func TestMarshal_unexpectedSegmentRecordType(t *testing.T) {
+	t.Parallel()
+
+	type trailerAsSegment struct {
+		Bank int    `cnab:"0,3"`
+		_    string `cnab:"7,8,const=5"`
+	}
+
+	file := cnab240.File{
+		Header: fileHeader{Bank: 237},
+		Batches: []cnab240.Batch{
+			{
+				Header: batchHeader{Bank: 237},
+				Segments: []cnab240.Segment{
+					segmentP{Bank: 237, Segment: "P"},
+					trailerAsSegment{Bank: 237},
+				},
+				Trailer: batchTrailer{Bank: 237},
+			},
+		},
+		Trailer: fileTrailer{Bank: 237},
+	}
+
+	expectedError := gocnab.LineError{
+		Line: 4,
+		Err:  cnab240.ErrUnexpectedRecord,
+	}
+
+	if _, err := cnab240.Marshal(file); !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected error “%v” and got “%v”", expectedError, err)
+	}
+}
+
+func TestMarshal_missingRecord(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		file        cnab240.File
+	}{
+		{
+			description: "it should detect a file without header",
+			file: cnab240.File{
+				Trailer: fileTrailer{},
+			},
+		},
+		{
+			description: "it should detect a batch without trailer",
+			file: cnab240.File{
+				Header: fileHeader{},
+				Batches: []cnab240.Batch{
+					{Header: batchHeader{}},
+				},
+				Trailer: fileTrailer{},
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			if _, err := cnab240.Marshal(scenario.file); err != cnab240.ErrMissingRecord {
+				t.Errorf("expected error “%v” and got “%v”", cnab240.ErrMissingRecord, err)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_invalidStructure(t *testing.T) {
+	t.Parallel()
+
+	line := func(prefix string) string {
+		return fmt.Sprintf("%-240s", prefix)
+	}
+
+	scenarios := []struct {
+		description   string
+		lines         []string
+		expectedError error
+	}{
+		{
+			description: "it should detect a detail outside of a batch",
+			lines: []string{
+				line("23700000"),
+				line("2370001300001P"),
+			},
+			expectedError: gocnab.LineError{Line: 2, Err: cnab240.ErrUnexpectedRecord},
+		},
+		{
+			description: "it should detect a wrong sequence number",
+			lines: []string{
+				line("23700000"),
+				line("23700011"),
+				line("2370001300002P"),
+			},
+			expectedError: gocnab.LineError{Line: 3, Err: cnab240.ErrSequenceMismatch},
+		},
+		{
+			description: "it should detect a wrong batch number",
+			lines: []string{
+				line("23700000"),
+				line("23700021"),
+			},
+			expectedError: gocnab.LineError{Line: 2, Err: cnab240.ErrSequenceMismatch},
+		},
+		{
+			description: "it should detect a wrong batch counter",
+			lines: []string{
+				line("23700000"),
+				line("23700011"),
+				line("2370001300001P"),
+				line("23700015         000004"),
+			},
+			expectedError: gocnab.LineError{Line: 4, Err: cnab240.ErrRecordCountMismatch},
+		},
+		{
+			description: "it should detect a wrong file counter",
+			lines: []string{
+				line("23700000"),
+				line("23700011"),
+				line("2370001300001P"),
+				line("23700015         000003"),
+				line("23799999         000001000004"),
+			},
+			expectedError: gocnab.LineError{Line: 5, Err: cnab240.ErrRecordCountMismatch},
+		},
+		{
+			description: "it should detect a line after the file trailer",
+			lines: []string{
+				line("23700000"),
+				line("23799999         000000000002"),
+				line("23700011"),
+			},
+			expectedError: gocnab.LineError{Line: 3, Err: cnab240.ErrUnexpectedRecord},
+		},
+		{
+			description: "it should detect a file without trailer",
+			lines: []string{
+				line("23700000"),
+				line("23700011"),
+				line("23700015         000002"),
+			},
+			expectedError: cnab240.ErrMissingRecord,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			data := []byte(strings.Join(scenario.lines, gocnab.LineBreak))
+			if _, err := cnab240.Unmarshal(data, newMapper()); !reflect.DeepEqual(scenario.expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func ExampleMarshal() {
+	type record struct {
+		Bank int `cnab:"0,3"`
+	}
+
+	data, _ := cnab240.Marshal(cnab240.File{
+		Header: record{Bank: 1},
+		Batches: []cnab240.Batch{
+			{
+				Header:   record{Bank: 1},
+				Segments: []cnab240.Segment{record{Bank: 1}},
+				Trailer:  record{Bank: 1},
+			},
+		},
+		Trailer: record{Bank: 1},
+	}, gocnab.WithFinalControlCharacter(false))
+
+	for _, line := range bytes.Split(data, []byte(gocnab.LineBreak)) {
+		fmt.Println(strings.TrimSpace(string(line[:29])))
+	}
+
+	// Output: 00100000
+	// 00100011
+	// 0010001300001
+	// 00100015         000003
+	// 00199999         000001000005
+}
@@ -0,0 +1,63 @@
+package cnab240
+
+import (
+	"time"
+
+	"github.com/rafaeljusto/gocnab/cnabdate"
+)
+
+// dateFormat is the format of the dates in CNAB 240 records (DDMMAAAA).
+const dateFormat = "02012006"
+
+// timeFormat is the format of the times in CNAB 240 records (HHMMSS).
+const timeFormat = "150405"
+
+// Date is a date in the format DDMMAAAA, used by the CNAB 240 records. The zero
+// value is encoded as zeros, which is how the layouts represent an absent
+// date, and blank dates are decoded as the zero value.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the date of the day, month and year in UTC.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{
+		Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// MarshalCNAB encodes the date in the format DDMMAAAA.
+func (d Date) MarshalCNAB() ([]byte, error) {
+	return cnabdate.Format(d.Time, dateFormat), nil
+}
+
+// UnmarshalCNAB decodes a date in the format DDMMAAAA.
+func (d *Date) UnmarshalCNAB(data []byte) (err error) {
+	d.Time, err = cnabdate.Parse(data, dateFormat)
+	return err
+}
+
+// Time is a time of the day in the format HHMMSS, used by the CNAB 240
+// records. The zero value is encoded as zeros, so midnight is decoded as the
+// zero value.
+type Time struct {
+	time.Time
+}
+
+// NewTime returns the time of the day in UTC.
+func NewTime(hour, minute, second int) Time {
+	return Time{
+		Time: time.Date(0, time.January, 1, hour, minute, second, 0, time.UTC),
+	}
+}
+
+// MarshalCNAB encodes the time in the format HHMMSS.
+func (t Time) MarshalCNAB() ([]byte, error) {
+	return cnabdate.Format(t.Time, timeFormat), nil
+}
+
+// UnmarshalCNAB decodes a time in the format HHMMSS.
+func (t *Time) UnmarshalCNAB(data []byte) (err error) {
+	t.Time, err = cnabdate.Parse(data, timeFormat)
+	return err
+}
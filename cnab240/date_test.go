@@ -0,0 +1,86 @@
+package cnab240_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab/cnab240"
+)
+
+func TestDate(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		data        string
+		expected    cnab240.Date
+	}{
+		{
+			description: "it should decode a date",
+			data:        "18102026",
+			expected:    cnab240.NewDate(2026, 10, 18),
+		},
+		{
+			description: "it should decode an absent date",
+			data:        "00000000",
+		},
+		{
+			description: "it should decode a blank date",
+			data:        "        ",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			var date cnab240.Date
+			if err := date.UnmarshalCNAB([]byte(scenario.data)); err != nil {
+				t.Fatalf("unexpected error. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(scenario.expected, date) {
+				t.Errorf("expected date “%v” and got “%v”", scenario.expected, date)
+			}
+
+			data, err := date.MarshalCNAB()
+			if err != nil {
+				t.Fatalf("unexpected error. details: %s", err)
+			}
+
+			expected := scenario.data
+			if date.IsZero() {
+				expected = "00000000"
+			}
+
+			if expected != string(data) {
+				t.Errorf("expected data “%s” and got “%s”", expected, string(data))
+			}
+		})
+	}
+
+	var date cnab240.Date
+	if err := date.UnmarshalCNAB([]byte("32132026")); err == nil {
+		t.Error("expected an error for an invalid date")
+	}
+}
+
+func TestTime(t *testing.T) {
+	t.Parallel()
+
+	var value cnab240.Time
+	if err := value.UnmarshalCNAB([]byte("153045")); err != nil {
+		t.Fatalf("unexpected error. details: %s", err)
+	}
+
+	if expected := cnab240.NewTime(15, 30, 45); !reflect.DeepEqual(expected, value) {
+		t.Errorf("expected time “%v” and got “%v”", expected, value)
+	}
+
+	data, err := cnab240.Time{}.MarshalCNAB()
+	if err != nil {
+		t.Fatalf("unexpected error. details: %s", err)
+	}
+
+	if string(data) != "000000" {
+		t.Errorf("expected data “000000” and got “%s”", string(data))
+	}
+}
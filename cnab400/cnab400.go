@@ -0,0 +1,193 @@
+// Package cnab400 implements the structure of a CNAB 400 file, as defined by
+// FEBRABAN. A CNAB 400 file contains a header, the detail records (that could
+// have different sub-types, like messages or apportionment records following
+// a title) and a trailer.
+//
+// Every CNAB 400 line has a sequential record number in the positions
+// [394,400), that is automatically filled when marshaling and verified when
+// unmarshaling, so the record types don't need to fill it.
+package cnab400
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/rafaeljusto/gocnab"
+)
+
+// LineSize number of characters of each CNAB 400 line.
+const LineSize = 400
+
+// Record types, stored in the first position of every line. The detail record
+// types vary for each bank.
+const (
+	RecordTypeHeader  = "0"
+	RecordTypeTrailer = "9"
+)
+
+// Positions of the control fields, following the same convention of the CNAB
+// tag.
+const (
+	recordTypeBegin     = 0
+	recordTypeEnd       = 1
+	sequenceNumberBegin = 394
+	sequenceNumberEnd   = 400
+)
+
+var (
+	// ErrMissingRecord raised when the file doesn't have the header or the
+	// trailer.
+	ErrMissingRecord = errors.New("missing header or trailer record")
+
+	// ErrUnexpectedRecord record type isn't expected in the current position of
+	// the file (e.g. a header after the first line).
+	ErrUnexpectedRecord = errors.New("unexpected record type")
+
+	// ErrSequenceMismatch sequential number of a record doesn't match its
+	// position in the file.
+	ErrSequenceMismatch = errors.New("sequence number doesn't match the record position")
+)
+
+// File is a CNAB 400 file.
+type File struct {
+	Header  interface{}
+	Details []Detail
+	Trailer interface{}
+}
+
+// Detail is a detail record of the file. Any type supported by
+// gocnab.Marshal400 can be used, so different detail sub-types can be mixed in
+// the same file. Composite records generate consecutive detail lines, each
+// one with its own sequence number.
+type Detail interface{}
+
+// MatchRecordType detects lines of a record type, to be used when registering
+// the record types in the mapper.
+func MatchRecordType(recordType string) gocnab.Discriminator {
+	return gocnab.MatchRange(recordTypeBegin, recordTypeEnd, recordType)
+}
+
+// Marshal returns the CNAB 400 encoding of the file. The record type of the
+// header and trailer, and the sequential number of every line are
+// automatically filled.
+func Marshal(file File, options ...gocnab.MarshalOptionFunc) ([]byte, error) {
+	if file.Header == nil || file.Trailer == nil {
+		return nil, ErrMissingRecord
+	}
+
+	vs := []interface{}{file.Header}
+	for _, detail := range file.Details {
+		// each line of a composite record is a detail of the file
+		lines, err := gocnab.CompositeRecords(detail)
+		if err != nil {
+			return nil, err
+		}
+
+		vs = append(vs, lines...)
+	}
+	vs = append(vs, file.Trailer)
+
+	records := len(vs)
+	for _, option := range options {
+		vs = append(vs, option)
+	}
+
+	data, err := gocnab.Marshal400(vs...)
+	if err != nil {
+		return nil, err
+	}
+
+	// the lines share the same memory of data, so the control fields can be
+	// changed directly in the lines
+	lines := bytes.Split(bytes.TrimSuffix(data, []byte(gocnab.FinalControlCharacter)), []byte(gocnab.LineBreak))
+	if len(lines) != records {
+		return nil, gocnab.ErrUnsupportedType
+	}
+
+	copy(lines[0][recordTypeBegin:], RecordTypeHeader)
+	copy(lines[len(lines)-1][recordTypeBegin:], RecordTypeTrailer)
+
+	for i, line := range lines {
+		copy(line[sequenceNumberBegin:sequenceNumberEnd], fmt.Sprintf("%06d", i+1))
+	}
+
+	return data, nil
+}
+
+// Unmarshal parses the CNAB 400 data into a file, using the mapper to decode
+// each line. The mapper must have all the record types registered, and the
+// registered pointers are only used to detect the record types, as in
+// gocnab.Mapper.Decode. The sequential number of every line is verified.
+func Unmarshal(data []byte, mapper *gocnab.Mapper, options ...gocnab.UnmarshalOptionFunc) (File, error) {
+	records, err := mapper.Decode(data, options...)
+	if err != nil {
+		return File{}, err
+	}
+
+	decoded := make(map[int]interface{}, len(records))
+	for _, record := range records {
+		decoded[record.Line] = record.Value
+	}
+
+	var file File
+	var sequence int
+	var hasHeader, hasTrailer bool
+
+	lines := bytes.Split(bytes.TrimSuffix(data, []byte(gocnab.FinalControlCharacter)), []byte(gocnab.LineBreak))
+	for i, line := range lines {
+		if len(line) == 0 {
+			continue
+		}
+
+		lineNumber := i + 1
+		if hasTrailer {
+			return File{}, lineError(lineNumber, ErrUnexpectedRecord)
+		}
+
+		sequence++
+		if len(line) < sequenceNumberEnd {
+			return File{}, lineError(lineNumber, ErrSequenceMismatch)
+		}
+
+		if number, err := strconv.Atoi(string(line[sequenceNumberBegin:sequenceNumberEnd])); err != nil || number != sequence {
+			return File{}, lineError(lineNumber, ErrSequenceMismatch)
+		}
+
+		switch recordType := string(line[recordTypeBegin:recordTypeEnd]); {
+		case !hasHeader:
+			if recordType != RecordTypeHeader {
+				return File{}, lineError(lineNumber, ErrUnexpectedRecord)
+			}
+
+			hasHeader = true
+			file.Header = decoded[lineNumber]
+
+		case recordType == RecordTypeHeader:
+			return File{}, lineError(lineNumber, ErrUnexpectedRecord)
+
+		case recordType == RecordTypeTrailer:
+			hasTrailer = true
+			file.Trailer = decoded[lineNumber]
+
+		default:
+			if value, ok := decoded[lineNumber]; ok {
+				file.Details = append(file.Details, value)
+			}
+		}
+	}
+
+	if !hasHeader || !hasTrailer {
+		return File{}, ErrMissingRecord
+	}
+
+	return file, nil
+}
+
+func lineError(line int, err error) error {
+	return gocnab.LineError{
+		Line: line,
+		Err:  err,
+	}
+}
@@ -0,0 +1,168 @@
+package cnab400_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab400"
+)
+
+type header struct {
+	Identifier string `cnab:"0,1"`
+	Operation  string `cnab:"1,2,const=1"`
+	Company    string `cnab:"46,76"`
+}
+
+type detail struct {
+	Identifier string  `cnab:"0,1,const=1"`
+	OurNumber  string  `cnab:"70,82"`
+	Amount     float64 `cnab:"126,139"`
+}
+
+type message struct {
+	Identifier string `cnab:"0,1,const=2"`
+	Message    string `cnab:"1,81"`
+}
+
+type trailer struct {
+	Identifier string `cnab:"0,1"`
+}
+
+func newMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*header)(nil), cnab400.MatchRecordType(cnab400.RecordTypeHeader))
+	mapper.Register((*detail)(nil), cnab400.MatchRecordType("1"))
+	mapper.Register((*message)(nil), cnab400.MatchRecordType("2"))
+	mapper.Register((*trailer)(nil), cnab400.MatchRecordType(cnab400.RecordTypeTrailer))
+	return mapper
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	file := cnab400.File{
+		Header: header{Company: "COMPANY"},
+		Details: []cnab400.Detail{
+			detail{Identifier: "1", OurNumber: "000000000001", Amount: 10.5},
+			message{Identifier: "2", Message: "PAY UNTIL THE DUE DATE"},
+			detail{Identifier: "1", OurNumber: "000000000002", Amount: 20.5},
+		},
+		Trailer: trailer{},
+	}
+
+	data, err := cnab400.Marshal(file, gocnab.WithFinalControlCharacter(false))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	lines := strings.Split(string(data), gocnab.LineBreak)
+	if len(lines) != 5 {
+		t.Fatalf("expected 5 lines and got %d", len(lines))
+	}
+
+	for i, line := range lines {
+		if expected := fmt.Sprintf("%06d", i+1); line[394:] != expected {
+			t.Errorf("expected sequence “%s” in line %d and got “%s”", expected, i+1, line[394:])
+		}
+	}
+
+	if lines[0][0] != '0' || lines[4][0] != '9' {
+		t.Errorf("expected header and trailer record types to be filled")
+	}
+
+	decoded, err := cnab400.Unmarshal(data, newMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	// the record types are filled on marshal
+	file.Header = header{Identifier: "0", Operation: "1", Company: "COMPANY"}
+	file.Trailer = trailer{Identifier: "9"}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+}
+
+func TestMarshal_missingRecord(t *testing.T) {
+	t.Parallel()
+
+	if _, err := cnab400.Marshal(cnab400.File{Header: header{}}); err != cnab400.ErrMissingRecord {
+		t.Errorf("expected error “%v” and got “%v”", cnab400.ErrMissingRecord, err)
+	}
+}
+
+func TestUnmarshal_invalidStructure(t *testing.T) {
+	t.Parallel()
+
+	line := func(prefix string, sequence int) string {
+		return fmt.Sprintf("%-394s%06d", prefix, sequence)
+	}
+
+	scenarios := []struct {
+		description   string
+		lines         []string
+		expectedError error
+	}{
+		{
+			description: "it should detect a wrong sequence number",
+			lines: []string{
+				line("01", 1),
+				line("1", 3),
+			},
+			expectedError: gocnab.LineError{Line: 2, Err: cnab400.ErrSequenceMismatch},
+		},
+		{
+			description: "it should detect a short line",
+			lines: []string{
+				line("01", 1),
+				"3",
+			},
+			expectedError: gocnab.LineError{Line: 2, Err: cnab400.ErrSequenceMismatch},
+		},
+		{
+			description: "it should detect a file not starting with header",
+			lines: []string{
+				line("1", 1),
+			},
+			expectedError: gocnab.LineError{Line: 1, Err: cnab400.ErrUnexpectedRecord},
+		},
+		{
+			description: "it should detect a second header",
+			lines: []string{
+				line("01", 1),
+				line("01", 2),
+			},
+			expectedError: gocnab.LineError{Line: 2, Err: cnab400.ErrUnexpectedRecord},
+		},
+		{
+			description: "it should detect a line after the trailer",
+			lines: []string{
+				line("01", 1),
+				line("9", 2),
+				line("1", 3),
+			},
+			expectedError: gocnab.LineError{Line: 3, Err: cnab400.ErrUnexpectedRecord},
+		},
+		{
+			description: "it should detect a file without trailer",
+			lines: []string{
+				line("01", 1),
+				line("1", 2),
+			},
+			expectedError: cnab400.ErrMissingRecord,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			data := []byte(strings.Join(scenario.lines, gocnab.LineBreak))
+			if _, err := cnab400.Unmarshal(data, newMapper()); !reflect.DeepEqual(scenario.expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+		})
+	}
+}
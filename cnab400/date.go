@@ -0,0 +1,36 @@
+package cnab400
+
+import (
+	"time"
+
+	"github.com/rafaeljusto/gocnab/cnabdate"
+)
+
+// dateFormat is the format of the dates in CNAB 400 records (DDMMAA).
+const dateFormat = "020106"
+
+// Date is a date in the format DDMMAA, used by the CNAB 400 records. Years
+// from 69 to 99 are decoded in the 20th century and the others in the 21st.
+// The zero value is encoded as zeros, which is how the layouts represent an
+// absent date, and blank dates are decoded as the zero value.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the date of the day, month and year in UTC.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{
+		Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// MarshalCNAB encodes the date in the format DDMMAA.
+func (d Date) MarshalCNAB() ([]byte, error) {
+	return cnabdate.Format(d.Time, dateFormat), nil
+}
+
+// UnmarshalCNAB decodes a date in the format DDMMAA.
+func (d *Date) UnmarshalCNAB(data []byte) (err error) {
+	d.Time, err = cnabdate.Parse(data, dateFormat)
+	return err
+}
@@ -0,0 +1,61 @@
+// Package cnabdate contains the date types shared by the layouts, and the
+// functions to encode and decode dates in the CNAB formats.
+//
+// The CNAB layouts represent an absent date with zeros, so the zero value of
+// time.Time is encoded as zeros, and blank or zeroed dates are decoded as the
+// zero value.
+package cnabdate
+
+import (
+	"strings"
+	"time"
+)
+
+// dateFormat is the format of the dates with the year first (AAAAMMDD).
+const dateFormat = "20060102"
+
+// Date is a date in the format AAAAMMDD, used by some banks instead of the
+// dates of cnab240.Date (DDMMAAAA) and cnab400.Date (DDMMAA), and by the CNAB
+// 150 records.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the date of the day, month and year in UTC.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{
+		Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// MarshalCNAB encodes the date in the format AAAAMMDD.
+func (d Date) MarshalCNAB() ([]byte, error) {
+	return Format(d.Time, dateFormat), nil
+}
+
+// UnmarshalCNAB decodes a date in the format AAAAMMDD.
+func (d *Date) UnmarshalCNAB(data []byte) (err error) {
+	d.Time, err = Parse(data, dateFormat)
+	return err
+}
+
+// Format encodes the time with the layout of the time package, using zeros
+// for the zero value.
+func Format(t time.Time, layout string) []byte {
+	if t.IsZero() {
+		return []byte(strings.Repeat("0", len(layout)))
+	}
+
+	return []byte(t.Format(layout))
+}
+
+// Parse decodes the content with the layout of the time package in UTC. Blank
+// or zeroed content is decoded as the zero value.
+func Parse(data []byte, layout string) (time.Time, error) {
+	content := strings.TrimSpace(string(data))
+	if content == "" || strings.Trim(content, "0") == "" {
+		return time.Time{}, nil
+	}
+
+	return time.ParseInLocation(layout, content, time.UTC)
+}
@@ -0,0 +1,69 @@
+package cnabdate_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab/cnabdate"
+)
+
+func TestDate(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		data        string
+		expected    cnabdate.Date
+	}{
+		{
+			description: "it should decode a date",
+			data:        "20261018",
+			expected:    cnabdate.NewDate(2026, 10, 18),
+		},
+		{
+			description: "it should decode a date of the 20th century",
+			data:        "19991231",
+			expected:    cnabdate.NewDate(1999, 12, 31),
+		},
+		{
+			description: "it should decode an absent date",
+			data:        "00000000",
+		},
+		{
+			description: "it should decode a blank date",
+			data:        "        ",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			var date cnabdate.Date
+			if err := date.UnmarshalCNAB([]byte(scenario.data)); err != nil {
+				t.Fatalf("unexpected error. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(scenario.expected, date) {
+				t.Errorf("expected date “%v” and got “%v”", scenario.expected, date)
+			}
+
+			data, err := date.MarshalCNAB()
+			if err != nil {
+				t.Fatalf("unexpected error. details: %s", err)
+			}
+
+			expected := scenario.data
+			if date.IsZero() {
+				expected = "00000000"
+			}
+
+			if expected != string(data) {
+				t.Errorf("expected data “%s” and got “%s”", expected, string(data))
+			}
+		})
+	}
+
+	var date cnabdate.Date
+	if err := date.UnmarshalCNAB([]byte("20261332")); err == nil {
+		t.Error("expected an error for an invalid date")
+	}
+}
@@ -0,0 +1,430 @@
+package gocnab
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// ErrMissingCompositeLine raised when a required line of a composite record
+// isn't found in the lines following the first line of the record.
+var ErrMissingCompositeLine = errors.New("missing line of composite record")
+
+// compositeTag is the CNAB tag of the fields of a composite record.
+const compositeTag = "line"
+
+// compositeField is a line of a composite record, stored in a struct field.
+// Optional lines are stored in pointer fields.
+type compositeField struct {
+	name       string
+	index      int
+	optional   bool
+	recordType reflect.Type
+}
+
+type compositeCacheEntry struct {
+	fields []compositeField
+	err    error
+}
+
+// compositeCache stores the already parsed composite records by type.
+var compositeCache sync.Map
+
+// compositeLayout returns the lines of a composite record type. A struct is a
+// composite record when it has fields with the tag `cnab:"line"`, otherwise no
+// lines are returned.
+func compositeLayout(structType reflect.Type) ([]compositeField, error) {
+	if structType.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	if entry, ok := compositeCache.Load(structType); ok {
+		return entry.(compositeCacheEntry).fields, entry.(compositeCacheEntry).err
+	}
+
+	fields, err := buildCompositeLayout(structType)
+	compositeCache.Store(structType, compositeCacheEntry{
+		fields: fields,
+		err:    err,
+	})
+
+	return fields, err
+}
+
+func buildCompositeLayout(structType reflect.Type) ([]compositeField, error) {
+	var fields []compositeField
+	var invalidField string
+
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+
+		tag := structField.Tag.Get("cnab")
+		if tag == "" {
+			continue
+		}
+
+		if tag != compositeTag {
+			invalidField = structField.Name
+			continue
+		}
+
+		field := compositeField{
+			name:       structField.Name,
+			index:      i,
+			recordType: structField.Type,
+		}
+
+		if field.recordType.Kind() == reflect.Ptr {
+			field.optional = true
+			field.recordType = field.recordType.Elem()
+		}
+
+		// composite records can't be nested, each field must be a single line
+		if structField.PkgPath != "" || hasCompositeTag(field.recordType) ||
+			(field.recordType.Kind() != reflect.Struct && !reflect.PtrTo(field.recordType).Implements(lineUnmarshalerType)) {
+			return nil, FieldError{
+				Field: structField.Name,
+				Err:   ErrUnsupportedType,
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	// a composite record can't map positions of a line by itself
+	if fields != nil && invalidField != "" {
+		return nil, FieldError{
+			Field: invalidField,
+			Err:   ErrInvalidFieldTagFormat,
+		}
+	}
+
+	return fields, nil
+}
+
+func hasCompositeTag(recordType reflect.Type) bool {
+	if recordType.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < recordType.NumField(); i++ {
+		if recordType.Field(i).Tag.Get("cnab") == compositeTag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkCompositeLine detects aggregate options in a line of a composite
+// record. They aren't supported, as the aggregates are computed only for the
+// records of the file, and not for the lines of a record. Layout problems are
+// reported when the line is marshaled or unmarshaled.
+func checkCompositeLine(recordType reflect.Type, lineSize int) error {
+	if recordType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields, err := structLayout(recordType, lineSize)
+	if err != nil {
+		return nil
+	}
+
+	for _, field := range fields {
+		if field.options.aggregate != nil {
+			return FieldError{
+				Field: field.name,
+				Err:   ErrInvalidFieldTagOption,
+			}
+		}
+	}
+
+	return nil
+}
+
+// isComposite checks if the type is a valid composite record.
+func isComposite(recordType reflect.Type) bool {
+	fields, err := compositeLayout(recordType)
+	return fields != nil && err == nil
+}
+
+// CompositeRecords returns the records of each line of a composite record, in
+// the same order they are marshaled, and without the absent optional lines.
+// The gocnab.BeforeMarshaler hook of the composite record is executed before
+// reading the lines. When v isn't a composite record it is returned as the
+// only record.
+//
+// It is useful to control each line of a composite record, like filling the
+// sequence numbers of the lines.
+func CompositeRecords(v interface{}) ([]interface{}, error) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || !isComposite(rv.Type()) {
+		return []interface{}{v}, nil
+	}
+
+	lines, err := compositeLines(rv, true)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]interface{}, len(lines))
+	for i, line := range lines {
+		records[i] = line.Interface()
+	}
+
+	return records, nil
+}
+
+// compositeLines returns the records of each line of a composite record,
+// optionally executing the gocnab.BeforeMarshaler hook of the record before.
+func compositeLines(v reflect.Value, hook bool) ([]reflect.Value, error) {
+	fields, err := compositeLayout(v.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	beforeMarshalerType := reflect.TypeOf((*BeforeMarshaler)(nil)).Elem()
+	if hook && reflect.PtrTo(v.Type()).Implements(beforeMarshalerType) {
+		// work on a copy, so the hook doesn't change the caller's record
+		record := reflect.New(v.Type())
+		record.Elem().Set(v)
+		v = record.Elem()
+
+		if err = record.Interface().(BeforeMarshaler).BeforeMarshalCNAB(); err != nil {
+			return nil, RecordError{
+				Record: v.Type().String(),
+				Err:    err,
+			}
+		}
+	}
+
+	var lines []reflect.Value
+	for _, field := range fields {
+		fieldValue := v.Field(field.index)
+		if field.optional {
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		}
+
+		lines = append(lines, fieldValue)
+	}
+
+	if len(lines) == 0 {
+		return nil, RecordError{
+			Record: v.Type().String(),
+			Err:    ErrMissingCompositeLine,
+		}
+	}
+
+	return lines, nil
+}
+
+// marshalComposite builds the consecutive CNAB lines of a composite record.
+func marshalComposite(lineSize int, v reflect.Value) ([]byte, error) {
+	lines, err := compositeLines(v, true)
+	if err != nil {
+		return nil, err
+	}
+
+	cnabLines := make([][]byte, len(lines))
+	for i, line := range lines {
+		if err = checkCompositeLine(line.Type(), lineSize); err != nil {
+			return nil, err
+		}
+
+		if cnabLines[i], err = marshalRecord(lineSize, line); err != nil {
+			return nil, err
+		}
+	}
+
+	return bytes.Join(cnabLines, []byte(LineBreak)), nil
+}
+
+// unmarshalComposite decodes a composite record from the first lines. Each
+// line is stored in the next field of the record that matches it, skipping
+// absent optional lines. It returns the number of lines used by the record,
+// or the index of the line with problem when there's an error.
+func unmarshalComposite(lines [][]byte, v reflect.Value) (int, error) {
+	fields, err := compositeLayout(v.Type())
+	if err != nil {
+		return 0, err
+	}
+
+	var consumed int
+	for _, field := range fields {
+		fieldValue := v.Field(field.index)
+
+		if consumed >= len(lines) || !matchRecord(lines[consumed], field.recordType) {
+			if field.optional {
+				fieldValue.Set(reflect.Zero(fieldValue.Type()))
+				continue
+			}
+
+			if consumed >= len(lines) {
+				consumed = len(lines) - 1
+			}
+
+			return consumed, FieldError{
+				Field: field.name,
+				Err:   ErrMissingCompositeLine,
+			}
+		}
+
+		if err := checkCompositeLine(field.recordType, len(lines[consumed])); err != nil {
+			return consumed, err
+		}
+
+		record := reflect.New(field.recordType)
+		if err := unmarshalRecord(lines[consumed], record.Elem()); err != nil {
+			return consumed, err
+		}
+
+		if field.optional {
+			fieldValue.Set(record)
+		} else {
+			fieldValue.Set(record.Elem())
+		}
+		consumed++
+	}
+
+	// all the lines are optional and none was found
+	if consumed == 0 {
+		return 0, RecordError{
+			Record: v.Type().String(),
+			Err:    ErrMissingCompositeLine,
+		}
+	}
+
+	if v.CanAddr() {
+		if afterUnmarshaler, ok := v.Addr().Interface().(AfterUnmarshaler); ok {
+			if err = afterUnmarshaler.AfterUnmarshalCNAB(); err != nil {
+				return consumed - 1, RecordError{
+					Record: v.Type().String(),
+					Err:    err,
+				}
+			}
+		}
+	}
+
+	return consumed, nil
+}
+
+// unmarshalRecordLines decodes the first lines into a record, returning the
+// number of lines used by the record, or the index of the line with problem
+// when there's an error. Only composite records use more than one line.
+func unmarshalRecordLines(lines [][]byte, v reflect.Value) (int, error) {
+	if fields, err := compositeLayout(v.Type()); err != nil {
+		return 0, err
+	} else if fields != nil {
+		return unmarshalComposite(lines, v)
+	}
+
+	if err := unmarshalRecord(lines[0], v); err != nil {
+		return 0, err
+	}
+
+	return 1, nil
+}
+
+// unmarshalCompositeLines decodes all the lines of data into a single
+// composite record.
+func unmarshalCompositeLines(data []byte, v reflect.Value) error {
+	cnabLines := nonEmptyLines(data)
+	lines := lineData(cnabLines)
+	if len(lines) == 0 {
+		return RecordError{
+			Record: v.Type().String(),
+			Err:    ErrMissingCompositeLine,
+		}
+	}
+
+	consumed, err := unmarshalComposite(lines, v)
+	if err != nil {
+		return err
+	}
+
+	if consumed < len(lines) {
+		return LineError{
+			Line: cnabLines[consumed].number,
+			Err:  ErrUnknownLine,
+		}
+	}
+
+	return nil
+}
+
+// unmarshalCompositeSlice decodes the lines of data into composite records,
+// where each record uses as many lines as it needs.
+func unmarshalCompositeSlice(data []byte, v reflect.Value) error {
+	lines := lineData(nonEmptyLines(data))
+	for len(lines) > 0 {
+		itemValue := reflect.New(v.Type().Elem())
+		consumed, err := unmarshalComposite(lines, itemValue.Elem())
+		if err != nil {
+			return err
+		}
+
+		v.Set(reflect.Append(v, itemValue.Elem()))
+		lines = lines[consumed:]
+	}
+
+	return nil
+}
+
+// matchRecord checks if the line contains the constant fields of the record
+// type, that identify the line (e.g. the segment code in CNAB 240). Record
+// types without constant fields match any line.
+func matchRecord(line []byte, recordType reflect.Type) bool {
+	if recordType.Kind() != reflect.Struct || reflect.PtrTo(recordType).Implements(lineUnmarshalerType) {
+		return true
+	}
+
+	fields, err := structLayout(recordType, len(line))
+	if err != nil {
+		// let the decoding report the layout problem
+		return true
+	}
+
+	for _, field := range fields {
+		if field.options.hasConstant && !bytes.Equal(line[field.begin:field.end], field.constant) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// numberedLine is a not empty CNAB line with its number in the input, starting
+// at 1.
+type numberedLine struct {
+	number int
+	data   []byte
+}
+
+// nonEmptyLines breaks the CNAB data into lines, ignoring the empty ones.
+func nonEmptyLines(data []byte) []numberedLine {
+	var lines []numberedLine
+	for i, cnabLine := range splitLines(data) {
+		if len(cnabLine) == 0 {
+			continue
+		}
+
+		lines = append(lines, numberedLine{
+			number: i + 1,
+			data:   cnabLine,
+		})
+	}
+	return lines
+}
+
+// lineData returns only the content of the lines.
+func lineData(lines []numberedLine) [][]byte {
+	data := make([][]byte, len(lines))
+	for i, line := range lines {
+		data[i] = line.data
+	}
+	return data
+}
@@ -0,0 +1,317 @@
+package gocnab_test
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+)
+
+type compositeP struct {
+	Segment string `cnab:"0,1,const=P"`
+	Amount  int    `cnab:"1,11"`
+}
+
+type compositeQ struct {
+	Segment string `cnab:"0,1,const=Q"`
+	Name    string `cnab:"1,21"`
+}
+
+type compositeR struct {
+	Segment string `cnab:"0,1,const=R"`
+	Message string `cnab:"1,21"`
+}
+
+type compositeBoleto struct {
+	P compositeP  `cnab:"line"`
+	Q compositeQ  `cnab:"line"`
+	R *compositeR `cnab:"line"`
+}
+
+type compositeTrailer struct {
+	Identifier string `cnab:"0,1,const=9"`
+	Boletos    int    `cnab:"1,7,count=compositeBoleto"`
+	Messages   int    `cnab:"7,13,count=compositeR"`
+	Total      int    `cnab:"13,28,sum=compositeP.Amount"`
+}
+
+func compositeLineP(amount int) string {
+	return fmt.Sprintf("P%010d%139s", amount, "")
+}
+
+func compositeLineQ(name string) string {
+	return fmt.Sprintf("Q%-20s%129s", name, "")
+}
+
+func compositeLineR(message string) string {
+	return fmt.Sprintf("R%-20s%129s", message, "")
+}
+
+func TestMarshalUnmarshal_composite(t *testing.T) {
+	t.Parallel()
+
+	boletos := []compositeBoleto{
+		{
+			P: compositeP{Amount: 10},
+			Q: compositeQ{Name: "PAYER 1"},
+			R: &compositeR{Message: "MESSAGE 1"},
+		},
+		{
+			P: compositeP{Amount: 20},
+			Q: compositeQ{Name: "PAYER 2"},
+		},
+	}
+
+	data, err := gocnab.Marshal150(boletos)
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	expected := strings.Join([]string{
+		compositeLineP(10), compositeLineQ("PAYER 1"), compositeLineR("MESSAGE 1"),
+		compositeLineP(20), compositeLineQ("PAYER 2"),
+	}, gocnab.LineBreak)
+
+	if expected != string(data) {
+		t.Errorf("expected data “%s” and got “%s”", expected, string(data))
+	}
+
+	var decoded []compositeBoleto
+	if err = gocnab.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	// the constant fields are filled on unmarshal
+	boletos[0].P.Segment, boletos[0].Q.Segment, boletos[0].R.Segment = "P", "Q", "R"
+	boletos[1].P.Segment, boletos[1].Q.Segment = "P", "Q"
+
+	if !reflect.DeepEqual(boletos, decoded) {
+		t.Errorf("expected data “%#v” and got “%#v”", boletos, decoded)
+	}
+
+	records, err := gocnab.CompositeRecords(boletos[1])
+	if err != nil {
+		t.Fatalf("error reading composite records. details: %s", err)
+	}
+
+	expectedRecords := []interface{}{boletos[1].P, boletos[1].Q}
+	if !reflect.DeepEqual(expectedRecords, records) {
+		t.Errorf("expected records “%#v” and got “%#v”", expectedRecords, records)
+	}
+}
+
+func TestUnmarshal_composite(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description   string
+		data          string
+		v             interface{}
+		expected      interface{}
+		expectedError error
+	}{
+		{
+			description: "it should decode a single composite record",
+			data:        compositeLineP(10) + gocnab.LineBreak + compositeLineQ("PAYER 1"),
+			v:           &compositeBoleto{},
+			expected: &compositeBoleto{
+				P: compositeP{Segment: "P", Amount: 10},
+				Q: compositeQ{Segment: "Q", Name: "PAYER 1"},
+			},
+		},
+		{
+			description: "it should detect a missing required line",
+			data:        compositeLineP(10) + gocnab.LineBreak + compositeLineR("MESSAGE 1"),
+			v:           &[]compositeBoleto{},
+			expected:    &[]compositeBoleto{},
+			expectedError: gocnab.FieldError{
+				Field: "Q",
+				Err:   gocnab.ErrMissingCompositeLine,
+			},
+		},
+		{
+			description: "it should detect lines that don't belong to the record",
+			data:        compositeLineP(10) + gocnab.LineBreak + compositeLineQ("PAYER 1") + gocnab.LineBreak + compositeLineP(20),
+			v:           &compositeBoleto{},
+			expected: &compositeBoleto{
+				P: compositeP{Segment: "P", Amount: 10},
+				Q: compositeQ{Segment: "Q", Name: "PAYER 1"},
+			},
+			expectedError: gocnab.LineError{
+				Line: 3,
+				Err:  gocnab.ErrUnknownLine,
+			},
+		},
+		{
+			description: "it should report the number of the first line that doesn't belong to the record",
+			data:        compositeLineP(10) + gocnab.LineBreak + compositeLineQ("PAYER 1") + gocnab.LineBreak + gocnab.LineBreak + compositeLineR("MESSAGE 1") + gocnab.LineBreak + compositeLineP(20),
+			v:           &compositeBoleto{},
+			expected: &compositeBoleto{
+				P: compositeP{Segment: "P", Amount: 10},
+				Q: compositeQ{Segment: "Q", Name: "PAYER 1"},
+				R: &compositeR{Segment: "R", Message: "MESSAGE 1"},
+			},
+			expectedError: gocnab.LineError{
+				Line: 5,
+				Err:  gocnab.ErrUnknownLine,
+			},
+		},
+		{
+			description: "it should detect an unsupported line type",
+			data:        compositeLineP(10),
+			v: &struct {
+				P compositeP `cnab:"line"`
+				N int        `cnab:"line"`
+			}{},
+			expected: &struct {
+				P compositeP `cnab:"line"`
+				N int        `cnab:"line"`
+			}{},
+			expectedError: gocnab.FieldError{
+				Field: "N",
+				Err:   gocnab.ErrUnsupportedType,
+			},
+		},
+		{
+			description: "it should detect a composite record mapping positions",
+			data:        compositeLineP(10),
+			v: &struct {
+				P compositeP `cnab:"line"`
+				N int        `cnab:"0,1"`
+			}{},
+			expected: &struct {
+				P compositeP `cnab:"line"`
+				N int        `cnab:"0,1"`
+			}{},
+			expectedError: gocnab.FieldError{
+				Field: "N",
+				Err:   gocnab.ErrInvalidFieldTagFormat,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			err := gocnab.Unmarshal([]byte(scenario.data), scenario.v)
+
+			if !reflect.DeepEqual(scenario.expected, scenario.v) {
+				t.Errorf("expected data “%#v” and got “%#v”", scenario.expected, scenario.v)
+			}
+
+			if !reflect.DeepEqual(scenario.expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestMapper_composite(t *testing.T) {
+	t.Parallel()
+
+	data := strings.Join([]string{
+		compositeLineP(10), compositeLineQ("PAYER 1"), compositeLineR("MESSAGE 1"),
+		compositeLineP(20), compositeLineQ("PAYER 2"),
+		fmt.Sprintf("9%06d%06d%015d%122s", 2, 1, 30, ""),
+	}, gocnab.LineBreak) + gocnab.FinalControlCharacter
+
+	mapper := gocnab.NewMapper()
+	mapper.Register((*compositeBoleto)(nil), gocnab.MatchPrefix("P"))
+	mapper.Register((*compositeTrailer)(nil), gocnab.MatchPrefix("9"))
+
+	records, err := mapper.Decode([]byte(data))
+	if err != nil {
+		t.Fatalf("error decoding. details: %s", err)
+	}
+
+	var lines []int
+	for _, record := range records {
+		lines = append(lines, record.Line)
+	}
+
+	if expected := []int{1, 4, 6}; !reflect.DeepEqual(expected, lines) {
+		t.Errorf("expected lines “%v” and got “%v”", expected, lines)
+	}
+
+	var boletos []compositeBoleto
+	var trailer compositeTrailer
+
+	mapper = gocnab.NewMapper()
+	mapper.Register(&boletos, gocnab.MatchPrefix("P"))
+	mapper.Register(&trailer, gocnab.MatchPrefix("9"))
+
+	if err = gocnab.Unmarshal([]byte(data), mapper); err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if len(boletos) != 2 || boletos[0].R == nil || boletos[1].R != nil {
+		t.Errorf("unexpected composite records “%#v”", boletos)
+	}
+
+	data = strings.Replace(data, compositeLineQ("PAYER 2"), compositeLineR("MESSAGE 2"), 1)
+	_, err = mapper.Decode([]byte(data))
+
+	expectedError := gocnab.LineError{
+		Line: 5,
+		Err: gocnab.FieldError{
+			Field: "Q",
+			Err:   gocnab.ErrMissingCompositeLine,
+		},
+	}
+
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected error “%v” and got “%v”", expectedError, err)
+	}
+}
+
+func TestDecoder_composite(t *testing.T) {
+	t.Parallel()
+
+	data := strings.Join([]string{
+		compositeLineP(10), compositeLineQ("PAYER 1"),
+		compositeLineP(20), "", compositeLineQ("PAYER 2"), compositeLineR("MESSAGE 2"),
+	}, gocnab.LineBreak) + gocnab.FinalControlCharacter
+
+	decoder := gocnab.NewDecoder[compositeBoleto](strings.NewReader(data))
+
+	var amounts []int
+	for {
+		record, err := decoder.Decode()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("unexpected error. details: %s", err)
+		}
+
+		amounts = append(amounts, record.P.Amount)
+		if record.P.Amount == 20 && (record.R == nil || record.R.Message != "MESSAGE 2") {
+			t.Errorf("unexpected composite record “%#v”", record)
+		}
+	}
+
+	if expected := []int{10, 20}; !reflect.DeepEqual(expected, amounts) {
+		t.Errorf("expected amounts “%v” and got “%v”", expected, amounts)
+	}
+}
+
+func TestMarshal_compositeAggregates(t *testing.T) {
+	t.Parallel()
+
+	data, err := gocnab.Marshal150([]compositeBoleto{
+		{P: compositeP{Amount: 10}, R: &compositeR{Message: "MESSAGE 1"}},
+		{P: compositeP{Amount: 20}},
+	}, compositeTrailer{})
+
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), gocnab.FinalControlCharacter), gocnab.LineBreak)
+	expected := fmt.Sprintf("9%06d%06d%015d%122s", 2, 1, 30, "")
+
+	if trailer := lines[len(lines)-1]; expected != trailer {
+		t.Errorf("expected trailer “%s” and got “%s”", expected, trailer)
+	}
+}
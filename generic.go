@@ -0,0 +1,143 @@
+package gocnab
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// MarshalLines returns the CNAB encoding of records, where each record is a
+// line with lineSize characters. The line break symbols are added between the
+// lines, and no final control character is added, the same behavior of
+// marshaling a slice of struct. The record type T must be a struct or a
+// gocnab.LineMarshaler.
+func MarshalLines[T any](lineSize int, records []T) ([]byte, error) {
+	return marshal(lineSize, records)
+}
+
+// UnmarshalAll parses all the CNAB lines of data into records of type T,
+// ignoring empty lines. The record type T must be a struct or a
+// gocnab.LineUnmarshaler.
+func UnmarshalAll[T any](data []byte) ([]T, error) {
+	var records []T
+	if err := Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// Decoder reads and decodes CNAB lines from an input stream, one record at a
+// time. Empty lines are ignored and the final control character is removed
+// from the last line.
+type Decoder[T any] struct {
+	scanner *bufio.Scanner
+	line    int
+
+	// pending stores the lines already read from the input and not decoded yet,
+	// as composite records need to look at the following lines
+	pending []numberedLine
+}
+
+// NewDecoder returns a new decoder that reads from r. The record type T must be
+// a struct (that could be a composite record) or a gocnab.LineUnmarshaler.
+func NewDecoder[T any](r io.Reader) *Decoder[T] {
+	return &Decoder[T]{
+		scanner: bufio.NewScanner(r),
+	}
+}
+
+// Decode reads the next CNAB line from the input and stores it in a new
+// record. Composite records read as many lines as they need. When there are no
+// more lines it returns io.EOF. Decoding errors are returned as
+// gocnab.LineError, so the next calls can continue reading the following
+// lines.
+func (d *Decoder[T]) Decode() (T, error) {
+	var record T
+
+	rv := reflect.ValueOf(&record).Elem()
+	if rv.Kind() != reflect.Struct && !reflect.PtrTo(rv.Type()).Implements(lineUnmarshalerType) {
+		return record, ErrUnsupportedType
+	}
+
+	fields, err := compositeLayout(rv.Type())
+	if err != nil {
+		return record, err
+	}
+
+	// a composite record can't use more lines than its number of fields
+	d.fill(max(len(fields), 1))
+
+	if len(d.pending) == 0 {
+		if err := d.scanner.Err(); err != nil {
+			return record, err
+		}
+
+		return record, io.EOF
+	}
+
+	consumed, err := unmarshalRecordLines(lineData(d.pending), rv)
+	if err != nil {
+		line := d.pending[consumed].number
+		d.pending = d.pending[consumed+1:]
+
+		var empty T
+		return empty, LineError{
+			Line: line,
+			Err:  err,
+		}
+	}
+
+	d.pending = d.pending[consumed:]
+	return record, nil
+}
+
+// fill reads not empty lines from the input until there are size pending
+// lines or the input ends.
+func (d *Decoder[T]) fill(size int) {
+	for len(d.pending) < size && d.scanner.Scan() {
+		d.line++
+
+		cnabLine := bytes.TrimSuffix(d.scanner.Bytes(), []byte(FinalControlCharacter))
+		if len(cnabLine) == 0 {
+			continue
+		}
+
+		// the scanner reuses its buffer, so the line must be copied
+		d.pending = append(d.pending, numberedLine{
+			number: d.line,
+			data:   append([]byte(nil), cnabLine...),
+		})
+	}
+}
+
+// Line returns the number of the last line read from the input, starting at
+// 1. Empty lines are also counted. Composite records could read lines ahead of
+// the decoded record.
+func (d *Decoder[T]) Line() int {
+	return d.line
+}
+
+// LineError stores the error that occurred while decoding a CNAB line, with
+// the number of the line in the input, starting at 1.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+// Error return a human readable representation of the line error.
+func (l LineError) Error() string {
+	errStr := "<nil>"
+	if l.Err != nil {
+		errStr = l.Err.Error()
+	}
+
+	return fmt.Sprintf("gocnab: error in line %d. details: %s", l.Line, errStr)
+}
+
+// Unwrap returns the error that occurred while decoding the line.
+func (l LineError) Unwrap() error {
+	return l.Err
+}
@@ -0,0 +1,177 @@
+package gocnab_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+)
+
+type genericRecord struct {
+	Identifier int    `cnab:"0,1"`
+	Name       string `cnab:"1,21"`
+	Amount     int    `cnab:"21,31"`
+}
+
+func TestMarshalLinesUnmarshalAll(t *testing.T) {
+	t.Parallel()
+
+	input := []genericRecord{
+		{Identifier: 1, Name: "FIRST", Amount: 10},
+		{Identifier: 1, Name: "SECOND", Amount: 20},
+	}
+
+	data, err := gocnab.MarshalLines(240, input)
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	expected := fmt.Sprintf("1%-20s%010d%209s\r\n1%-20s%010d%209s", "FIRST", 10, "", "SECOND", 20, "")
+	if expected != string(data) {
+		t.Errorf("expected data “%s” and got “%s”", expected, string(data))
+	}
+
+	output, err := gocnab.UnmarshalAll[genericRecord](data)
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(input, output) {
+		t.Errorf("expected data “%#v” and got “%#v”", input, output)
+	}
+
+	if _, err = gocnab.MarshalLines(240, []int{1}); !errors.Is(err, gocnab.ErrUnsupportedType) {
+		t.Errorf("expected error “%v” and got “%v”", gocnab.ErrUnsupportedType, err)
+	}
+
+	if _, err = gocnab.UnmarshalAll[int](data); !errors.Is(err, gocnab.ErrUnsupportedType) {
+		t.Errorf("expected error “%v” and got “%v”", gocnab.ErrUnsupportedType, err)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	t.Parallel()
+
+	data := fmt.Sprintf("1%-20s%010d%209s\r\n\r\n1%-20s%10s%209s\r\n1%-20s%010d%209s\x1a",
+		"FIRST", 10, "",
+		"SECOND", "XX", "",
+		"THIRD", 30, "")
+
+	decoder := gocnab.NewDecoder[genericRecord](strings.NewReader(data))
+
+	record, err := decoder.Decode()
+	if err != nil {
+		t.Fatalf("unexpected error. details: %s", err)
+	}
+
+	expected := genericRecord{Identifier: 1, Name: "FIRST", Amount: 10}
+	if !reflect.DeepEqual(expected, record) {
+		t.Errorf("expected data “%#v” and got “%#v”", expected, record)
+	}
+
+	_, err = decoder.Decode()
+	expectedError := gocnab.LineError{
+		Line: 3,
+		Err: gocnab.UnmarshalFieldError{
+			Field: "Amount",
+			Data:  []byte("        XX"),
+			Err: &strconv.NumError{
+				Func: "ParseInt",
+				Num:  "XX",
+				Err:  strconv.ErrSyntax,
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected error “%v” and got “%v”", expectedError, err)
+	}
+
+	record, err = decoder.Decode()
+	if err != nil {
+		t.Fatalf("unexpected error. details: %s", err)
+	}
+
+	expected = genericRecord{Identifier: 1, Name: "THIRD", Amount: 30}
+	if !reflect.DeepEqual(expected, record) {
+		t.Errorf("expected data “%#v” and got “%#v”", expected, record)
+	}
+
+	if decoder.Line() != 4 {
+		t.Errorf("expected line 4 and got %d", decoder.Line())
+	}
+
+	if _, err = decoder.Decode(); err != io.EOF {
+		t.Errorf("expected error “%v” and got “%v”", io.EOF, err)
+	}
+
+	if _, err = gocnab.NewDecoder[int](strings.NewReader(data)).Decode(); err != gocnab.ErrUnsupportedType {
+		t.Errorf("expected error “%v” and got “%v”", gocnab.ErrUnsupportedType, err)
+	}
+}
+
+func TestLineError_Error(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		err         gocnab.LineError
+		expected    string
+	}{
+		{
+			description: "it should build the error message correctly",
+			err: gocnab.LineError{
+				Line: 10,
+				Err:  gocnab.ErrUnsupportedType,
+			},
+			expected: "gocnab: error in line 10. details: gocnab: unsupported type",
+		},
+		{
+			description: "it should detect when internal error is nil",
+			err: gocnab.LineError{
+				Line: 10,
+			},
+			expected: "gocnab: error in line 10. details: <nil>",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			text := scenario.err.Error()
+
+			if scenario.expected != text {
+				t.Errorf("expected text “%s” and got “%s”", scenario.expected, text)
+			}
+		})
+	}
+}
+
+func ExampleDecoder() {
+	type record struct {
+		Identifier int    `cnab:"0,1"`
+		Name       string `cnab:"1,7"`
+	}
+
+	data := "1FIRST " + gocnab.LineBreak + "1SECOND" + gocnab.FinalControlCharacter
+
+	decoder := gocnab.NewDecoder[record](strings.NewReader(data))
+	for {
+		r, err := decoder.Decode()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		fmt.Printf("%v\n", r)
+	}
+
+	// Output: {1 FIRST}
+	// {1 SECOND}
+}
@@ -1,5 +1,47 @@
 // Package gocnab implements encoding and decoding of CNAB (Centro Nacional de
 // Automação Bancária) as defined by FEBRABAN (Federação Brasileira de Bancos).
+//
+// The struct fields are mapped into CNAB positions with the "cnab" tag, using
+// the format "begin,end[,option...]", where the range [begin,end) starts at 0.
+// The following options are available after the range:
+//
+//	const=value    literal always written, ignoring the field value. When
+//	               unmarshaling the CNAB content must match it.
+//	default=value  literal written when the field has its zero value.
+//	required       field can't have its zero value.
+//	enum=a|b|c     content must be one of the listed values.
+//	min=n          numeric field can't be lower than n.
+//	max=n          numeric field can't be greater than n.
+//	regex=expr     the whole content must match the regular expression (it
+//	               is anchored at both ends). As it can contain commas, it
+//	               must be the last option.
+//	count=Type     numeric field with the number of records of the struct
+//	               type Type in the file (e.g. in a trailer record).
+//	sum=Type.Field numeric field with the sum of the field Field of all
+//	               records of the struct type Type in the file.
+//	               Many record types can be combined with "|" in count and
+//	               sum (e.g. count=Header|Detail or sum=A.Amount|B.Value).
+//	keepcase       string field written without converting it to uppercase
+//	               (e.g. a Pix key or an e-mail).
+//
+// Literals and string fields are written left aligned and in uppercase, so
+// numeric literals must already contain the leading zeros (e.g.
+// `cnab:"7,9,const=01"`).
+// The enum and regex options are checked against the CNAB content without the
+// surrounding spaces, in uppercase unless the field has the keepcase option.
+// Validations are executed when marshaling and unmarshaling, including the
+// default values written by the default option.
+//
+// The count and sum options are computed when marshaling all the records of a
+// file in the same call, and verified when unmarshaling a full file into a map
+// or a gocnab.Mapper. The records are identified by the name of the struct
+// type, optionally qualified by the package name (e.g.
+// count=febraban240.SegmentP), and the sums are computed in the unit written
+// in the CNAB line, where float fields are written in cents.
+//
+// Layouts known only at runtime can be declared in a gocnab.LayoutSpec
+// (loaded from JSON or YAML) and used through gocnab.DynamicLayout, without
+// structs.
 package gocnab
 
 import (
@@ -8,6 +50,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -25,6 +69,17 @@ var (
 	// struct or a slice.
 	ErrUnsupportedType = errors.New("gocnab: unsupported type")
 
+	// ErrInvalidLineSize line built by a gocnab.LineMarshaler doesn't have the
+	// expected size.
+	ErrInvalidLineSize = errors.New("invalid line size")
+
+	// ErrUnknownLine line doesn't match any record type of the mapper.
+	ErrUnknownLine = errors.New("unknown line")
+
+	// ErrUnsupportedOption unmarshal option given for a type that can't use it,
+	// like the unknown line options when unmarshaling into a struct or a slice.
+	ErrUnsupportedOption = errors.New("gocnab: unsupported unmarshal option")
+
 	// ErrInvalidFieldTagFormat CNAB field tag doesn't follow the expected format.
 	ErrInvalidFieldTagFormat = errors.New("invalid field tag format")
 
@@ -38,6 +93,47 @@ var (
 	// ErrInvalidFieldTagRange ranges don't have consistency with the desired
 	// encoding in the CNAB tag.
 	ErrInvalidFieldTagRange = errors.New("invalid range in cnab tag")
+
+	// ErrInvalidFieldTagOption option after the range in the CNAB tag is unknown
+	// or has an invalid value.
+	ErrInvalidFieldTagOption = errors.New("invalid option in cnab tag")
+
+	// ErrConstantMismatch CNAB content doesn't match the constant defined in the
+	// CNAB tag.
+	ErrConstantMismatch = errors.New("content doesn't match the constant in cnab tag")
+
+	// ErrRequiredField field marked as required in the CNAB tag has its zero
+	// value.
+	ErrRequiredField = errors.New("required field without value")
+
+	// ErrValueNotInEnum CNAB content isn't one of the values allowed by the enum
+	// in the CNAB tag.
+	ErrValueNotInEnum = errors.New("value not allowed by the enum in cnab tag")
+
+	// ErrValueOutOfRange numeric value is lower than the minimum or greater than
+	// the maximum defined in the CNAB tag.
+	ErrValueOutOfRange = errors.New("value out of the limits in cnab tag")
+
+	// ErrValuePatternMismatch CNAB content doesn't match the regular expression
+	// in the CNAB tag.
+	ErrValuePatternMismatch = errors.New("value doesn't match the regex in cnab tag")
+
+	// ErrOverlappingFieldRange range in the CNAB tag uses positions that are
+	// already used by another field.
+	ErrOverlappingFieldRange = errors.New("overlapping range in cnab tag")
+
+	// ErrDuplicatedFieldRange range in the CNAB tag is exactly the same of
+	// another field.
+	ErrDuplicatedFieldRange = errors.New("duplicated range in cnab tag")
+
+	// ErrAggregateMismatch CNAB content of a field with the count or sum option
+	// doesn't match the value computed from the records of the file.
+	ErrAggregateMismatch = errors.New("value doesn't match the records of the file")
+
+	// ErrAmbiguousRecordType record type name in the count or sum option of the
+	// CNAB tag refers to different types of the file (e.g. types with the same
+	// name from different packages).
+	ErrAmbiguousRecordType = errors.New("record type name in cnab tag matches different types")
 )
 
 // MarshalOptions contains available options when marshaling. The properties can
@@ -59,16 +155,84 @@ type MarshalOptionFunc func(*MarshalOptions)
 // the final control hexadecimal character 1A. But as this library started being
 // used by other integrations, the final control character became an issue, as
 // it doesn't comply with other specifications. By default, the final control
-// character is added to keep backward compatibility.
+// character is added to keep backward compatibility. The record types of
+// Bradesco's cobrança are available in the layouts/bradesco400 package.
 func WithFinalControlCharacter(enabled bool) MarshalOptionFunc {
 	return MarshalOptionFunc(func(options *MarshalOptions) {
 		options.addFinalControlCharacter = enabled
 	})
 }
 
-// Marshal150 returns the CNAB 150 encoding of vs. The accepted types are struct
-// and slice of struct, where only the exported struct fields with the tag
-// "cnab" are going to be used. Invalid cnab tag ranges will generate errors.
+// UnmarshalOptions contains available options when unmarshaling. The
+// properties can be modified using auxiliary functions directly into the
+// unmarshal calls.
+//
+// Example:
+//
+//	Unmarshal(data, mapper, gocnab.WithUnknownLineError())
+type UnmarshalOptions struct {
+	unknownLineHandler func(line int, data []byte) error
+}
+
+// UnmarshalOptionFunc helper type alias to handle options.
+type UnmarshalOptionFunc func(*UnmarshalOptions)
+
+// WithUnknownLineHandler routes the lines of a full CNAB file that don't match
+// any record type of the mapper to the handler, with the number of the line
+// (starting at 1). If the handler returns an error the unmarshal is aborted.
+// By default, unknown lines are ignored.
+func WithUnknownLineHandler(handler func(line int, data []byte) error) UnmarshalOptionFunc {
+	return UnmarshalOptionFunc(func(options *UnmarshalOptions) {
+		options.unknownLineHandler = handler
+	})
+}
+
+// WithUnknownLineError aborts the unmarshal when a line of a full CNAB file
+// doesn't match any record type of the mapper.
+func WithUnknownLineError() UnmarshalOptionFunc {
+	return WithUnknownLineHandler(func(line int, data []byte) error {
+		return ErrUnknownLine
+	})
+}
+
+// WithUnknownLines collects the lines of a full CNAB file that don't match any
+// record type of the mapper into lines.
+func WithUnknownLines(lines *[]RawLine) UnmarshalOptionFunc {
+	return WithUnknownLineHandler(func(line int, data []byte) error {
+		*lines = append(*lines, RawLine{
+			Line: line,
+			Data: append([]byte(nil), data...),
+		})
+		return nil
+	})
+}
+
+// RawLine is a CNAB line that wasn't decoded, with the number of the line in
+// the file, starting at 1.
+type RawLine struct {
+	Line int
+	Data []byte
+}
+
+func (u UnmarshalOptions) unknownLine(line int, data []byte) error {
+	if u.unknownLineHandler == nil {
+		return nil
+	}
+
+	if err := u.unknownLineHandler(line, data); err != nil {
+		return LineError{
+			Line: line,
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// Marshal150 returns the CNAB 150 encoding of vs. The accepted types are struct,
+// gocnab.LineMarshaler and slice of them, where only the exported struct fields
+// with the tag "cnab" are going to be used. Invalid cnab tag ranges will
+// generate errors.
 //
 // The following struct field types are supported: string, bool, int, int8,
 // int16, int32, int64, uint, uint8, uint16, uint23, uint64, float32, float64,
@@ -85,9 +249,10 @@ func Marshal150(vs ...interface{}) ([]byte, error) {
 	return marshal(150, vs...)
 }
 
-// Marshal240 returns the CNAB 240 encoding of vs. The accepted types are struct
-// and slice of struct, where only the exported struct fields with the tag
-// "cnab" are going to be used. Invalid cnab tag ranges will generate errors.
+// Marshal240 returns the CNAB 240 encoding of vs. The accepted types are struct,
+// gocnab.LineMarshaler and slice of them, where only the exported struct fields
+// with the tag "cnab" are going to be used. Invalid cnab tag ranges will
+// generate errors.
 //
 // The following struct field types are supported: string, bool, int, int8,
 // int16, int32, int64, uint, uint8, uint16, uint23, uint64, float32, float64,
@@ -104,9 +269,10 @@ func Marshal240(vs ...interface{}) ([]byte, error) {
 	return marshal(240, vs...)
 }
 
-// Marshal400 returns the CNAB 400 encoding of vs. The accepted types are struct
-// and slice of struct, where only the exported struct fields with the tag
-// "cnab" are going to be used. Invalid cnab tag ranges will generate errors.
+// Marshal400 returns the CNAB 400 encoding of vs. The accepted types are struct,
+// gocnab.LineMarshaler and slice of them, where only the exported struct fields
+// with the tag "cnab" are going to be used. Invalid cnab tag ranges will
+// generate errors.
 //
 // The following struct field types are supported: string, bool, int, int8,
 // int16, int32, int64, uint, uint8, uint16, uint23, uint64, float32, float64,
@@ -123,9 +289,10 @@ func Marshal400(vs ...interface{}) ([]byte, error) {
 	return marshal(400, vs...)
 }
 
-// Marshal500 returns the CNAB 500 encoding of vs. The accepted types are struct
-// and slice of struct, where only the exported struct fields with the tag
-// "cnab" are going to be used. Invalid cnab tag ranges will generate errors.
+// Marshal500 returns the CNAB 500 encoding of vs. The accepted types are struct,
+// gocnab.LineMarshaler and slice of them, where only the exported struct fields
+// with the tag "cnab" are going to be used. Invalid cnab tag ranges will
+// generate errors.
 //
 // The following struct field types are supported: string, bool, int, int8,
 // int16, int32, int64, uint, uint8, uint16, uint23, uint64, float32, float64,
@@ -157,6 +324,11 @@ func marshal(lineSize int, vs ...interface{}) ([]byte, error) {
 	}
 	vs = vs[:i]
 
+	vs, err := applyAggregates(lineSize, vs)
+	if err != nil {
+		return nil, err
+	}
+
 	var cnab []byte
 
 	for i, v := range vs {
@@ -183,21 +355,12 @@ func marshal(lineSize int, vs ...interface{}) ([]byte, error) {
 func marshalLine(lineSize int, v interface{}) ([]byte, error) {
 	rv := reflect.ValueOf(v)
 
-	switch rv.Kind() {
-	case reflect.Struct:
-		cnab := []byte(strings.Repeat(" ", lineSize))
-		if err := marshalStruct(cnab, rv); err != nil {
-			return nil, err
-		}
-
-		return cnab, nil
-
-	case reflect.Slice:
+	if rv.Kind() == reflect.Slice && !rv.Type().Implements(lineMarshalerType) {
 		var cnab []byte
 
 		for i := 0; i < rv.Len(); i++ {
-			line := []byte(strings.Repeat(" ", lineSize))
-			if err := marshalStruct(line, rv.Index(i)); err != nil {
+			line, err := marshalRecord(lineSize, rv.Index(i))
+			if err != nil {
 				return nil, err
 			}
 
@@ -212,29 +375,132 @@ func marshalLine(lineSize int, v interface{}) ([]byte, error) {
 		return cnab, nil
 	}
 
-	return nil, ErrUnsupportedType
+	return marshalRecord(lineSize, rv)
+}
+
+var lineMarshalerType = reflect.TypeOf((*LineMarshaler)(nil)).Elem()
+
+// marshalRecord builds a single CNAB line from a record, that could be a
+// gocnab.LineMarshaler or a struct with CNAB tags.
+func marshalRecord(lineSize int, v reflect.Value) ([]byte, error) {
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return nil, ErrUnsupportedType
+	}
+
+	if v.Type().Implements(lineMarshalerType) || reflect.PtrTo(v.Type()).Implements(lineMarshalerType) {
+		record := v
+		if !v.Type().Implements(lineMarshalerType) {
+			record = reflect.New(v.Type())
+			record.Elem().Set(v)
+		}
+
+		cnab, err := record.Interface().(LineMarshaler).MarshalCNABLine(lineSize)
+		if err == nil && len(cnab) != lineSize {
+			err = ErrInvalidLineSize
+		}
+
+		if err != nil {
+			return nil, RecordError{
+				Record: v.Type().String(),
+				Err:    err,
+			}
+		}
+
+		return cnab, nil
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, ErrUnsupportedType
+	}
+
+	if fields, err := compositeLayout(v.Type()); err != nil {
+		return nil, err
+	} else if fields != nil {
+		return marshalComposite(lineSize, v)
+	}
+
+	cnab := []byte(strings.Repeat(" ", lineSize))
+	if err := marshalStruct(cnab, v); err != nil {
+		return nil, err
+	}
+
+	return cnab, nil
 }
 
 func marshalStruct(data []byte, v reflect.Value) error {
-	structType := v.Type()
-	for i := 0; i < structType.NumField(); i++ {
-		structField := structType.Field(i)
-		begin, end, err := parseCNABFieldTag(structField, len(data))
+	fields, err := structLayout(v.Type(), len(data))
+	if err != nil {
+		return err
+	}
+
+	beforeMarshalerType := reflect.TypeOf((*BeforeMarshaler)(nil)).Elem()
+	if reflect.PtrTo(v.Type()).Implements(beforeMarshalerType) {
+		// work on a copy, so the hook doesn't change the caller's record
+		record := reflect.New(v.Type())
+		record.Elem().Set(v)
+		v = record.Elem()
+
+		if err = record.Interface().(BeforeMarshaler).BeforeMarshalCNAB(); err != nil {
+			return RecordError{
+				Record: v.Type().String(),
+				Err:    err,
+			}
+		}
+	}
+
+	return marshalFields(data, fields, func(field fieldLayout) (reflect.Value, error) {
+		return v.Field(field.index), nil
+	}, encodeField)
+}
+
+// marshalFields writes the fields in the CNAB line: constants are always
+// written, default values are written when the field isn't exported or has
+// the zero value, and the other exported fields are encoded. The written
+// content is validated. The field value is read with value and written with
+// encode, so the same rules apply to the struct fields and to the fields of
+// the dynamic layouts.
+func marshalFields(data []byte, fields []fieldLayout, value func(fieldLayout) (reflect.Value, error), encode func([]byte, reflect.Value, fieldLayout) error) error {
+	for _, field := range fields {
+		if field.options.hasConstant {
+			setFieldContent(data, field.options.constant, field.begin, field.end)
+			continue
+		}
+
+		// not exported fields can't be read, but they still reserve their range
+		fieldValue, err := value(field)
 		if err != nil {
 			return FieldError{
-				Field: structField.Name,
+				Field: field.name,
 				Err:   err,
 			}
 		}
 
-		// ignore fields without range
-		if begin == 0 && end == 0 {
+		if field.options.hasDefault && (!field.exported || fieldValue.IsZero()) {
+			setFieldOption(data, field.options.defaultValue, field)
+
+			if err = validateDefault(data, fieldValue.Type(), field); err != nil {
+				return FieldError{
+					Field: field.name,
+					Err:   err,
+				}
+			}
+			continue
+		}
+
+		if !field.exported {
 			continue
 		}
 
-		if err = marshalField(data, v.FieldByName(structField.Name), begin, end); err != nil {
+		if err = encode(data, fieldValue, field); err != nil {
+			return FieldError{
+				Field: field.name,
+				Err:   err,
+			}
+		}
+
+		if err = validateField(data, fieldValue, field); err != nil {
 			return FieldError{
-				Field: structField.Name,
+				Field: field.name,
 				Err:   err,
 			}
 		}
@@ -243,17 +509,28 @@ func marshalStruct(data []byte, v reflect.Value) error {
 	return nil
 }
 
+// encodeField writes the value of a struct field in its range of the CNAB
+// line.
+func encodeField(data []byte, v reflect.Value, field fieldLayout) error {
+	if field.options.keepCase {
+		setFieldContentKeepCase(data, v.String(), field.begin, field.end)
+		return nil
+	}
+
+	return marshalField(data, v, field.begin, field.end)
+}
+
 func marshalField(data []byte, v reflect.Value, begin, end int) error {
 	cnabFieldSize := end - begin
 
 	switch v.Kind() {
 	case reflect.String:
-		fieldContent := v.Interface().(string)
+		fieldContent := v.String()
 		setFieldContent(data, fieldContent, begin, end)
 		return nil
 
 	case reflect.Bool:
-		fieldContent := v.Interface().(bool)
+		fieldContent := v.Bool()
 		var convertedFieldContent string
 		if fieldContent {
 			convertedFieldContent = "1"
@@ -308,8 +585,26 @@ func marshalField(data []byte, v reflect.Value, begin, end int) error {
 }
 
 func setFieldContent(data []byte, fieldContent string, begin, end int) {
-	cnabFieldSize := end - begin
+	copy(data[begin:], strings.ToUpper(fitFieldContent(fieldContent, end-begin)))
+}
+
+// setFieldContentKeepCase works like setFieldContent, but without converting
+// the content to uppercase.
+func setFieldContentKeepCase(data []byte, fieldContent string, begin, end int) {
+	copy(data[begin:], fitFieldContent(fieldContent, end-begin))
+}
 
+// setFieldOption writes a literal from the field options (e.g. the default
+// value), respecting the keepcase option of the field.
+func setFieldOption(data []byte, value string, field fieldLayout) {
+	if field.options.keepCase {
+		setFieldContentKeepCase(data, value, field.begin, field.end)
+	} else {
+		setFieldContent(data, value, field.begin, field.end)
+	}
+}
+
+func fitFieldContent(fieldContent string, cnabFieldSize int) string {
 	// strip field if is too big for the space
 	if len(fieldContent) > cnabFieldSize {
 		fieldContent = fieldContent[0:cnabFieldSize]
@@ -317,12 +612,13 @@ func setFieldContent(data []byte, fieldContent string, begin, end int) {
 		fieldContent = fieldContent + strings.Repeat(" ", cnabFieldSize-len(fieldContent))
 	}
 
-	copy(data[begin:], strings.ToUpper(fieldContent))
+	return fieldContent
 }
 
 // Unmarshal parses the CNAB-encoded data and stores the result in the value
-// pointed to by v. Accepted types of v are: *struct, *[]struct or
-// map[string]interface{}.
+// pointed to by v. Accepted types of v are: *struct, *[]struct,
+// gocnab.LineUnmarshaler, *[]gocnab.LineUnmarshaler, map[string]interface{} or
+// *gocnab.Mapper.
 //
 // The following struct field types are supported: string, bool, int, int8,
 // int16, int32, int64, uint, uint8, uint16, uint23, uint64, float32, float64,
@@ -345,17 +641,55 @@ func setFieldContent(data []byte, fieldContent string, begin, end int) {
 //	  "1": &content,
 //	  "2": &footer,
 //	})
-func Unmarshal(data []byte, v interface{}) error {
+//
+// Lines of a full CNAB file that don't match any record type are ignored,
+// unless an option like gocnab.WithUnknownLineError is given. These options
+// are only accepted with the map type and gocnab.Mapper, as the other types
+// decode every line, returning gocnab.ErrUnsupportedOption otherwise.
+//
+// When the record type isn't detected only by the line prefix (like CNAB 240,
+// where the record type is in the position 7 and the segment code in the
+// position 13) use gocnab.Mapper instead.
+func Unmarshal(data []byte, v interface{}, optionFuncs ...UnmarshalOptionFunc) error {
+	var options UnmarshalOptions
+	for _, optionFunc := range optionFuncs {
+		optionFunc(&options)
+	}
+
+	if mapper, ok := v.(*Mapper); ok && mapper != nil {
+		return mapper.unmarshal(data, options)
+	}
+
 	rv := reflect.ValueOf(v)
 	if (rv.Kind() != reflect.Ptr && rv.Kind() != reflect.Map) || rv.IsNil() {
 		return ErrUnsupportedType
 	}
 
+	if mapper, ok := v.(map[string]interface{}); ok {
+		return unmarshalMapper(data, mapper, options)
+	}
+
+	// structs and slices decode every line, so there are no unknown lines to be
+	// handled by the options
+	if options.unknownLineHandler != nil {
+		return ErrUnsupportedOption
+	}
+
 	if rv.Kind() == reflect.Ptr {
 		rvElem := rv.Elem()
 
+		if rv.Type().Implements(lineUnmarshalerType) {
+			return unmarshalRecord(data, rvElem)
+		}
+
 		switch rvElem.Kind() {
 		case reflect.Struct:
+			if fields, err := compositeLayout(rvElem.Type()); err != nil {
+				return err
+			} else if fields != nil {
+				return unmarshalCompositeLines(data, rvElem)
+			}
+
 			return unmarshalStruct(data, rvElem)
 
 		case reflect.Slice:
@@ -363,22 +697,16 @@ func Unmarshal(data []byte, v interface{}) error {
 		}
 	}
 
-	if mapper, ok := v.(map[string]interface{}); ok {
-		return unmarshalMapper(data, mapper)
-	}
-
 	return ErrUnsupportedType
 }
 
-func unmarshalMapper(data []byte, mapper map[string]interface{}) error {
+func unmarshalMapper(data []byte, mapper map[string]interface{}, options UnmarshalOptions) error {
 	cnabLinesGroupBy := make(map[string][]byte)
-	cnabLines := bytes.Split(data, []byte(LineBreak))
 
-	for _, cnabLine := range cnabLines {
-		if len(cnabLine) == 0 {
-			continue
-		}
+	for _, line := range nonEmptyLines(data) {
+		cnabLine := line.data
 
+		var found bool
 		for id := range mapper {
 			if !bytes.HasPrefix(cnabLine, []byte(id)) {
 				continue
@@ -389,32 +717,64 @@ func unmarshalMapper(data []byte, mapper map[string]interface{}) error {
 			}
 
 			cnabLinesGroupBy[id] = append(cnabLinesGroupBy[id], cnabLine...)
+			found = true
+		}
+
+		if !found {
+			if err := options.unknownLine(line.number, cnabLine); err != nil {
+				return err
+			}
 		}
 	}
 
-	for id, lines := range cnabLinesGroupBy {
-		if err := Unmarshal(lines, mapper[id]); err != nil {
+	ids := make([]string, 0, len(cnabLinesGroupBy))
+	for id := range cnabLinesGroupBy {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var records []reflect.Value
+	var lineSizes []int
+	for _, id := range ids {
+		if err := Unmarshal(cnabLinesGroupBy[id], mapper[id]); err != nil {
 			return err
 		}
+
+		groupRecords := flattenRecords([]interface{}{
+			reflect.Indirect(reflect.ValueOf(mapper[id])).Interface(),
+		})
+		records = append(records, groupRecords...)
+
+		// all the lines of the group were decoded into the same record type, so
+		// the size of the first line is enough to read its layout
+		lineSize := bytes.Index(cnabLinesGroupBy[id], []byte(LineBreak))
+		if lineSize < 0 {
+			lineSize = len(cnabLinesGroupBy[id])
+		}
+		for range groupRecords {
+			lineSizes = append(lineSizes, lineSize)
+		}
 	}
 
-	return nil
+	_, err := verifyAggregates(records, lineSizes)
+	return err
 }
 
 func unmarshalSlice(data []byte, v reflect.Value) error {
 	sliceType := v.Type().Elem()
-	if sliceType.Kind() != reflect.Struct {
+	if sliceType.Kind() != reflect.Struct && !reflect.PtrTo(sliceType).Implements(lineUnmarshalerType) {
 		return ErrUnsupportedType
 	}
 
-	cnabLines := bytes.Split(data, []byte(LineBreak))
-	for _, cnabLine := range cnabLines {
-		if len(cnabLine) == 0 {
-			continue
-		}
+	if fields, err := compositeLayout(sliceType); err != nil {
+		return err
+	} else if fields != nil {
+		return unmarshalCompositeSlice(data, v)
+	}
 
+	for _, cnabLine := range nonEmptyLines(data) {
 		itemValue := reflect.New(sliceType)
-		if err := unmarshalStruct(cnabLine, itemValue.Elem()); err != nil {
+		if err := unmarshalRecord(cnabLine.data, itemValue.Elem()); err != nil {
 			return err
 		}
 
@@ -424,33 +784,74 @@ func unmarshalSlice(data []byte, v reflect.Value) error {
 	return nil
 }
 
+var lineUnmarshalerType = reflect.TypeOf((*LineUnmarshaler)(nil)).Elem()
+
+// unmarshalRecord fills a record from a single CNAB line, where the record
+// could be a gocnab.LineUnmarshaler or a struct with CNAB tags.
+func unmarshalRecord(data []byte, v reflect.Value) error {
+	if lineUnmarshaler, ok := v.Addr().Interface().(LineUnmarshaler); ok {
+		if err := lineUnmarshaler.UnmarshalCNABLine(data); err != nil {
+			return RecordError{
+				Record: v.Type().String(),
+				Err:    err,
+			}
+		}
+
+		return nil
+	}
+
+	return unmarshalStruct(data, v)
+}
+
 func unmarshalStruct(data []byte, v reflect.Value) error {
-	structType := v.Type()
-	for i := 0; i < structType.NumField(); i++ {
-		structField := structType.Field(i)
-		begin, end, err := parseCNABFieldTag(structField, len(data))
-		if err != nil {
-			return FieldError{
-				Field: structField.Name,
-				Err:   err,
+	fields, err := structLayout(v.Type(), len(data))
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if field.options.hasConstant && !bytes.Equal(data[field.begin:field.end], field.constant) {
+			return UnmarshalFieldError{
+				Field: field.name,
+				Data:  data[field.begin:field.end],
+				Err:   ErrConstantMismatch,
 			}
 		}
 
-		// ignore fields without range or not exported
-		field := v.FieldByName(structField.Name)
-		if (begin == 0 && end == 0) || !field.CanSet() {
+		// ignore fields not exported
+		fieldValue := v.Field(field.index)
+		if !fieldValue.CanSet() {
 			continue
 		}
 
-		if err = unmarshalField(data, field, begin, end); err != nil {
+		if err = unmarshalField(data, fieldValue, field.begin, field.end); err != nil {
+			return UnmarshalFieldError{
+				Field: field.name,
+				Data:  data[field.begin:field.end],
+				Err:   err,
+			}
+		}
+
+		if err = validateField(data, fieldValue, field); err != nil {
 			return UnmarshalFieldError{
-				Field: structField.Name,
-				Data:  data[begin:end],
+				Field: field.name,
+				Data:  data[field.begin:field.end],
 				Err:   err,
 			}
 		}
 	}
 
+	if v.CanAddr() {
+		if afterUnmarshaler, ok := v.Addr().Interface().(AfterUnmarshaler); ok {
+			if err = afterUnmarshaler.AfterUnmarshalCNAB(); err != nil {
+				return RecordError{
+					Record: v.Type().String(),
+					Err:    err,
+				}
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -524,34 +925,145 @@ func unmarshalField(data []byte, v reflect.Value, begin, end int) error {
 	return ErrUnsupportedType
 }
 
-func parseCNABFieldTag(structField reflect.StructField, dataSize int) (begin int, end int, err error) {
+// fieldOptions stores the extra options of the CNAB tag, defined after the
+// range.
+type fieldOptions struct {
+	constant     string
+	hasConstant  bool
+	defaultValue string
+	hasDefault   bool
+	required     bool
+	enum         []string
+	min          float64
+	hasMin       bool
+	max          float64
+	hasMax       bool
+	regex        *regexp.Regexp
+	aggregate    *aggregateOption
+	keepCase     bool
+}
+
+func parseCNABFieldTag(structField reflect.StructField, dataSize int) (begin int, end int, options fieldOptions, err error) {
 	cnabFieldOptionsRaw := structField.Tag.Get("cnab")
 	if cnabFieldOptionsRaw == "" {
-		return 0, 0, nil
+		return 0, 0, options, nil
 	}
 
 	cnabFieldOptions := strings.Split(cnabFieldOptionsRaw, ",")
-	if len(cnabFieldOptions) != 2 {
-		return 0, 0, ErrInvalidFieldTagFormat
+	if len(cnabFieldOptions) < 2 {
+		return 0, 0, options, ErrInvalidFieldTagFormat
 	}
 
 	begin, err = strconv.Atoi(cnabFieldOptions[0])
 	if err != nil {
-		return 0, 0, ErrInvalidFieldTagBeginRange
+		return 0, 0, options, ErrInvalidFieldTagBeginRange
 	}
 
 	end, err = strconv.Atoi(cnabFieldOptions[1])
 	if err != nil {
-		return 0, 0, ErrInvalidFieldTagEndRange
+		return 0, 0, options, ErrInvalidFieldTagEndRange
 	}
 
 	if begin < 0 || end < begin || end > dataSize {
-		return 0, 0, ErrInvalidFieldTagRange
+		return 0, 0, options, ErrInvalidFieldTagRange
+	}
+
+	if options, err = parseCNABFieldOptions(structField, cnabFieldOptions[2:], end-begin); err != nil {
+		return 0, 0, options, err
 	}
 
 	return
 }
 
+func parseCNABFieldOptions(structField reflect.StructField, cnabFieldOptions []string, fieldSize int) (options fieldOptions, err error) {
+	for i, option := range cnabFieldOptions {
+		name, value := option, ""
+		if j := strings.Index(option, "="); j >= 0 {
+			name, value = option[:j], option[j+1:]
+		}
+
+		switch name {
+		case "const", "default":
+			// literals are written as they are, so they must fit in the field
+			if len(value) > fieldSize {
+				return options, ErrInvalidFieldTagOption
+			}
+
+			if name == "const" {
+				options.constant = value
+				options.hasConstant = true
+			} else {
+				options.defaultValue = value
+				options.hasDefault = true
+			}
+
+		case "required":
+			options.required = true
+
+		case "keepcase":
+			if structField.Type.Kind() != reflect.String {
+				return options, ErrInvalidFieldTagOption
+			}
+			options.keepCase = true
+
+		case "enum":
+			options.enum = strings.Split(value, "|")
+
+		case "min", "max":
+			switch structField.Type.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+				reflect.Float32, reflect.Float64:
+			default:
+				return options, ErrInvalidFieldTagOption
+			}
+
+			limit, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return options, ErrInvalidFieldTagOption
+			}
+
+			if name == "min" {
+				options.min = limit
+				options.hasMin = true
+			} else {
+				options.max = limit
+				options.hasMax = true
+			}
+
+		case "regex":
+			// regular expressions can contain commas, so the option consumes the rest
+			// of the tag
+			value = strings.Join(append([]string{value}, cnabFieldOptions[i+1:]...), ",")
+
+			// the whole content must match the expression
+			if options.regex, err = regexp.Compile("^(?:" + value + ")$"); err != nil {
+				return options, ErrInvalidFieldTagOption
+			}
+			return options, nil
+
+		case "count", "sum":
+			switch structField.Type.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+				reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+				reflect.Float32, reflect.Float64:
+			default:
+				return options, ErrInvalidFieldTagOption
+			}
+
+			var ok bool
+			if options.aggregate, ok = parseAggregateOption(name, value); !ok {
+				return options, ErrInvalidFieldTagOption
+			}
+
+		default:
+			return options, ErrInvalidFieldTagOption
+		}
+	}
+
+	return options, nil
+}
+
 // Marshaler is the interface implemented by types that can marshal themselves
 // into valid string representation.
 type Marshaler interface {
@@ -565,6 +1077,35 @@ type Unmarshaler interface {
 	UnmarshalCNAB([]byte) error
 }
 
+// LineMarshaler is the interface implemented by records that can marshal
+// themselves into a whole CNAB line, without using the CNAB tags. The returned
+// line must have exactly lineSize characters.
+type LineMarshaler interface {
+	MarshalCNABLine(lineSize int) ([]byte, error)
+}
+
+// LineUnmarshaler is the interface implemented by records that can unmarshal a
+// whole CNAB line of themselves, without using the CNAB tags.
+// UnmarshalCNABLine must copy the CNAB data if it wishes to retain the data
+// after returning.
+type LineUnmarshaler interface {
+	UnmarshalCNABLine([]byte) error
+}
+
+// BeforeMarshaler is the interface implemented by records that need to prepare
+// themselves right before being marshaled, like computing derived fields. The
+// hook is called on a copy of the record, so the original value isn't
+// modified.
+type BeforeMarshaler interface {
+	BeforeMarshalCNAB() error
+}
+
+// AfterUnmarshaler is the interface implemented by records that need to
+// normalize themselves right after being unmarshaled.
+type AfterUnmarshaler interface {
+	AfterUnmarshalCNAB() error
+}
+
 // FieldError problem detected in a field tag containing CNAB options or when
 // marshalling the field itself.
 type FieldError struct {
@@ -604,3 +1145,20 @@ func (u UnmarshalFieldError) Error() string {
 
 	return fmt.Sprintf("gocnab: error unmarshaling in field %s with data “%s”. details: %s", u.Field, dataStr, errStr)
 }
+
+// RecordError stores the error returned by a record hook (BeforeMarshalCNAB or
+// AfterUnmarshalCNAB).
+type RecordError struct {
+	Record string
+	Err    error
+}
+
+// Error return a human readable representation of the record error.
+func (r RecordError) Error() string {
+	errStr := "<nil>"
+	if r.Err != nil {
+		errStr = r.Err.Error()
+	}
+
+	return fmt.Sprintf("gocnab: error in record %s. details: %s", r.Record, errStr)
+}
@@ -1155,6 +1155,74 @@ func TestMarshal400(t *testing.T) {
 				Err:   gocnab.ErrUnsupportedType,
 			},
 		},
+		{
+			description: "it should write constant and default values",
+			vs: []interface{}{
+				struct {
+					Identifier int    `cnab:"0,1,const=0"`
+					Operation  string `cnab:"1,8,const=remessa"`
+					FieldA     string `cnab:"8,10,default=01"`
+					FieldB     string `cnab:"10,12,default=01"`
+					_          string `cnab:"12,15,const=237"`
+					_          string `cnab:"15,17,default=XX"`
+				}{
+					Identifier: 5,
+					Operation:  "SOMETHING",
+					FieldB:     "02",
+				},
+			},
+			expected: []byte(fmt.Sprintf("%-400s", "0REMESSA0102237XX")),
+		},
+		{
+			description: "it should keep the case of the fields with the keepcase option",
+			vs: []interface{}{
+				struct {
+					FieldA string `cnab:"0,20,keepcase"`
+					FieldB string `cnab:"20,25,keepcase,default=abc"`
+					FieldC string `cnab:"25,30"`
+				}{
+					FieldA: "Fulano@Example.com",
+					FieldC: "abc",
+				},
+			},
+			expected: []byte(fmt.Sprintf("%-400s", "Fulano@Example.com  abc  ABC")),
+		},
+		{
+			description: "it should detect the keepcase option in a field that isn't a string",
+			vs: []interface{}{
+				struct {
+					FieldA int `cnab:"0,5,keepcase"`
+				}{},
+			},
+			expectedError: gocnab.FieldError{
+				Field: "FieldA",
+				Err:   gocnab.ErrInvalidFieldTagOption,
+			},
+		},
+		{
+			description: "it should detect an unknown tag option",
+			vs: []interface{}{
+				struct {
+					FieldA int `cnab:"0,1,unknown"`
+				}{},
+			},
+			expectedError: gocnab.FieldError{
+				Field: "FieldA",
+				Err:   gocnab.ErrInvalidFieldTagOption,
+			},
+		},
+		{
+			description: "it should detect a constant that doesn't fit in the field",
+			vs: []interface{}{
+				struct {
+					FieldA string `cnab:"0,3,const=REMESSA"`
+				}{},
+			},
+			expectedError: gocnab.FieldError{
+				Field: "FieldA",
+				Err:   gocnab.ErrInvalidFieldTagOption,
+			},
+		},
 		{
 			description:   "it should detect an unsupported root type",
 			vs:            []interface{}{10},
@@ -2015,6 +2083,40 @@ func TestUnmarshal(t *testing.T) {
 				Err:   gocnab.ErrUnsupportedType,
 			},
 		},
+		{
+			description: "it should unmarshal a field with constant correctly",
+			data:        []byte(fmt.Sprintf("%-400s", "0REMESSA")),
+			v: &struct {
+				Identifier int    `cnab:"0,1,const=0"`
+				Operation  string `cnab:"1,8,const=REMESSA"`
+				_          string `cnab:"8,10,const="`
+			}{},
+			expected: &struct {
+				Identifier int    `cnab:"0,1,const=0"`
+				Operation  string `cnab:"1,8,const=REMESSA"`
+				_          string `cnab:"8,10,const="`
+			}{
+				Identifier: 0,
+				Operation:  "REMESSA",
+			},
+		},
+		{
+			description: "it should detect a content that doesn't match the constant",
+			data:        []byte(fmt.Sprintf("%-400s", "0RETORNO")),
+			v: &struct {
+				Identifier int    `cnab:"0,1,const=0"`
+				Operation  string `cnab:"1,8,const=REMESSA"`
+			}{},
+			expected: &struct {
+				Identifier int    `cnab:"0,1,const=0"`
+				Operation  string `cnab:"1,8,const=REMESSA"`
+			}{},
+			expectedError: gocnab.UnmarshalFieldError{
+				Field: "Operation",
+				Data:  []byte("RETORNO"),
+				Err:   gocnab.ErrConstantMismatch,
+			},
+		},
 		{
 			description: "it should detect an error while unmarshal to a mapper",
 			data: []byte(fmt.Sprintf("0%019d%-30s%10s%010d0000000000%-30s%-30s%100s\r\n\r\n1%019d%-30s%10s%010d1000000000%-30s%-30s%100s\r\n1%019d%-30s%10s%010d0000000001%-30s%-30s%100s\x1a",
@@ -2119,6 +2221,109 @@ func TestMarshalUnmarshal(t *testing.T) {
 	}
 }
 
+func TestMarshalUnmarshal_hooks(t *testing.T) {
+	t.Parallel()
+
+	input := []hookRecord{
+		{Name: "first"},
+		{Name: "second"},
+	}
+
+	data, err := gocnab.Marshal400(input)
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	expected := fmt.Sprintf("%-10s%05d%385s\r\n%-10s%05d%385s", "FIRST", 5, "", "SECOND", 6, "")
+	if expected != string(data) {
+		t.Errorf("expected data “%s” and got “%s”", expected, string(data))
+	}
+
+	if input[0].Size != 0 || input[1].Size != 0 {
+		t.Errorf("hook shouldn't change the original records")
+	}
+
+	var output []hookRecord
+	if err = gocnab.Unmarshal(data, &output); err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	expectedOutput := []hookRecord{
+		{Name: "first", Size: 5},
+		{Name: "second", Size: 6},
+	}
+
+	if !reflect.DeepEqual(expectedOutput, output) {
+		t.Errorf("expected data “%#v” and got “%#v”", expectedOutput, output)
+	}
+
+	expectedError := gocnab.RecordError{
+		Record: "gocnab_test.hookRecord",
+		Err:    errors.New("empty name"),
+	}
+
+	if _, err = gocnab.Marshal400(hookRecord{}); !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected error “%v” and got “%v”", expectedError, err)
+	}
+
+	var record hookRecord
+	if err = gocnab.Unmarshal([]byte(fmt.Sprintf("%10s%05d%385s", "", 0, "")), &record); !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected error “%v” and got “%v”", expectedError, err)
+	}
+}
+
+func TestMarshalUnmarshal_lineMarshaler(t *testing.T) {
+	t.Parallel()
+
+	input := []lineRecord{"first line", "second line"}
+
+	data, err := gocnab.Marshal240(input)
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	expected := fmt.Sprintf("%-240s\r\n%-240s", "first line", "second line")
+	if expected != string(data) {
+		t.Errorf("expected data “%s” and got “%s”", expected, string(data))
+	}
+
+	var output []lineRecord
+	if err = gocnab.Unmarshal(data, &output); err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(input, output) {
+		t.Errorf("expected data “%#v” and got “%#v”", input, output)
+	}
+
+	var single lineRecord
+	if err = gocnab.Unmarshal([]byte(fmt.Sprintf("%-240s", "single line")), &single); err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if single != "single line" {
+		t.Errorf("expected data “single line” and got “%s”", single)
+	}
+
+	expectedError := gocnab.RecordError{
+		Record: "gocnab_test.lineRecord",
+		Err:    gocnab.ErrInvalidLineSize,
+	}
+
+	if _, err = gocnab.Marshal240(lineRecord(strings.Repeat("X", 241))); !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected error “%v” and got “%v”", expectedError, err)
+	}
+
+	expectedError = gocnab.RecordError{
+		Record: "gocnab_test.lineRecord",
+		Err:    errors.New("empty line"),
+	}
+
+	if err = gocnab.Unmarshal([]byte(fmt.Sprintf("%240s", "")), &single); !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected error “%v” and got “%v”", expectedError, err)
+	}
+}
+
 func TestFieldError_Error(t *testing.T) {
 	t.Parallel()
 
@@ -2192,6 +2397,42 @@ func TestUnmarshalFieldError_Error(t *testing.T) {
 	}
 }
 
+func TestRecordError_Error(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		err         gocnab.RecordError
+		expected    string
+	}{
+		{
+			description: "it should build the error message correctly",
+			err: gocnab.RecordError{
+				Record: "main.header",
+				Err:    errors.New("invalid checksum"),
+			},
+			expected: "gocnab: error in record main.header. details: invalid checksum",
+		},
+		{
+			description: "it should detect when internal error is nil",
+			err: gocnab.RecordError{
+				Record: "main.header",
+			},
+			expected: "gocnab: error in record main.header. details: <nil>",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			text := scenario.err.Error()
+
+			if scenario.expected != text {
+				t.Errorf("expected text “%s” and got “%s”", scenario.expected, text)
+			}
+		})
+	}
+}
+
 func ExampleMarshal240() {
 	e := struct {
 		FieldA int     `cnab:"0,20"`
@@ -2421,3 +2662,46 @@ func (c *customType4) UnmarshalText(data []byte) error {
 func (c customType4) MarshalCNAB() ([]byte, error) {
 	return []byte(c.data), c.err
 }
+
+type hookRecord struct {
+	Name string `cnab:"0,10"`
+	Size int    `cnab:"10,15"`
+}
+
+func (h *hookRecord) BeforeMarshalCNAB() error {
+	if h.Name == "" {
+		return errors.New("empty name")
+	}
+
+	h.Size = len(h.Name)
+	return nil
+}
+
+func (h *hookRecord) AfterUnmarshalCNAB() error {
+	if h.Name == "" {
+		return errors.New("empty name")
+	}
+
+	h.Name = strings.ToLower(h.Name)
+	return nil
+}
+
+type lineRecord string
+
+func (l lineRecord) MarshalCNABLine(lineSize int) ([]byte, error) {
+	if len(l) > lineSize {
+		return []byte(l), nil
+	}
+
+	return []byte(string(l) + strings.Repeat(" ", lineSize-len(l))), nil
+}
+
+func (l *lineRecord) UnmarshalCNABLine(data []byte) error {
+	content := strings.TrimSpace(string(data))
+	if content == "" {
+		return errors.New("empty line")
+	}
+
+	*l = lineRecord(content)
+	return nil
+}
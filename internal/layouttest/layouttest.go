@@ -0,0 +1,211 @@
+// Package layouttest contains helpers shared by the tests of the ready-made
+// layouts.
+//
+// The golden files (testdata/*.golden) are generated by the code under test
+// when the tests run with the -update flag, so they only detect unintended
+// changes in the encoding: they aren't samples supplied by the banks, and the
+// positions of the layouts are only as right as their transcription from the
+// specifications. Sample files supplied by the banks must be stored with
+// another extension (e.g. .txt), so they are never rewritten by -update.
+//
+// To check the positions independently of the encoder, the tests also decode
+// lines assembled with SpecLine, where each field is transcribed from the
+// layout tables of the specifications, with the same 1-based positions.
+package layouttest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// CNAB240ControlFields are the fields of the CNAB 240 records filled by
+// cnab240.Marshal (batch and sequence numbers, and the trailer counters).
+var CNAB240ControlFields = []string{"Batch", "Sequence", "Records", "Batches"}
+
+// CNAB400ControlFields are the fields of the CNAB 400 records filled by
+// cnab400.Marshal (the sequential number of the line).
+var CNAB400ControlFields = []string{"Sequence"}
+
+// CheckGolden compares the data with the golden file testdata/name, that is
+// rewritten when the tests run with the -update flag, but only when it has the
+// .golden extension. The golden file content is returned, so it can be decoded
+// by the test.
+func CheckGolden(t *testing.T, name string, data []byte) []byte {
+	t.Helper()
+
+	golden := filepath.Join("testdata", name)
+	if *update && filepath.Ext(name) == ".golden" {
+		if err := os.WriteFile(golden, data, 0644); err != nil {
+			t.Fatalf("error updating golden file. details: %s", err)
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("error reading golden file. details: %s", err)
+	}
+
+	if !bytes.Equal(expected, data) {
+		t.Errorf("data doesn't match the golden file %s.\nexpected:\n%s\ngot:\n%s", golden, expected, data)
+	}
+
+	return expected
+}
+
+// SpecField is a field of a line as described in the layout tables of the
+// specifications, where the positions start at 1 and both ends are inclusive
+// (e.g. 001-003).
+type SpecField struct {
+	From  int
+	To    int
+	Value string
+}
+
+// Number returns a numeric field, right-aligned and filled with zeros. Amounts
+// must be given in cents.
+func Number(from, to int, value int64) SpecField {
+	return SpecField{From: from, To: to, Value: fmt.Sprintf("%0*d", to-from+1, value)}
+}
+
+// Text returns an alphanumeric field, left-aligned and filled with spaces.
+func Text(from, to int, value string) SpecField {
+	return SpecField{From: from, To: to, Value: fmt.Sprintf("%-*s", to-from+1, value)}
+}
+
+// SpecLine assembles a line with lineSize characters from the fields, filling
+// the positions without fields with spaces. The test fails when a field
+// doesn't have the size of its positions, doesn't fit in the line or overlaps
+// another field, so transcription mistakes are detected.
+func SpecLine(t *testing.T, lineSize int, fields ...SpecField) []byte {
+	t.Helper()
+
+	line := bytes.Repeat([]byte(" "), lineSize)
+	used := make([]bool, lineSize)
+
+	for _, field := range fields {
+		if field.From < 1 || field.To < field.From || field.To > lineSize {
+			t.Fatalf("field %03d-%03d doesn't fit in the line", field.From, field.To)
+		}
+
+		if len(field.Value) != field.To-field.From+1 {
+			t.Fatalf("field %03d-%03d with value “%s” of size %d", field.From, field.To, field.Value, len(field.Value))
+		}
+
+		for i := field.From - 1; i < field.To; i++ {
+			if used[i] {
+				t.Fatalf("field %03d-%03d overlaps another field", field.From, field.To)
+			}
+			used[i] = true
+		}
+
+		copy(line[field.From-1:], field.Value)
+	}
+
+	return line
+}
+
+// CheckCoverage verifies that the record types map all the positions of the
+// line, with fields or blank fields.
+func CheckCoverage(t *testing.T, lineSize int, records ...interface{}) {
+	t.Helper()
+
+	for _, record := range records {
+		name := strings.TrimPrefix(fmt.Sprintf("%T", record), "*")
+
+		t.Run(name, func(t *testing.T) {
+			coverage, err := gocnab.LayoutCoverage(record, lineSize)
+			if err != nil {
+				t.Fatalf("unexpected error. details: %s", err)
+			}
+
+			if len(coverage.Uncovered) > 0 {
+				t.Errorf("positions not mapped:\n%s", coverage)
+			}
+		})
+	}
+}
+
+// WithoutFields returns a copy of v where the exported integer fields with the
+// given names are set to zero, in v and in all the nested structs,
+// slices, pointers and interfaces. It is used to check that the control fields
+// are filled by the marshal functions, instead of by the tests.
+func WithoutFields[T any](v T, names ...string) T {
+	fields := make(map[string]bool, len(names))
+	for _, name := range names {
+		fields[name] = true
+	}
+
+	return withoutFields(reflect.ValueOf(&v).Elem(), fields).Interface().(T)
+}
+
+func withoutFields(v reflect.Value, names map[string]bool) reflect.Value {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+
+		result := reflect.New(v.Type()).Elem()
+		result.Set(withoutFields(v.Elem(), names))
+		return result
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+
+		result := reflect.New(v.Type().Elem())
+		result.Elem().Set(withoutFields(v.Elem(), names))
+		return result
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		result := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(withoutFields(v.Index(i), names))
+		}
+		return result
+
+	case reflect.Struct:
+		result := reflect.New(v.Type()).Elem()
+		result.Set(v)
+
+		for i := 0; i < v.NumField(); i++ {
+			field := result.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+
+			if names[v.Type().Field(i).Name] && isInteger(field.Kind()) {
+				field.Set(reflect.Zero(field.Type()))
+			} else {
+				field.Set(withoutFields(field, names))
+			}
+		}
+		return result
+	}
+
+	return v
+}
+
+func isInteger(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package gocnab
+
+import (
+	"io"
+	"iter"
+)
+
+// Records returns an iterator over the CNAB lines read from r, decoding one
+// line at a time into records of type T. Lines are only read when the
+// iteration requests them, so breaking the loop stops reading the input.
+//
+// Decoding errors are returned as gocnab.LineError, with the number of the
+// line, and the iteration can continue to the next lines. Errors reading the
+// input or related to the record type stop the iteration.
+//
+//	for record, err := range gocnab.Records[content](file) {
+//	  if err != nil {
+//	    return err
+//	  }
+//	  // ...
+//	}
+func Records[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		decoder := NewDecoder[T](r)
+
+		for {
+			record, err := decoder.Decode()
+			if err == io.EOF {
+				return
+			}
+
+			if !yield(record, err) {
+				return
+			}
+
+			if _, ok := err.(LineError); err != nil && !ok {
+				return
+			}
+		}
+	}
+}
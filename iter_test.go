@@ -0,0 +1,98 @@
+package gocnab_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+)
+
+func TestRecords(t *testing.T) {
+	t.Parallel()
+
+	data := fmt.Sprintf("1%-20s%010d%209s\r\n1%-20s%10s%209s\r\n1%-20s%010d%209s\x1a",
+		"FIRST", 10, "",
+		"SECOND", "XX", "",
+		"THIRD", 30, "")
+
+	var records []genericRecord
+	var lineErrors []int
+
+	for record, err := range gocnab.Records[genericRecord](strings.NewReader(data)) {
+		if err != nil {
+			var lineError gocnab.LineError
+			if !errors.As(err, &lineError) {
+				t.Fatalf("unexpected error. details: %s", err)
+			}
+
+			lineErrors = append(lineErrors, lineError.Line)
+			continue
+		}
+
+		records = append(records, record)
+	}
+
+	expected := []genericRecord{
+		{Identifier: 1, Name: "FIRST", Amount: 10},
+		{Identifier: 1, Name: "THIRD", Amount: 30},
+	}
+
+	if !reflect.DeepEqual(expected, records) {
+		t.Errorf("expected data “%#v” and got “%#v”", expected, records)
+	}
+
+	if !reflect.DeepEqual([]int{2}, lineErrors) {
+		t.Errorf("expected errors in lines “%v” and got “%v”", []int{2}, lineErrors)
+	}
+}
+
+func TestRecords_earlyBreak(t *testing.T) {
+	t.Parallel()
+
+	line := fmt.Sprintf("1%-20s%010d%209s", "RECORD", 10, "")
+	data := strings.Repeat(line+gocnab.LineBreak, 1000)
+	reader := &countingReader{reader: strings.NewReader(data)}
+
+	var count int
+	for _, err := range gocnab.Records[genericRecord](reader) {
+		if err != nil {
+			t.Fatalf("unexpected error. details: %s", err)
+		}
+
+		if count++; count == 2 {
+			break
+		}
+	}
+
+	if reader.read >= len(data) {
+		t.Errorf("expected to read only part of the %d bytes and read %d", len(data), reader.read)
+	}
+}
+
+func TestRecords_unsupportedType(t *testing.T) {
+	t.Parallel()
+
+	var errs []error
+	for _, err := range gocnab.Records[int](strings.NewReader("1\r\n2")) {
+		errs = append(errs, err)
+	}
+
+	if !reflect.DeepEqual([]error{gocnab.ErrUnsupportedType}, errs) {
+		t.Errorf("expected errors “%v” and got “%v”", []error{gocnab.ErrUnsupportedType}, errs)
+	}
+}
+
+type countingReader struct {
+	reader io.Reader
+	read   int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.read += n
+	return n, err
+}
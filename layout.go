@@ -0,0 +1,379 @@
+package gocnab
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// fieldLayout stores the parsed CNAB tag of a struct field, so the tags don't
+// need to be parsed again for every CNAB line.
+type fieldLayout struct {
+	name     string
+	index    int
+	exported bool
+	begin    int
+	end      int
+	options  fieldOptions
+
+	// constant is the CNAB content expected for fields with a constant option,
+	// already aligned in the field range.
+	constant []byte
+}
+
+type layoutCacheKey struct {
+	structType reflect.Type
+	lineSize   int
+}
+
+type layoutCacheEntry struct {
+	fields []fieldLayout
+	err    error
+}
+
+// layoutCache stores the already validated layouts by type and line size.
+var layoutCache sync.Map
+
+// ValidateLayout checks the CNAB tags of the struct type of v against a line
+// with lineSize characters. The accepted types of v are struct, pointer to
+// struct and slice of struct. Besides the tag format, it detects fields that
+// don't fit in the line, fields sharing the same range and fields with
+// overlapping ranges.
+//
+// The same validation is automatically executed on the first time a type is
+// marshaled or unmarshaled, so calling it directly is useful to detect layout
+// problems earlier, like in unit tests.
+//
+// For composite records the layout of each line is validated. The lines of a
+// composite record can't have count or sum options.
+func ValidateLayout(v interface{}, lineSize int) error {
+	structType, err := layoutStructType(v)
+	if err != nil {
+		return err
+	}
+
+	compositeFields, err := compositeLayout(structType)
+	if err != nil {
+		return err
+	}
+
+	for _, compositeField := range compositeFields {
+		if compositeField.recordType.Kind() != reflect.Struct {
+			continue
+		}
+
+		if _, err = structLayout(compositeField.recordType, lineSize); err != nil {
+			return err
+		}
+
+		if err = checkCompositeLine(compositeField.recordType, lineSize); err != nil {
+			return err
+		}
+	}
+
+	if compositeFields != nil {
+		return nil
+	}
+
+	_, err = structLayout(structType, lineSize)
+	return err
+}
+
+// layoutStructType returns the struct type from a struct, pointer to struct or
+// slice of struct.
+func layoutStructType(v interface{}) (reflect.Type, error) {
+	structType := reflect.TypeOf(v)
+	for structType != nil && (structType.Kind() == reflect.Ptr || structType.Kind() == reflect.Slice) {
+		structType = structType.Elem()
+	}
+
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return nil, ErrUnsupportedType
+	}
+
+	return structType, nil
+}
+
+// structLayout returns the CNAB fields of the struct type. The layout is
+// validated only on the first call for a type and line size, following calls
+// use the cached result.
+func structLayout(structType reflect.Type, lineSize int) ([]fieldLayout, error) {
+	key := layoutCacheKey{
+		structType: structType,
+		lineSize:   lineSize,
+	}
+
+	if entry, ok := layoutCache.Load(key); ok {
+		return entry.(layoutCacheEntry).fields, entry.(layoutCacheEntry).err
+	}
+
+	fields, err := buildStructLayout(structType, lineSize)
+	layoutCache.Store(key, layoutCacheEntry{
+		fields: fields,
+		err:    err,
+	})
+
+	return fields, err
+}
+
+func buildStructLayout(structType reflect.Type, lineSize int) ([]fieldLayout, error) {
+	var fields []fieldLayout
+
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		begin, end, options, err := parseCNABFieldTag(structField, lineSize)
+		if err != nil {
+			return nil, FieldError{
+				Field: structField.Name,
+				Err:   err,
+			}
+		}
+
+		// ignore fields without range
+		if begin == 0 && end == 0 {
+			continue
+		}
+
+		field := fieldLayout{
+			name:     structField.Name,
+			index:    i,
+			exported: structField.PkgPath == "",
+			begin:    begin,
+			end:      end,
+			options:  options,
+		}
+
+		if options.hasConstant {
+			field.constant = make([]byte, end-begin)
+			setFieldContent(field.constant, options.constant, 0, end-begin)
+		}
+
+		fields = append(fields, field)
+	}
+
+	if err := checkOverlaps(fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// checkOverlaps detects fields sharing the same CNAB positions. Empty ranges
+// don't use any position, so they never overlap.
+func checkOverlaps(fields []fieldLayout) error {
+	sorted := make([]fieldLayout, 0, len(fields))
+	for _, field := range fields {
+		if field.begin < field.end {
+			sorted = append(sorted, field)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].begin < sorted[j].begin
+	})
+
+	// as the fields are sorted by the beginning of the range, keeping the field
+	// that ends last is enough to detect any overlap
+	var previous fieldLayout
+	for i, current := range sorted {
+		if i == 0 || current.begin >= previous.end {
+			if current.end > previous.end {
+				previous = current
+			}
+			continue
+		}
+
+		err := ErrOverlappingFieldRange
+		if current.begin == previous.begin && current.end == previous.end {
+			err = ErrDuplicatedFieldRange
+		}
+
+		return LayoutError{
+			Field:      current.name,
+			OtherField: previous.name,
+			Err:        err,
+		}
+	}
+
+	return nil
+}
+
+// LayoutError conflict detected between the CNAB tags of two fields of the same
+// struct.
+type LayoutError struct {
+	Field      string
+	OtherField string
+	Err        error
+}
+
+// Error return a human readable representation of the layout error.
+func (l LayoutError) Error() string {
+	errStr := "<nil>"
+	if l.Err != nil {
+		errStr = l.Err.Error()
+	}
+
+	return fmt.Sprintf("gocnab: error in field %s conflicting with field %s. details: %s", l.Field, l.OtherField, errStr)
+}
+
+// ColumnRange positions [Begin,End) of a CNAB line, following the same
+// convention of the CNAB tag.
+type ColumnRange struct {
+	Begin int
+	End   int
+}
+
+// String return the range in the same format used in the CNAB tag.
+func (c ColumnRange) String() string {
+	return fmt.Sprintf("[%d,%d)", c.Begin, c.End)
+}
+
+// FieldRange positions of a CNAB line mapped by a struct field.
+type FieldRange struct {
+	Field string
+	Range ColumnRange
+
+	// Filler is true for not exported fields (like blank fields "_"), that are
+	// never filled by the library, reserving positions that are deliberately left
+	// blank.
+	Filler bool
+}
+
+// Coverage describes how the positions of a CNAB line are mapped by a struct
+// type.
+type Coverage struct {
+	LineSize  int
+	Fields    []FieldRange
+	Uncovered []ColumnRange
+}
+
+// coverageSymbols are used to identify each field in the ruler map.
+const coverageSymbols = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// coverageRulerWidth number of positions per row in the ruler map.
+const coverageRulerWidth = 100
+
+// String return a human readable map of the line positions. Each field is
+// represented by a symbol, described in the legend after the ruler, filler
+// fields are represented by "-" and not mapped positions by ".".
+func (c Coverage) String() string {
+	line := []byte(strings.Repeat(".", c.LineSize))
+	symbols := make([]byte, len(c.Fields))
+
+	var i int
+	for j, field := range c.Fields {
+		symbol := byte('-')
+		if !field.Filler {
+			symbol = coverageSymbols[i%len(coverageSymbols)]
+			i++
+		}
+
+		symbols[j] = symbol
+		for k := field.Range.Begin; k < field.Range.End; k++ {
+			line[k] = symbol
+		}
+	}
+
+	var output strings.Builder
+	for begin := 0; begin < c.LineSize; begin += coverageRulerWidth {
+		end := begin + coverageRulerWidth
+		if end > c.LineSize {
+			end = c.LineSize
+		}
+
+		var numbers, marks strings.Builder
+		for position := begin; position < end; position += 10 {
+			label := strconv.Itoa(position)
+			numbers.WriteString(label + strings.Repeat(" ", 10-len(label)))
+			marks.WriteString("|" + strings.Repeat(" ", 9))
+		}
+
+		output.WriteString(strings.TrimRight(numbers.String(), " ") + "\n")
+		output.WriteString(strings.TrimRight(marks.String(), " ") + "\n")
+		output.Write(line[begin:end])
+		output.WriteString("\n\n")
+	}
+
+	for j, field := range c.Fields {
+		fmt.Fprintf(&output, "%c %s %s\n", symbols[j], field.Range, field.Field)
+	}
+
+	for _, uncovered := range c.Uncovered {
+		fmt.Fprintf(&output, ". %s not mapped\n", uncovered)
+	}
+
+	return output.String()
+}
+
+// LayoutCoverage analyzes the positions of a CNAB line with lineSize
+// characters that are mapped by the struct type of v. The accepted types of v
+// are struct, pointer to struct and slice of struct. The layout is validated
+// in the same way as ValidateLayout.
+//
+// It is useful to assert in unit tests that every position is deliberately
+// mapped. Positions that are intentionally left blank can be declared with
+// blank fields, that are never filled by the library:
+//
+//	type header struct {
+//	  Identifier string `cnab:"0,1"`
+//	  _          string `cnab:"1,9"`
+//	  Name       string `cnab:"9,39"`
+//	}
+func LayoutCoverage(v interface{}, lineSize int) (Coverage, error) {
+	structType, err := layoutStructType(v)
+	if err != nil {
+		return Coverage{}, err
+	}
+
+	fields, err := structLayout(structType, lineSize)
+	if err != nil {
+		return Coverage{}, err
+	}
+
+	coverage := Coverage{
+		LineSize: lineSize,
+	}
+
+	for _, field := range fields {
+		if field.begin == field.end {
+			continue
+		}
+
+		coverage.Fields = append(coverage.Fields, FieldRange{
+			Field: field.name,
+			Range: ColumnRange{
+				Begin: field.begin,
+				End:   field.end,
+			},
+			Filler: !field.exported,
+		})
+	}
+
+	sort.SliceStable(coverage.Fields, func(i, j int) bool {
+		return coverage.Fields[i].Range.Begin < coverage.Fields[j].Range.Begin
+	})
+
+	// as the layout was already validated there are no overlaps
+	var position int
+	for _, field := range coverage.Fields {
+		if field.Range.Begin > position {
+			coverage.Uncovered = append(coverage.Uncovered, ColumnRange{
+				Begin: position,
+				End:   field.Range.Begin,
+			})
+		}
+		position = field.Range.End
+	}
+
+	if position < lineSize {
+		coverage.Uncovered = append(coverage.Uncovered, ColumnRange{
+			Begin: position,
+			End:   lineSize,
+		})
+	}
+
+	return coverage, nil
+}
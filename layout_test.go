@@ -0,0 +1,315 @@
+package gocnab_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+)
+
+func TestValidateLayout(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description   string
+		v             interface{}
+		lineSize      int
+		expectedError error
+	}{
+		{
+			description: "it should accept a valid layout",
+			v: struct {
+				FieldA int    `cnab:"0,20"`
+				FieldB string `cnab:"20,50"`
+				FieldC bool   `cnab:"50,51"`
+				FieldD string
+				FieldE int `cnab:"0,0"`
+			}{},
+			lineSize: 240,
+		},
+		{
+			description: "it should accept a pointer to struct",
+			v: &struct {
+				FieldA int `cnab:"0,20"`
+			}{},
+			lineSize: 240,
+		},
+		{
+			description: "it should accept a slice of struct",
+			v: []struct {
+				FieldA int `cnab:"0,20"`
+			}{},
+			lineSize: 240,
+		},
+		{
+			description: "it should consider not exported fields",
+			v: struct {
+				FieldA int    `cnab:"0,20"`
+				_      string `cnab:"10,30"`
+			}{},
+			lineSize: 240,
+			expectedError: gocnab.LayoutError{
+				Field:      "_",
+				OtherField: "FieldA",
+				Err:        gocnab.ErrOverlappingFieldRange,
+			},
+		},
+		{
+			description: "it should detect overlapping ranges",
+			v: struct {
+				FieldA int    `cnab:"10,20"`
+				FieldB string `cnab:"15,25"`
+			}{},
+			lineSize: 240,
+			expectedError: gocnab.LayoutError{
+				Field:      "FieldB",
+				OtherField: "FieldA",
+				Err:        gocnab.ErrOverlappingFieldRange,
+			},
+		},
+		{
+			description: "it should detect overlapping ranges out of order",
+			v: struct {
+				FieldA int    `cnab:"0,100"`
+				FieldB string `cnab:"150,160"`
+				FieldC string `cnab:"10,20"`
+			}{},
+			lineSize: 240,
+			expectedError: gocnab.LayoutError{
+				Field:      "FieldC",
+				OtherField: "FieldA",
+				Err:        gocnab.ErrOverlappingFieldRange,
+			},
+		},
+		{
+			description: "it should detect duplicated ranges",
+			v: struct {
+				FieldA int    `cnab:"10,20"`
+				FieldB string `cnab:"10,20"`
+			}{},
+			lineSize: 240,
+			expectedError: gocnab.LayoutError{
+				Field:      "FieldB",
+				OtherField: "FieldA",
+				Err:        gocnab.ErrDuplicatedFieldRange,
+			},
+		},
+		{
+			description: "it should detect a field beyond the line size",
+			v: struct {
+				FieldA int `cnab:"230,241"`
+			}{},
+			lineSize: 240,
+			expectedError: gocnab.FieldError{
+				Field: "FieldA",
+				Err:   gocnab.ErrInvalidFieldTagRange,
+			},
+		},
+		{
+			description: "it should detect an invalid field format",
+			v: struct {
+				FieldA int `cnab:"xxxxxxxx"`
+			}{},
+			lineSize: 240,
+			expectedError: gocnab.FieldError{
+				Field: "FieldA",
+				Err:   gocnab.ErrInvalidFieldTagFormat,
+			},
+		},
+		{
+			description:   "it should detect an unsupported type",
+			v:             []int{},
+			lineSize:      240,
+			expectedError: gocnab.ErrUnsupportedType,
+		},
+		{
+			description:   "it should detect a nil value",
+			v:             nil,
+			lineSize:      240,
+			expectedError: gocnab.ErrUnsupportedType,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			err := gocnab.ValidateLayout(scenario.v, scenario.lineSize)
+
+			if !reflect.DeepEqual(scenario.expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestValidateLayout_onFirstUse(t *testing.T) {
+	t.Parallel()
+
+	type overlapping struct {
+		FieldA int    `cnab:"10,20"`
+		FieldB string `cnab:"15,25"`
+	}
+
+	expectedError := gocnab.LayoutError{
+		Field:      "FieldB",
+		OtherField: "FieldA",
+		Err:        gocnab.ErrOverlappingFieldRange,
+	}
+
+	if _, err := gocnab.Marshal240(overlapping{}); !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected marshal error “%v” and got “%v”", expectedError, err)
+	}
+
+	var v overlapping
+	if err := gocnab.Unmarshal(make([]byte, 240), &v); !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected unmarshal error “%v” and got “%v”", expectedError, err)
+	}
+}
+
+func TestLayoutError_Error(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		err         gocnab.LayoutError
+		expected    string
+	}{
+		{
+			description: "it should build the error message correctly",
+			err: gocnab.LayoutError{
+				Field:      "FieldB",
+				OtherField: "FieldA",
+				Err:        gocnab.ErrOverlappingFieldRange,
+			},
+			expected: "gocnab: error in field FieldB conflicting with field FieldA. details: overlapping range in cnab tag",
+		},
+		{
+			description: "it should detect when internal error is nil",
+			err: gocnab.LayoutError{
+				Field:      "FieldB",
+				OtherField: "FieldA",
+			},
+			expected: "gocnab: error in field FieldB conflicting with field FieldA. details: <nil>",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			text := scenario.err.Error()
+
+			if scenario.expected != text {
+				t.Errorf("expected text “%s” and got “%s”", scenario.expected, text)
+			}
+		})
+	}
+}
+
+func TestLayoutCoverage(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description   string
+		v             interface{}
+		lineSize      int
+		expected      gocnab.Coverage
+		expectedError error
+	}{
+		{
+			description: "it should detect not mapped positions",
+			v: struct {
+				FieldA int    `cnab:"10,20"`
+				FieldB string `cnab:"0,5"`
+				FieldC string `cnab:"20,20"`
+				_      string `cnab:"20,30"`
+				FieldD string
+			}{},
+			lineSize: 40,
+			expected: gocnab.Coverage{
+				LineSize: 40,
+				Fields: []gocnab.FieldRange{
+					{Field: "FieldB", Range: gocnab.ColumnRange{Begin: 0, End: 5}},
+					{Field: "FieldA", Range: gocnab.ColumnRange{Begin: 10, End: 20}},
+					{Field: "_", Range: gocnab.ColumnRange{Begin: 20, End: 30}, Filler: true},
+				},
+				Uncovered: []gocnab.ColumnRange{
+					{Begin: 5, End: 10},
+					{Begin: 30, End: 40},
+				},
+			},
+		},
+		{
+			description: "it should detect a fully mapped line",
+			v: []struct {
+				FieldA int    `cnab:"0,20"`
+				FieldB string `cnab:"20,40"`
+			}{},
+			lineSize: 40,
+			expected: gocnab.Coverage{
+				LineSize: 40,
+				Fields: []gocnab.FieldRange{
+					{Field: "FieldA", Range: gocnab.ColumnRange{Begin: 0, End: 20}},
+					{Field: "FieldB", Range: gocnab.ColumnRange{Begin: 20, End: 40}},
+				},
+			},
+		},
+		{
+			description: "it should detect an invalid layout",
+			v: struct {
+				FieldA int    `cnab:"10,20"`
+				FieldB string `cnab:"15,25"`
+			}{},
+			lineSize: 40,
+			expectedError: gocnab.LayoutError{
+				Field:      "FieldB",
+				OtherField: "FieldA",
+				Err:        gocnab.ErrOverlappingFieldRange,
+			},
+		},
+		{
+			description:   "it should detect an unsupported type",
+			v:             10,
+			lineSize:      40,
+			expectedError: gocnab.ErrUnsupportedType,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			coverage, err := gocnab.LayoutCoverage(scenario.v, scenario.lineSize)
+
+			if !reflect.DeepEqual(scenario.expected, coverage) {
+				t.Errorf("expected coverage “%#v” and got “%#v”", scenario.expected, coverage)
+			}
+
+			if !reflect.DeepEqual(scenario.expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func ExampleLayoutCoverage() {
+	coverage, err := gocnab.LayoutCoverage(struct {
+		Identifier string `cnab:"0,1"`
+		_          string `cnab:"1,9"`
+		Name       string `cnab:"9,39"`
+		Amount     int    `cnab:"45,60"`
+	}{}, 60)
+
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Print(coverage)
+	// Output:
+	// 0         10        20        30        40        50
+	// |         |         |         |         |         |
+	// A--------BBBBBBBBBBBBBBBBBBBBBBBBBBBBBB......CCCCCCCCCCCCCCC
+	//
+	// A [0,1) Identifier
+	// - [1,9) _
+	// B [9,39) Name
+	// C [45,60) Amount
+	// . [39,45) not mapped
+}
@@ -0,0 +1,45 @@
+// Package bb contains the record types of the Banco do Brasil cobrança
+// layouts, ready to be used with the cnab240 and cnab400 packages.
+//
+// The CNAB 240 layout follows FEBRABAN (see the febraban240 package), with
+// BB-specific fields: the convênio of the headers is split into the number of
+// the convênio, the cobrança code, the carteira and its variação, and the
+// nosso número of the segments P and T is composed by the convênio and a
+// sequential number. The segments that don't have BB-specific fields are the
+// ones of the febraban240 package.
+//
+// The legacy CBR643 layout is a CNAB 400 layout for convênios of 7 digits,
+// where the details are the records of type 7 (see the CBR643 prefixed
+// types).
+//
+//	file := cnab400.File{
+//	  Header: bb.CBR643Header{...},
+//	  Details: []cnab400.Detail{
+//	    bb.CBR643Title{Detail: bb.CBR643Detail{...}, Fine: &bb.CBR643Fine{...}},
+//	  },
+//	  Trailer: bb.CBR643Trailer{},
+//	}
+//
+//	data, err := cnab400.Marshal(file)
+package bb
+
+import (
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+)
+
+// BankCode is the code of Banco do Brasil in the clearing system.
+const BankCode = 1
+
+// CobrancaCode is the code of the cobrança service of the convênio (cobrança
+// cedente).
+const CobrancaCode = "0014"
+
+// DocumentType identifies the type of the document (inscrição) of a company or
+// person. BB uses the FEBRABAN codes in both layouts.
+type DocumentType = febraban240.DocumentType
+
+// List of document types.
+const (
+	DocumentTypeCPF  = febraban240.DocumentTypeCPF
+	DocumentTypeCNPJ = febraban240.DocumentTypeCNPJ
+)
@@ -0,0 +1,31 @@
+package bb_test
+
+import (
+	"testing"
+
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/cnab400"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/bb"
+)
+
+func TestLayouts(t *testing.T) {
+	t.Parallel()
+
+	layouttest.CheckCoverage(t, cnab240.LineSize,
+		bb.FileHeader{},
+		bb.CobrancaBatchHeader{},
+		bb.SegmentP{},
+		bb.SegmentT{},
+	)
+
+	layouttest.CheckCoverage(t, cnab400.LineSize,
+		bb.CBR643Header{},
+		bb.CBR643Detail{},
+		bb.CBR643Fine{},
+		bb.CBR643Trailer{},
+		bb.CBR643ReturnHeader{},
+		bb.CBR643ReturnDetail{},
+		bb.CBR643ReturnTrailer{},
+	)
+}
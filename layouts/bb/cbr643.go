@@ -0,0 +1,258 @@
+package bb
+
+import (
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab400"
+)
+
+// Record types of the CBR643 details.
+const (
+	RecordTypeCBR643Detail = "7"
+	RecordTypeCBR643Fine   = "5"
+)
+
+// MatchCBR643Fine detects the CBR643 fine records (registro 5 with the service
+// type 99), as the record type 5 is shared with other optional records.
+var MatchCBR643Fine = gocnab.MatchAll(
+	cnab400.MatchRecordType(RecordTypeCBR643Fine),
+	gocnab.MatchRange(1, 3, "99"),
+)
+
+// CBR643Header is the header of a CBR643 remittance file (registro 0).
+type CBR643Header struct {
+	_                string       `cnab:"0,1,const=0"`
+	_                string       `cnab:"1,2,const=1"`
+	_                string       `cnab:"2,9,const=REMESSA"`
+	_                string       `cnab:"9,11,const=01"`
+	_                string       `cnab:"11,19,const=COBRANCA"`
+	_                string       `cnab:"19,26"`
+	Agency           int          `cnab:"26,30"`
+	AgencyDigit      string       `cnab:"30,31"`
+	Account          int          `cnab:"31,39"`
+	AccountDigit     string       `cnab:"39,40"`
+	_                string       `cnab:"40,46,const=000000"`
+	CompanyName      string       `cnab:"46,76"`
+	_                string       `cnab:"76,94,const=001BANCODOBRASIL"`
+	RecordingDate    cnab400.Date `cnab:"94,100"`
+	RemittanceNumber int          `cnab:"100,107"`
+	_                string       `cnab:"107,129"`
+	LeaderAgreement  int          `cnab:"129,136"`
+	_                string       `cnab:"136,394"`
+	Sequence         int          `cnab:"394,400"`
+}
+
+// CBR643Detail contains the data of a título in a CBR643 remittance file
+// (registro 7). The nosso número is composed by the convênio of 7 digits and a
+// sequential number of 10 digits.
+type CBR643Detail struct {
+	_                   string          `cnab:"0,1,const=7"`
+	CompanyDocumentType DocumentType    `cnab:"1,3"`
+	CompanyDocument     int64           `cnab:"3,17"`
+	Agency              int             `cnab:"17,21"`
+	AgencyDigit         string          `cnab:"21,22"`
+	Account             int             `cnab:"22,30"`
+	AccountDigit        string          `cnab:"30,31"`
+	Agreement           int             `cnab:"31,38"`
+	ControlNumber       string          `cnab:"38,63"`
+	OurNumberAgreement  int             `cnab:"63,70"`
+	OurNumber           int64           `cnab:"70,80"`
+	_                   string          `cnab:"80,82,const=00"`
+	_                   string          `cnab:"82,84,const=00"`
+	_                   string          `cnab:"84,87"`
+	GuarantorIndicator  string          `cnab:"87,88"`
+	_                   string          `cnab:"88,91"`
+	WalletVariation     int             `cnab:"91,94"`
+	_                   string          `cnab:"94,95,const=0"`
+	_                   string          `cnab:"95,101,const=000000"`
+	CobrancaType        string          `cnab:"101,106"`
+	Wallet              int             `cnab:"106,108"`
+	Command             InstructionCode `cnab:"108,110"`
+	DocumentNumber      string          `cnab:"110,120"`
+	DueDate             cnab400.Date    `cnab:"120,126"`
+	Amount              float64         `cnab:"126,139"`
+	_                   string          `cnab:"139,142,const=001"`
+	_                   string          `cnab:"142,146,const=0000"`
+	_                   string          `cnab:"146,147"`
+	TitleKind           string          `cnab:"147,149"`
+	Acceptance          string          `cnab:"149,150"`
+	IssueDate           cnab400.Date    `cnab:"150,156"`
+	Instruction1        string          `cnab:"156,158"`
+	Instruction2        string          `cnab:"158,160"`
+	DailyInterest       float64         `cnab:"160,173"`
+	DiscountDate        cnab400.Date    `cnab:"173,179"`
+	Discount            float64         `cnab:"179,192"`
+	IOF                 float64         `cnab:"192,205"`
+	Rebate              float64         `cnab:"205,218"`
+	PayerDocumentType   DocumentType    `cnab:"218,220"`
+	PayerDocument       int64           `cnab:"220,234"`
+	PayerName           string          `cnab:"234,271"`
+	_                   string          `cnab:"271,274"`
+	PayerAddress        string          `cnab:"274,314"`
+	PayerDistrict       string          `cnab:"314,326"`
+	PayerZipCode        int             `cnab:"326,334"`
+	PayerCity           string          `cnab:"334,349"`
+	PayerState          string          `cnab:"349,351"`
+	Notes               string          `cnab:"351,391"`
+	ProtestDays         int             `cnab:"391,393"`
+	_                   string          `cnab:"393,394"`
+	Sequence            int             `cnab:"394,400"`
+}
+
+// CBR643Fine contains the fine of a título (registro 5, tipo de serviço 99),
+// an optional record that follows the detail.
+type CBR643Fine struct {
+	_        string       `cnab:"0,1,const=5"`
+	_        string       `cnab:"1,3,const=99"`
+	Code     int          `cnab:"3,4"`
+	Date     cnab400.Date `cnab:"4,10"`
+	Value    float64      `cnab:"10,22"`
+	_        string       `cnab:"22,394"`
+	Sequence int          `cnab:"394,400"`
+}
+
+// CBR643Trailer is the trailer of a CBR643 remittance file (registro 9).
+type CBR643Trailer struct {
+	_        string `cnab:"0,1,const=9"`
+	_        string `cnab:"1,394"`
+	Sequence int    `cnab:"394,400"`
+}
+
+// CBR643Title is a título of a CBR643 remittance, composed by the consecutive
+// records 7 and optionally 5.
+type CBR643Title struct {
+	Detail CBR643Detail `cnab:"line"`
+	Fine   *CBR643Fine  `cnab:"line"`
+}
+
+// CBR643ReturnHeader is the header of a CBR643 return file (registro 0).
+type CBR643ReturnHeader struct {
+	_             string       `cnab:"0,1,const=0"`
+	_             string       `cnab:"1,2,const=2"`
+	_             string       `cnab:"2,9,const=RETORNO"`
+	_             string       `cnab:"9,11,const=01"`
+	_             string       `cnab:"11,19,const=COBRANCA"`
+	_             string       `cnab:"19,26"`
+	Agency        int          `cnab:"26,30"`
+	AgencyDigit   string       `cnab:"30,31"`
+	Account       int          `cnab:"31,39"`
+	AccountDigit  string       `cnab:"39,40"`
+	_             string       `cnab:"40,46,const=000000"`
+	CompanyName   string       `cnab:"46,76"`
+	_             string       `cnab:"76,94,const=001BANCODOBRASIL"`
+	RecordingDate cnab400.Date `cnab:"94,100"`
+	ReturnNumber  int          `cnab:"100,107"`
+	_             string       `cnab:"107,149"`
+	Agreement     int          `cnab:"149,156"`
+	_             string       `cnab:"156,394"`
+	Sequence      int          `cnab:"394,400"`
+}
+
+// CBR643ReturnDetail contains the occurrence of a título in a CBR643 return
+// file (registro 7).
+type CBR643ReturnDetail struct {
+	_                     string         `cnab:"0,1,const=7"`
+	CompanyDocumentType   DocumentType   `cnab:"1,3"`
+	CompanyDocument       int64          `cnab:"3,17"`
+	Agency                int            `cnab:"17,21"`
+	AgencyDigit           string         `cnab:"21,22"`
+	Account               int            `cnab:"22,30"`
+	AccountDigit          string         `cnab:"30,31"`
+	Agreement             int            `cnab:"31,38"`
+	ControlNumber         string         `cnab:"38,63"`
+	OurNumberAgreement    int            `cnab:"63,70"`
+	OurNumber             int64          `cnab:"70,80"`
+	CobrancaType          string         `cnab:"80,81"`
+	CommandCobrancaType   string         `cnab:"81,82"`
+	CalculationDays       int            `cnab:"82,86"`
+	SettlementNature      string         `cnab:"86,88"`
+	_                     string         `cnab:"88,91"`
+	WalletVariation       int            `cnab:"91,94"`
+	_                     string         `cnab:"94,95"`
+	DiscountRate          float64        `cnab:"95,100"`
+	IOFRate               float64        `cnab:"100,105"`
+	_                     string         `cnab:"105,106"`
+	Wallet                int            `cnab:"106,108"`
+	Command               OccurrenceCode `cnab:"108,110"`
+	SettlementDate        cnab400.Date   `cnab:"110,116"`
+	DocumentNumber        string         `cnab:"116,126"`
+	_                     string         `cnab:"126,146"`
+	DueDate               cnab400.Date   `cnab:"146,152"`
+	Amount                float64        `cnab:"152,165"`
+	CollectingBank        int            `cnab:"165,168"`
+	CollectingAgency      int            `cnab:"168,172"`
+	CollectingAgencyDigit string         `cnab:"172,173"`
+	TitleKind             string         `cnab:"173,175"`
+	CreditDate            cnab400.Date   `cnab:"175,181"`
+	Fee                   float64        `cnab:"181,188"`
+	OtherExpenses         float64        `cnab:"188,201"`
+	DiscountInterest      float64        `cnab:"201,214"`
+	DiscountIOF           float64        `cnab:"214,227"`
+	Rebate                float64        `cnab:"227,240"`
+	Discount              float64        `cnab:"240,253"`
+	PaidAmount            float64        `cnab:"253,266"`
+	Interest              float64        `cnab:"266,279"`
+	OtherCredits          float64        `cnab:"279,292"`
+	UnusedRebate          float64        `cnab:"292,305"`
+	EntryAmount           float64        `cnab:"305,318"`
+	DebitCredit           string         `cnab:"318,319"`
+	AdjustmentIndicator   string         `cnab:"319,320"`
+	Adjustment            float64        `cnab:"320,332"`
+	_                     string         `cnab:"332,390"`
+	PaymentChannel        string         `cnab:"390,392"`
+	_                     string         `cnab:"392,394"`
+	Sequence              int            `cnab:"394,400"`
+}
+
+// CBR643ReturnTrailer is the trailer of a CBR643 return file (registro 9), with
+// the summary of each type of cobrança.
+type CBR643ReturnTrailer struct {
+	_                string  `cnab:"0,1,const=9"`
+	_                string  `cnab:"1,2,const=2"`
+	_                string  `cnab:"2,4,const=01"`
+	_                string  `cnab:"4,7,const=001"`
+	_                string  `cnab:"7,17"`
+	SimpleCount      int     `cnab:"17,25"`
+	SimpleAmount     float64 `cnab:"25,39"`
+	SimpleNotice     string  `cnab:"39,47"`
+	_                string  `cnab:"47,57"`
+	LinkedCount      int     `cnab:"57,65"`
+	LinkedAmount     float64 `cnab:"65,79"`
+	LinkedNotice     string  `cnab:"79,87"`
+	_                string  `cnab:"87,97"`
+	PledgedCount     int     `cnab:"97,105"`
+	PledgedAmount    float64 `cnab:"105,119"`
+	PledgedNotice    string  `cnab:"119,127"`
+	_                string  `cnab:"127,137"`
+	DiscountedCount  int     `cnab:"137,145"`
+	DiscountedAmount float64 `cnab:"145,159"`
+	DiscountedNotice string  `cnab:"159,167"`
+	_                string  `cnab:"167,217"`
+	VendorCount      int     `cnab:"217,225"`
+	VendorAmount     float64 `cnab:"225,239"`
+	VendorNotice     string  `cnab:"239,247"`
+	_                string  `cnab:"247,394"`
+	Sequence         int     `cnab:"394,400"`
+}
+
+// NewCBR643RemittanceMapper returns a mapper with the CBR643 remittance record
+// types, to be used with cnab400.Unmarshal. The títulos are decoded as
+// CBR643Title, and fine records that aren't part of one are decoded
+// individually.
+func NewCBR643RemittanceMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*CBR643Header)(nil), cnab400.MatchRecordType(cnab400.RecordTypeHeader))
+	mapper.Register((*CBR643Title)(nil), cnab400.MatchRecordType(RecordTypeCBR643Detail))
+	mapper.Register((*CBR643Fine)(nil), MatchCBR643Fine)
+	mapper.Register((*CBR643Trailer)(nil), cnab400.MatchRecordType(cnab400.RecordTypeTrailer))
+	return mapper
+}
+
+// NewCBR643ReturnMapper returns a mapper with the CBR643 return record types,
+// to be used with cnab400.Unmarshal.
+func NewCBR643ReturnMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*CBR643ReturnHeader)(nil), cnab400.MatchRecordType(cnab400.RecordTypeHeader))
+	mapper.Register((*CBR643ReturnDetail)(nil), cnab400.MatchRecordType(RecordTypeCBR643Detail))
+	mapper.Register((*CBR643ReturnTrailer)(nil), cnab400.MatchRecordType(cnab400.RecordTypeTrailer))
+	return mapper
+}
@@ -0,0 +1,147 @@
+package bb
+
+// InstructionCode is the command of a título in a CBR643 remittance (comando),
+// defining the instruction sent to the bank.
+type InstructionCode string
+
+// List of instruction codes accepted by BB in the CBR643 layout.
+const (
+	InstructionEntry                InstructionCode = "01"
+	InstructionWriteOff             InstructionCode = "02"
+	InstructionDebitAccount         InstructionCode = "03"
+	InstructionGrantRebate          InstructionCode = "04"
+	InstructionCancelRebate         InstructionCode = "05"
+	InstructionChangeDueDate        InstructionCode = "06"
+	InstructionChangeControlNumber  InstructionCode = "07"
+	InstructionChangeDocumentNumber InstructionCode = "08"
+	InstructionProtest              InstructionCode = "09"
+	InstructionStopProtest          InstructionCode = "10"
+	InstructionWaiveInterest        InstructionCode = "11"
+	InstructionChangePayer          InstructionCode = "12"
+	InstructionChangeInterest       InstructionCode = "16"
+	InstructionGrantDiscount        InstructionCode = "31"
+	InstructionDontGrantDiscount    InstructionCode = "32"
+	InstructionChangeDiscount       InstructionCode = "33"
+	InstructionChangeDiscountDate   InstructionCode = "34"
+	InstructionChargeFine           InstructionCode = "35"
+	InstructionWaiveFine            InstructionCode = "36"
+	InstructionChangeCobrancaType   InstructionCode = "72"
+)
+
+var instructionDescriptions = map[InstructionCode]string{
+	InstructionEntry:                "Registro de títulos",
+	InstructionWriteOff:             "Solicitação de baixa",
+	InstructionDebitAccount:         "Pedido de débito em conta",
+	InstructionGrantRebate:          "Concessão de abatimento",
+	InstructionCancelRebate:         "Cancelamento de abatimento",
+	InstructionChangeDueDate:        "Alteração de vencimento de título",
+	InstructionChangeControlNumber:  "Alteração do número de controle do participante",
+	InstructionChangeDocumentNumber: "Alteração do número do título dado pelo cedente",
+	InstructionProtest:              "Instrução para protestar",
+	InstructionStopProtest:          "Instrução para sustar protesto",
+	InstructionWaiveInterest:        "Instrução para dispensar juros",
+	InstructionChangePayer:          "Alteração do nome e endereço do sacado",
+	InstructionChangeInterest:       "Alterar juros de mora",
+	InstructionGrantDiscount:        "Conceder desconto",
+	InstructionDontGrantDiscount:    "Não conceder desconto",
+	InstructionChangeDiscount:       "Retificar dados da concessão de desconto",
+	InstructionChangeDiscountDate:   "Alterar data para concessão de desconto",
+	InstructionChargeFine:           "Cobrar multa",
+	InstructionWaiveFine:            "Dispensar multa",
+	InstructionChangeCobrancaType:   "Alteração de tipo de cobrança",
+}
+
+// Description returns the description of the instruction code, or an empty
+// string for unknown codes.
+func (i InstructionCode) Description() string {
+	return instructionDescriptions[i]
+}
+
+// OccurrenceCode is the command of a título in a CBR643 return file (comando),
+// informing what happened with it.
+type OccurrenceCode string
+
+// List of occurrence codes of the CBR643 layout.
+const (
+	OccurrenceEntryConfirmed      OccurrenceCode = "02"
+	OccurrenceEntryRejected       OccurrenceCode = "03"
+	OccurrenceSettlementNoEntry   OccurrenceCode = "05"
+	OccurrenceSettlement          OccurrenceCode = "06"
+	OccurrenceSettlementOnAccount OccurrenceCode = "07"
+	OccurrenceSettlementByBalance OccurrenceCode = "08"
+	OccurrenceAutomaticWriteOff   OccurrenceCode = "09"
+	OccurrenceWriteOffRequested   OccurrenceCode = "10"
+	OccurrenceTitleInWallet       OccurrenceCode = "11"
+	OccurrenceRebateGranted       OccurrenceCode = "12"
+	OccurrenceRebateCancelled     OccurrenceCode = "13"
+	OccurrenceDueDateChanged      OccurrenceCode = "14"
+	OccurrenceNotarySettlement    OccurrenceCode = "15"
+	OccurrenceInterestChanged     OccurrenceCode = "16"
+	OccurrenceProtestConfirmed    OccurrenceCode = "19"
+	OccurrenceAccountDebit        OccurrenceCode = "20"
+	OccurrencePayerNameChanged    OccurrenceCode = "21"
+	OccurrencePayerAddressChanged OccurrenceCode = "22"
+	OccurrenceSentToNotary        OccurrenceCode = "23"
+	OccurrenceStopProtest         OccurrenceCode = "24"
+	OccurrenceInterestWaived      OccurrenceCode = "25"
+	OccurrenceDocumentChanged     OccurrenceCode = "26"
+	OccurrenceOverdueMaintenance  OccurrenceCode = "28"
+	OccurrenceDiscountGranted     OccurrenceCode = "31"
+	OccurrenceDiscountNotGranted  OccurrenceCode = "32"
+	OccurrenceDiscountChanged     OccurrenceCode = "33"
+	OccurrenceDiscountDateChanged OccurrenceCode = "34"
+	OccurrenceFineCharged         OccurrenceCode = "35"
+	OccurrenceFineWaived          OccurrenceCode = "36"
+	OccurrenceControlChanged      OccurrenceCode = "41"
+	OccurrenceReturnedCheck       OccurrenceCode = "44"
+	OccurrencePendingCheck        OccurrenceCode = "46"
+	OccurrenceCobrancaTypeChanged OccurrenceCode = "72"
+	OccurrenceProtestFees         OccurrenceCode = "96"
+	OccurrenceStopProtestFees     OccurrenceCode = "97"
+	OccurrenceAdvanceFeesDebit    OccurrenceCode = "98"
+)
+
+var occurrenceDescriptions = map[OccurrenceCode]string{
+	OccurrenceEntryConfirmed:      "Confirmação de entrada de título",
+	OccurrenceEntryRejected:       "Comando recusado",
+	OccurrenceSettlementNoEntry:   "Liquidado sem registro",
+	OccurrenceSettlement:          "Liquidação normal",
+	OccurrenceSettlementOnAccount: "Liquidação por conta",
+	OccurrenceSettlementByBalance: "Liquidação por saldo",
+	OccurrenceAutomaticWriteOff:   "Baixa de título",
+	OccurrenceWriteOffRequested:   "Baixa solicitada",
+	OccurrenceTitleInWallet:       "Títulos em ser",
+	OccurrenceRebateGranted:       "Abatimento concedido",
+	OccurrenceRebateCancelled:     "Abatimento cancelado",
+	OccurrenceDueDateChanged:      "Alteração de vencimento do título",
+	OccurrenceNotarySettlement:    "Liquidação em cartório",
+	OccurrenceInterestChanged:     "Confirmação de alteração de juros de mora",
+	OccurrenceProtestConfirmed:    "Confirmação de recebimento de instruções para protesto",
+	OccurrenceAccountDebit:        "Débito em conta",
+	OccurrencePayerNameChanged:    "Alteração do nome do sacado",
+	OccurrencePayerAddressChanged: "Alteração do endereço do sacado",
+	OccurrenceSentToNotary:        "Indicação de encaminhamento a cartório",
+	OccurrenceStopProtest:         "Sustar protesto",
+	OccurrenceInterestWaived:      "Dispensar juros de mora",
+	OccurrenceDocumentChanged:     "Alteração do número do título dado pelo cedente",
+	OccurrenceOverdueMaintenance:  "Manutenção de título vencido",
+	OccurrenceDiscountGranted:     "Conceder desconto",
+	OccurrenceDiscountNotGranted:  "Não conceder desconto",
+	OccurrenceDiscountChanged:     "Retificar desconto",
+	OccurrenceDiscountDateChanged: "Alterar data para desconto",
+	OccurrenceFineCharged:         "Cobrar multa",
+	OccurrenceFineWaived:          "Dispensar multa",
+	OccurrenceControlChanged:      "Alteração do número de controle do participante",
+	OccurrenceReturnedCheck:       "Título pago com cheque devolvido",
+	OccurrencePendingCheck:        "Título pago com cheque, aguardando compensação",
+	OccurrenceCobrancaTypeChanged: "Alteração de tipo de cobrança",
+	OccurrenceProtestFees:         "Despesas de protesto",
+	OccurrenceStopProtestFees:     "Despesas de sustação de protesto",
+	OccurrenceAdvanceFeesDebit:    "Débito de custas antecipadas",
+}
+
+// Description returns the description of the occurrence code, or an empty
+// string for unknown codes.
+func (o OccurrenceCode) Description() string {
+	return occurrenceDescriptions[o]
+}
@@ -0,0 +1,252 @@
+package bb_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab/cnab400"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/bb"
+)
+
+func TestCBR643_remittance(t *testing.T) {
+	t.Parallel()
+
+	file := cnab400.File{
+		Header: bb.CBR643Header{
+			Agency:           1234,
+			AgencyDigit:      "5",
+			Account:          67890,
+			AccountDigit:     "1",
+			CompanyName:      "EMPRESA EXEMPLO LTDA",
+			RecordingDate:    cnab400.NewDate(2026, 10, 18),
+			RemittanceNumber: 42,
+			LeaderAgreement:  1234567,
+			Sequence:         1,
+		},
+		Details: []cnab400.Detail{
+			bb.CBR643Title{
+				Detail: bb.CBR643Detail{
+					CompanyDocumentType: bb.DocumentTypeCNPJ,
+					CompanyDocument:     12345678000195,
+					Agency:              1234,
+					AgencyDigit:         "5",
+					Account:             67890,
+					AccountDigit:        "1",
+					Agreement:           1234567,
+					ControlNumber:       "PEDIDO 1001",
+					OurNumberAgreement:  1234567,
+					OurNumber:           1,
+					WalletVariation:     19,
+					Wallet:              17,
+					Command:             bb.InstructionEntry,
+					DocumentNumber:      "NF-1001",
+					DueDate:             cnab400.NewDate(2026, 11, 10),
+					Amount:              1500.75,
+					TitleKind:           "01",
+					Acceptance:          "N",
+					IssueDate:           cnab400.NewDate(2026, 10, 18),
+					DailyInterest:       0.5,
+					PayerDocumentType:   bb.DocumentTypeCPF,
+					PayerDocument:       12345678909,
+					PayerName:           "FULANO DE TAL",
+					PayerAddress:        "RUA DAS FLORES 100",
+					PayerDistrict:       "CENTRO",
+					PayerZipCode:        1001000,
+					PayerCity:           "SAO PAULO",
+					PayerState:          "SP",
+					Sequence:            2,
+				},
+				Fine: &bb.CBR643Fine{
+					Code:     2,
+					Date:     cnab400.NewDate(2026, 11, 11),
+					Value:    2,
+					Sequence: 3,
+				},
+			},
+			bb.CBR643Title{
+				Detail: bb.CBR643Detail{
+					CompanyDocumentType: bb.DocumentTypeCNPJ,
+					CompanyDocument:     12345678000195,
+					Agency:              1234,
+					AgencyDigit:         "5",
+					Account:             67890,
+					AccountDigit:        "1",
+					Agreement:           1234567,
+					ControlNumber:       "PEDIDO 1002",
+					OurNumberAgreement:  1234567,
+					OurNumber:           2,
+					WalletVariation:     19,
+					Wallet:              17,
+					Command:             bb.InstructionEntry,
+					DocumentNumber:      "NF-1002",
+					DueDate:             cnab400.NewDate(2026, 11, 20),
+					Amount:              99.9,
+					TitleKind:           "01",
+					Acceptance:          "N",
+					IssueDate:           cnab400.NewDate(2026, 10, 18),
+					PayerDocumentType:   bb.DocumentTypeCNPJ,
+					PayerDocument:       98765432000198,
+					PayerName:           "CLIENTE EXEMPLO SA",
+					PayerAddress:        "AVENIDA CENTRAL 200",
+					PayerZipCode:        20040020,
+					PayerCity:           "RIO DE JANEIRO",
+					PayerState:          "RJ",
+					ProtestDays:         10,
+					Sequence:            4,
+				},
+			},
+		},
+		Trailer: bb.CBR643Trailer{
+			Sequence: 5,
+		},
+	}
+
+	data, err := cnab400.Marshal(layouttest.WithoutFields(file, layouttest.CNAB400ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "cbr643_remessa.golden", data)
+
+	decoded, err := cnab400.Unmarshal(golden, bb.NewCBR643RemittanceMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+}
+
+func TestCBR643_return(t *testing.T) {
+	t.Parallel()
+
+	file := cnab400.File{
+		Header: bb.CBR643ReturnHeader{
+			Agency:        1234,
+			AgencyDigit:   "5",
+			Account:       67890,
+			AccountDigit:  "1",
+			CompanyName:   "EMPRESA EXEMPLO LTDA",
+			RecordingDate: cnab400.NewDate(2026, 11, 12),
+			ReturnNumber:  15,
+			Agreement:     1234567,
+			Sequence:      1,
+		},
+		Details: []cnab400.Detail{
+			bb.CBR643ReturnDetail{
+				CompanyDocumentType: bb.DocumentTypeCNPJ,
+				CompanyDocument:     12345678000195,
+				Agency:              1234,
+				AgencyDigit:         "5",
+				Account:             67890,
+				AccountDigit:        "1",
+				Agreement:           1234567,
+				ControlNumber:       "PEDIDO 1001",
+				OurNumberAgreement:  1234567,
+				OurNumber:           1,
+				CobrancaType:        "1",
+				WalletVariation:     19,
+				Wallet:              17,
+				Command:             bb.OccurrenceSettlement,
+				SettlementDate:      cnab400.NewDate(2026, 11, 12),
+				DocumentNumber:      "NF-1001",
+				DueDate:             cnab400.NewDate(2026, 11, 10),
+				Amount:              1500.75,
+				CollectingBank:      1,
+				CollectingAgency:    4321,
+				TitleKind:           "01",
+				CreditDate:          cnab400.NewDate(2026, 11, 13),
+				Fee:                 2.5,
+				PaidAmount:          1503.75,
+				Interest:            3,
+				EntryAmount:         1501.25,
+				DebitCredit:         "2",
+				Sequence:            2,
+			},
+			bb.CBR643ReturnDetail{
+				CompanyDocumentType: bb.DocumentTypeCNPJ,
+				CompanyDocument:     12345678000195,
+				Agency:              1234,
+				AgencyDigit:         "5",
+				Account:             67890,
+				AccountDigit:        "1",
+				Agreement:           1234567,
+				ControlNumber:       "PEDIDO 1002",
+				OurNumberAgreement:  1234567,
+				OurNumber:           2,
+				CobrancaType:        "1",
+				WalletVariation:     19,
+				Wallet:              17,
+				Command:             bb.OccurrenceEntryRejected,
+				DocumentNumber:      "NF-1002",
+				DueDate:             cnab400.NewDate(2026, 11, 20),
+				Amount:              99.9,
+				Sequence:            3,
+			},
+		},
+		Trailer: bb.CBR643ReturnTrailer{
+			SimpleCount:  2,
+			SimpleAmount: 1600.65,
+			Sequence:     4,
+		},
+	}
+
+	data, err := cnab400.Marshal(layouttest.WithoutFields(file, layouttest.CNAB400ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "cbr643_retorno.golden", data)
+
+	decoded, err := cnab400.Unmarshal(golden, bb.NewCBR643ReturnMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+
+	rejected := decoded.Details[1].(bb.CBR643ReturnDetail)
+	if description := rejected.Command.Description(); description != "Comando recusado" {
+		t.Errorf("unexpected occurrence description “%s”", description)
+	}
+}
+
+func TestMatchCBR643Fine(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		line        string
+		expected    bool
+	}{
+		{
+			description: "it should match a fine record",
+			line:        "599",
+			expected:    true,
+		},
+		{
+			description: "it should not match other records of type 5",
+			line:        "501",
+			expected:    false,
+		},
+		{
+			description: "it should not match a detail",
+			line:        "799",
+			expected:    false,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			line := []byte(scenario.line + strings.Repeat(" ", cnab400.LineSize-len(scenario.line)))
+			if matched := bb.MatchCBR643Fine(line); matched != scenario.expected {
+				t.Errorf("expected match “%t” and got “%t”", scenario.expected, matched)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package bb
+
+import (
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+)
+
+// FileHeader is the header of a CNAB 240 file (registro 0), with the convênio
+// split into the BB fields. The reserved field should contain "TS" in test
+// files.
+type FileHeader struct {
+	_                   string               `cnab:"0,3,const=001"`
+	_                   string               `cnab:"3,7,const=0000"`
+	_                   string               `cnab:"7,8,const=0"`
+	_                   string               `cnab:"8,17"`
+	CompanyDocumentType DocumentType         `cnab:"17,18"`
+	CompanyDocument     int64                `cnab:"18,32"`
+	Agreement           int                  `cnab:"32,41"`
+	_                   string               `cnab:"41,45,const=0014"`
+	Wallet              int                  `cnab:"45,47"`
+	WalletVariation     int                  `cnab:"47,50"`
+	Reserved            string               `cnab:"50,52"`
+	Agency              int                  `cnab:"52,57"`
+	AgencyDigit         string               `cnab:"57,58"`
+	Account             int64                `cnab:"58,70"`
+	AccountDigit        string               `cnab:"70,71"`
+	AgencyAccountDigit  string               `cnab:"71,72"`
+	CompanyName         string               `cnab:"72,102"`
+	_                   string               `cnab:"102,132,const=BANCO DO BRASIL S.A."`
+	_                   string               `cnab:"132,142"`
+	FileCode            febraban240.FileCode `cnab:"142,143"`
+	GenerationDate      cnab240.Date         `cnab:"143,151"`
+	GenerationTime      cnab240.Time         `cnab:"151,157"`
+	FileSequence        int                  `cnab:"157,163"`
+	LayoutVersion       string               `cnab:"163,166"`
+	Density             int                  `cnab:"166,171"`
+	BankReserved        string               `cnab:"171,191"`
+	CompanyReserved     string               `cnab:"191,211"`
+	_                   string               `cnab:"211,240"`
+}
+
+// CobrancaBatchHeader is the header of a cobrança batch (registro 1), with the
+// convênio split into the BB fields.
+type CobrancaBatchHeader struct {
+	_                   string       `cnab:"0,3,const=001"`
+	Batch               int          `cnab:"3,7"`
+	_                   string       `cnab:"7,8,const=1"`
+	Operation           string       `cnab:"8,9"`
+	_                   string       `cnab:"9,11,const=01"`
+	_                   string       `cnab:"11,13"`
+	LayoutVersion       string       `cnab:"13,16"`
+	_                   string       `cnab:"16,17"`
+	CompanyDocumentType DocumentType `cnab:"17,18"`
+	CompanyDocument     int64        `cnab:"18,33"`
+	Agreement           int          `cnab:"33,42"`
+	_                   string       `cnab:"42,46,const=0014"`
+	Wallet              int          `cnab:"46,48"`
+	WalletVariation     int          `cnab:"48,51"`
+	Reserved            string       `cnab:"51,53"`
+	Agency              int          `cnab:"53,58"`
+	AgencyDigit         string       `cnab:"58,59"`
+	Account             int64        `cnab:"59,71"`
+	AccountDigit        string       `cnab:"71,72"`
+	AgencyAccountDigit  string       `cnab:"72,73"`
+	CompanyName         string       `cnab:"73,103"`
+	Message1            string       `cnab:"103,143"`
+	Message2            string       `cnab:"143,183"`
+	RemittanceNumber    int          `cnab:"183,191"`
+	RecordingDate       cnab240.Date `cnab:"191,199"`
+	CreditDate          cnab240.Date `cnab:"199,207"`
+	_                   string       `cnab:"207,240"`
+}
+
+// SegmentP contains the main data of a título in a remittance (segmento P).
+// The nosso número is composed by the convênio of 7 digits and a sequential
+// number of 10 digits.
+type SegmentP struct {
+	_                     string                      `cnab:"0,3,const=001"`
+	Batch                 int                         `cnab:"3,7"`
+	_                     string                      `cnab:"7,8,const=3"`
+	Sequence              int                         `cnab:"8,13"`
+	_                     string                      `cnab:"13,14,const=P"`
+	_                     string                      `cnab:"14,15"`
+	Movement              febraban240.InstructionCode `cnab:"15,17"`
+	Agency                int                         `cnab:"17,22"`
+	AgencyDigit           string                      `cnab:"22,23"`
+	Account               int64                       `cnab:"23,35"`
+	AccountDigit          string                      `cnab:"35,36"`
+	AgencyAccountDigit    string                      `cnab:"36,37"`
+	OurNumberAgreement    int                         `cnab:"37,44"`
+	OurNumber             int64                       `cnab:"44,54"`
+	_                     string                      `cnab:"54,57"`
+	Wallet                int                         `cnab:"57,58"`
+	RegistrationType      int                         `cnab:"58,59"`
+	DocumentKind          int                         `cnab:"59,60"`
+	IssuanceType          int                         `cnab:"60,61"`
+	DistributionType      string                      `cnab:"61,62"`
+	DocumentNumber        string                      `cnab:"62,77"`
+	DueDate               cnab240.Date                `cnab:"77,85"`
+	Amount                float64                     `cnab:"85,100"`
+	CollectingAgency      int                         `cnab:"100,105"`
+	CollectingAgencyDigit string                      `cnab:"105,106"`
+	TitleKind             int                         `cnab:"106,108"`
+	Acceptance            string                      `cnab:"108,109"`
+	IssueDate             cnab240.Date                `cnab:"109,117"`
+	InterestCode          int                         `cnab:"117,118"`
+	InterestDate          cnab240.Date                `cnab:"118,126"`
+	Interest              float64                     `cnab:"126,141"`
+	DiscountCode          int                         `cnab:"141,142"`
+	DiscountDate          cnab240.Date                `cnab:"142,150"`
+	Discount              float64                     `cnab:"150,165"`
+	IOF                   float64                     `cnab:"165,180"`
+	Rebate                float64                     `cnab:"180,195"`
+	CompanyTitleID        string                      `cnab:"195,220"`
+	ProtestCode           int                         `cnab:"220,221"`
+	ProtestDays           int                         `cnab:"221,223"`
+	WriteOffCode          int                         `cnab:"223,224"`
+	WriteOffDays          int                         `cnab:"224,227"`
+	Currency              int                         `cnab:"227,229"`
+	Contract              int64                       `cnab:"229,239"`
+	PartialPayment        string                      `cnab:"239,240"`
+}
+
+// SegmentT contains the main data of a título in a return file (segmento T).
+// The nosso número is composed by the convênio of 7 digits and a sequential
+// number of 10 digits.
+type SegmentT struct {
+	_                     string                     `cnab:"0,3,const=001"`
+	Batch                 int                        `cnab:"3,7"`
+	_                     string                     `cnab:"7,8,const=3"`
+	Sequence              int                        `cnab:"8,13"`
+	_                     string                     `cnab:"13,14,const=T"`
+	_                     string                     `cnab:"14,15"`
+	Occurrence            febraban240.OccurrenceCode `cnab:"15,17"`
+	Agency                int                        `cnab:"17,22"`
+	AgencyDigit           string                     `cnab:"22,23"`
+	Account               int64                      `cnab:"23,35"`
+	AccountDigit          string                     `cnab:"35,36"`
+	AgencyAccountDigit    string                     `cnab:"36,37"`
+	OurNumberAgreement    int                        `cnab:"37,44"`
+	OurNumber             int64                      `cnab:"44,54"`
+	_                     string                     `cnab:"54,57"`
+	Wallet                int                        `cnab:"57,58"`
+	DocumentNumber        string                     `cnab:"58,73"`
+	DueDate               cnab240.Date               `cnab:"73,81"`
+	Amount                float64                    `cnab:"81,96"`
+	CollectingBank        int                        `cnab:"96,99"`
+	CollectingAgency      int                        `cnab:"99,104"`
+	CollectingAgencyDigit string                     `cnab:"104,105"`
+	CompanyTitleID        string                     `cnab:"105,130"`
+	Currency              int                        `cnab:"130,132"`
+	PayerDocumentType     DocumentType               `cnab:"132,133"`
+	PayerDocument         int64                      `cnab:"133,148"`
+	PayerName             string                     `cnab:"148,188"`
+	Contract              int64                      `cnab:"188,198"`
+	Fee                   float64                    `cnab:"198,213"`
+	OccurrenceReasons     string                     `cnab:"213,223"`
+	_                     string                     `cnab:"223,240"`
+}
+
+// Reasons returns the codes of the reasons of the occurrence (motivos da
+// ocorrência), that are stored in groups of 2 characters. Empty and zeroed
+// codes are ignored.
+func (s SegmentT) Reasons() []string {
+	return febraban240.SegmentT{OccurrenceReasons: s.OccurrenceReasons}.Reasons()
+}
+
+// Boleto is a título of a remittance, composed by the consecutive segments P,
+// Q and optionally R.
+type Boleto struct {
+	P SegmentP              `cnab:"line"`
+	Q febraban240.SegmentQ  `cnab:"line"`
+	R *febraban240.SegmentR `cnab:"line"`
+}
+
+// BoletoReturn is a título of a return file, composed by the consecutive
+// segments T and U.
+type BoletoReturn struct {
+	T SegmentT             `cnab:"line"`
+	U febraban240.SegmentU `cnab:"line"`
+}
+
+// NewCobrancaMapper returns a mapper with the CNAB 240 cobrança record types,
+// to be used with cnab240.Unmarshal. The títulos are decoded as Boleto
+// (remittance) and BoletoReturn (return file), and the segments without
+// BB-specific fields are decoded with the febraban240 types.
+func NewCobrancaMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*FileHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileHeader))
+	mapper.Register((*CobrancaBatchHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchHeader))
+	mapper.Register((*Boleto)(nil), cnab240.MatchSegment("P"))
+	mapper.Register((*febraban240.SegmentQ)(nil), cnab240.MatchSegment("Q"))
+	mapper.Register((*febraban240.SegmentR)(nil), cnab240.MatchSegment("R"))
+	mapper.Register((*febraban240.SegmentS)(nil), cnab240.MatchSegment("S"))
+	mapper.RegisterWithPriority((*febraban240.SegmentSMessages)(nil), febraban240.MatchSegmentSMessages, 1)
+	mapper.Register((*BoletoReturn)(nil), cnab240.MatchSegment("T"))
+	mapper.Register((*febraban240.SegmentU)(nil), cnab240.MatchSegment("U"))
+	mapper.Register((*febraban240.CobrancaBatchTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchTrailer))
+	mapper.Register((*febraban240.FileTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileTrailer))
+	return mapper
+}
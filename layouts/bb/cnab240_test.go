@@ -0,0 +1,256 @@
+package bb_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/bb"
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+)
+
+func cobrancaFileHeader(fileCode febraban240.FileCode) bb.FileHeader {
+	return bb.FileHeader{
+		CompanyDocumentType: bb.DocumentTypeCNPJ,
+		CompanyDocument:     12345678000195,
+		Agreement:           1234567,
+		Wallet:              17,
+		WalletVariation:     19,
+		Agency:              1234,
+		AgencyDigit:         "5",
+		Account:             67890,
+		AccountDigit:        "1",
+		CompanyName:         "EMPRESA EXEMPLO LTDA",
+		FileCode:            fileCode,
+		GenerationDate:      cnab240.NewDate(2026, 10, 18),
+		GenerationTime:      cnab240.NewTime(10, 30, 15),
+		FileSequence:        42,
+		LayoutVersion:       "083",
+	}
+}
+
+func cobrancaBatchHeader(operation string) bb.CobrancaBatchHeader {
+	return bb.CobrancaBatchHeader{
+		Batch:               1,
+		Operation:           operation,
+		LayoutVersion:       "042",
+		CompanyDocumentType: bb.DocumentTypeCNPJ,
+		CompanyDocument:     12345678000195,
+		Agreement:           1234567,
+		Wallet:              17,
+		WalletVariation:     19,
+		Agency:              1234,
+		AgencyDigit:         "5",
+		Account:             67890,
+		AccountDigit:        "1",
+		CompanyName:         "EMPRESA EXEMPLO LTDA",
+		RemittanceNumber:    42,
+		RecordingDate:       cnab240.NewDate(2026, 10, 18),
+	}
+}
+
+func TestCobranca_remittance(t *testing.T) {
+	t.Parallel()
+
+	file := cnab240.File{
+		Header: cobrancaFileHeader(febraban240.FileCodeRemittance),
+		Batches: []cnab240.Batch{
+			{
+				Header: cobrancaBatchHeader(febraban240.OperationRemittance),
+				Segments: []cnab240.Segment{
+					bb.Boleto{
+						P: bb.SegmentP{
+							Batch:              1,
+							Sequence:           1,
+							Movement:           febraban240.InstructionEntry,
+							Agency:             1234,
+							AgencyDigit:        "5",
+							Account:            67890,
+							AccountDigit:       "1",
+							OurNumberAgreement: 1234567,
+							OurNumber:          1,
+							Wallet:             7,
+							DocumentNumber:     "NF-1001",
+							DueDate:            cnab240.NewDate(2026, 11, 10),
+							Amount:             1500.75,
+							TitleKind:          2,
+							Acceptance:         "N",
+							IssueDate:          cnab240.NewDate(2026, 10, 18),
+							InterestCode:       1,
+							InterestDate:       cnab240.NewDate(2026, 11, 11),
+							Interest:           0.5,
+							CompanyTitleID:     "PEDIDO 1001",
+							ProtestCode:        3,
+							WriteOffCode:       1,
+							WriteOffDays:       60,
+							Currency:           9,
+						},
+						Q: febraban240.SegmentQ{
+							Bank:              1,
+							Batch:             1,
+							Sequence:          2,
+							Movement:          febraban240.InstructionEntry,
+							PayerDocumentType: bb.DocumentTypeCPF,
+							PayerDocument:     12345678909,
+							PayerName:         "FULANO DE TAL",
+							PayerAddress:      "RUA DAS FLORES 100",
+							PayerDistrict:     "CENTRO",
+							PayerZipCode:      1001,
+							PayerCity:         "SAO PAULO",
+							PayerState:        "SP",
+						},
+						R: &febraban240.SegmentR{
+							Bank:     1,
+							Batch:    1,
+							Sequence: 3,
+							Movement: febraban240.InstructionEntry,
+							FineCode: 2,
+							FineDate: cnab240.NewDate(2026, 11, 11),
+							Fine:     2,
+						},
+					},
+				},
+				Trailer: febraban240.CobrancaBatchTrailer{
+					Bank:    1,
+					Batch:   1,
+					Records: 5,
+				},
+			},
+		},
+		Trailer: febraban240.FileTrailer{
+			Bank:    1,
+			Batches: 1,
+			Records: 7,
+		},
+	}
+
+	data, err := cnab240.Marshal(layouttest.WithoutFields(file, layouttest.CNAB240ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "cobranca_remessa.golden", data)
+
+	decoded, err := cnab240.Unmarshal(golden, bb.NewCobrancaMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+}
+
+func TestCobranca_return(t *testing.T) {
+	t.Parallel()
+
+	file := cnab240.File{
+		Header: cobrancaFileHeader(febraban240.FileCodeReturn),
+		Batches: []cnab240.Batch{
+			{
+				Header: cobrancaBatchHeader(febraban240.OperationReturn),
+				Segments: []cnab240.Segment{
+					bb.BoletoReturn{
+						T: bb.SegmentT{
+							Batch:              1,
+							Sequence:           1,
+							Occurrence:         febraban240.OccurrenceSettlement,
+							Agency:             1234,
+							AgencyDigit:        "5",
+							Account:            67890,
+							AccountDigit:       "1",
+							OurNumberAgreement: 1234567,
+							OurNumber:          1,
+							Wallet:             7,
+							DocumentNumber:     "NF-1001",
+							DueDate:            cnab240.NewDate(2026, 11, 10),
+							Amount:             1500.75,
+							CollectingBank:     1,
+							CollectingAgency:   4321,
+							CompanyTitleID:     "PEDIDO 1001",
+							Currency:           9,
+							PayerDocumentType:  bb.DocumentTypeCPF,
+							PayerDocument:      12345678909,
+							PayerName:          "FULANO DE TAL",
+							Fee:                2.5,
+						},
+						U: febraban240.SegmentU{
+							Bank:           1,
+							Batch:          1,
+							Sequence:       2,
+							Occurrence:     febraban240.OccurrenceSettlement,
+							Charges:        3,
+							PaidAmount:     1503.75,
+							NetAmount:      1501.25,
+							OccurrenceDate: cnab240.NewDate(2026, 11, 12),
+							CreditDate:     cnab240.NewDate(2026, 11, 13),
+						},
+					},
+					bb.BoletoReturn{
+						T: bb.SegmentT{
+							Batch:              1,
+							Sequence:           3,
+							Occurrence:         febraban240.OccurrenceEntryRejected,
+							Agency:             1234,
+							AgencyDigit:        "5",
+							Account:            67890,
+							AccountDigit:       "1",
+							OurNumberAgreement: 1234567,
+							OurNumber:          2,
+							Wallet:             7,
+							DocumentNumber:     "NF-1002",
+							DueDate:            cnab240.NewDate(2026, 11, 20),
+							Amount:             99.9,
+							Currency:           9,
+							PayerDocumentType:  bb.DocumentTypeCNPJ,
+							PayerDocument:      98765432000198,
+							PayerName:          "CLIENTE EXEMPLO SA",
+							OccurrenceReasons:  "0816",
+						},
+						U: febraban240.SegmentU{
+							Bank:           1,
+							Batch:          1,
+							Sequence:       4,
+							Occurrence:     febraban240.OccurrenceEntryRejected,
+							OccurrenceDate: cnab240.NewDate(2026, 10, 19),
+						},
+					},
+				},
+				Trailer: febraban240.CobrancaBatchTrailer{
+					Bank:         1,
+					Batch:        1,
+					Records:      6,
+					SimpleCount:  1,
+					SimpleAmount: 1500.75,
+				},
+			},
+		},
+		Trailer: febraban240.FileTrailer{
+			Bank:    1,
+			Batches: 1,
+			Records: 8,
+		},
+	}
+
+	data, err := cnab240.Marshal(layouttest.WithoutFields(file, layouttest.CNAB240ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "cobranca_retorno.golden", data)
+
+	decoded, err := cnab240.Unmarshal(golden, bb.NewCobrancaMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+
+	rejected := decoded.Batches[0].Segments[1].(bb.BoletoReturn)
+	if reasons := rejected.T.Reasons(); !reflect.DeepEqual([]string{"08", "16"}, reasons) {
+		t.Errorf("unexpected occurrence reasons “%v”", reasons)
+	}
+}
@@ -0,0 +1,341 @@
+// Package bradesco400 contains the record types of the Bradesco CNAB 400
+// cobrança layout, ready to be used with the cnab400 package.
+//
+// The sequential number of the records is filled by cnab400.Marshal, so it
+// doesn't need to be filled. The constant fields of each record are declared
+// as blank fields with the const option, and the positions reserved by the
+// bank are blank fields that are always written with spaces.
+//
+//	file := cnab400.File{
+//	  Header: bradesco400.Header{...},
+//	  Details: []cnab400.Detail{
+//	    bradesco400.Title{Detail: bradesco400.Detail{...}, Messages: &bradesco400.Messages{...}},
+//	  },
+//	  Trailer: bradesco400.Trailer{},
+//	}
+//
+//	data, err := cnab400.Marshal(file)
+package bradesco400
+
+import (
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab400"
+)
+
+// BankCode is the code of Bradesco in the clearing system.
+const BankCode = "237"
+
+// Record types of the details.
+const (
+	RecordTypeDetail        = "1"
+	RecordTypeMessages      = "2"
+	RecordTypeApportionment = "3"
+)
+
+// DocumentType identifies the type of the document (inscrição) of the payer or
+// of the company.
+type DocumentType int
+
+// List of document types.
+const (
+	DocumentTypeCPF  DocumentType = 1
+	DocumentTypeCNPJ DocumentType = 2
+)
+
+// Header is the header of a remittance file (registro 0).
+type Header struct {
+	_                string       `cnab:"0,1,const=0"`
+	_                string       `cnab:"1,2,const=1"`
+	_                string       `cnab:"2,9,const=REMESSA"`
+	_                string       `cnab:"9,11,const=01"`
+	_                string       `cnab:"11,26,const=COBRANCA"`
+	CompanyCode      int64        `cnab:"26,46"`
+	CompanyName      string       `cnab:"46,76"`
+	_                string       `cnab:"76,79,const=237"`
+	_                string       `cnab:"79,94,const=BRADESCO"`
+	RecordingDate    cnab400.Date `cnab:"94,100"`
+	_                string       `cnab:"100,108"`
+	_                string       `cnab:"108,110,const=MX"`
+	RemittanceNumber int          `cnab:"110,117"`
+	_                string       `cnab:"117,394"`
+	Sequence         int          `cnab:"394,400"`
+}
+
+// Detail contains the data of a título in a remittance file (registro 1).
+type Detail struct {
+	_                    string          `cnab:"0,1,const=1"`
+	DebitAgency          int             `cnab:"1,6"`
+	DebitAgencyDigit     string          `cnab:"6,7"`
+	DebitAccountPrefix   int             `cnab:"7,12"`
+	DebitAccount         int             `cnab:"12,19"`
+	DebitAccountDigit    string          `cnab:"19,20"`
+	_                    string          `cnab:"20,21,const=0"`
+	Wallet               int             `cnab:"21,24"`
+	Agency               int             `cnab:"24,29"`
+	Account              int             `cnab:"29,36"`
+	AccountDigit         string          `cnab:"36,37"`
+	ControlNumber        string          `cnab:"37,62"`
+	DebitBank            int             `cnab:"62,65"`
+	FineCode             int             `cnab:"65,66"`
+	FinePercentage       float64         `cnab:"66,70"`
+	OurNumber            int64           `cnab:"70,81"`
+	OurNumberDigit       string          `cnab:"81,82"`
+	DailyBonus           float64         `cnab:"82,92"`
+	PrintCondition       int             `cnab:"92,93"`
+	AutomaticDebitPrint  string          `cnab:"93,94"`
+	_                    string          `cnab:"94,104"`
+	Apportionment        string          `cnab:"104,105"`
+	AutomaticDebitNotice string          `cnab:"105,106"`
+	Installments         string          `cnab:"106,108"`
+	Occurrence           InstructionCode `cnab:"108,110"`
+	DocumentNumber       string          `cnab:"110,120"`
+	DueDate              cnab400.Date    `cnab:"120,126"`
+	Amount               float64         `cnab:"126,139"`
+	_                    string          `cnab:"139,142,const=000"`
+	_                    string          `cnab:"142,147,const=00000"`
+	TitleKind            string          `cnab:"147,149"`
+	_                    string          `cnab:"149,150,const=N"`
+	IssueDate            cnab400.Date    `cnab:"150,156"`
+	Instruction1         string          `cnab:"156,158"`
+	Instruction2         string          `cnab:"158,160"`
+	DailyInterest        float64         `cnab:"160,173"`
+	DiscountDate         cnab400.Date    `cnab:"173,179"`
+	Discount             float64         `cnab:"179,192"`
+	IOF                  float64         `cnab:"192,205"`
+	Rebate               float64         `cnab:"205,218"`
+	PayerDocumentType    DocumentType    `cnab:"218,220"`
+	PayerDocument        int64           `cnab:"220,234"`
+	PayerName            string          `cnab:"234,274"`
+	PayerAddress         string          `cnab:"274,314"`
+	Message1             string          `cnab:"314,326"`
+	PayerZipCode         int             `cnab:"326,331"`
+	PayerZipCodeSuffix   int             `cnab:"331,334"`
+	GuarantorOrMessage2  string          `cnab:"334,394"`
+	Sequence             int             `cnab:"394,400"`
+}
+
+// Messages contains the messages printed in the boleto and the additional
+// discounts of a título (registro 2).
+type Messages struct {
+	_              string       `cnab:"0,1,const=2"`
+	Message1       string       `cnab:"1,81"`
+	Message2       string       `cnab:"81,161"`
+	Message3       string       `cnab:"161,241"`
+	Message4       string       `cnab:"241,321"`
+	DiscountDate2  cnab400.Date `cnab:"321,327"`
+	Discount2      float64      `cnab:"327,340"`
+	DiscountDate3  cnab400.Date `cnab:"340,346"`
+	Discount3      float64      `cnab:"346,359"`
+	_              string       `cnab:"359,366"`
+	Wallet         int          `cnab:"366,369"`
+	Agency         int          `cnab:"369,374"`
+	Account        int          `cnab:"374,381"`
+	AccountDigit   string       `cnab:"381,382"`
+	OurNumber      int64        `cnab:"382,393"`
+	OurNumberDigit string       `cnab:"393,394"`
+	Sequence       int          `cnab:"394,400"`
+}
+
+// Apportionment contains the split of the credit of a título among up to
+// three beneficiaries (registro 3, rateio de crédito).
+type Apportionment struct {
+	_                        string  `cnab:"0,1,const=3"`
+	Wallet                   int     `cnab:"1,4"`
+	Agency                   int     `cnab:"4,9"`
+	Account                  int     `cnab:"9,16"`
+	AccountDigit             string  `cnab:"16,17"`
+	OurNumber                int64   `cnab:"17,28"`
+	OurNumberDigit           string  `cnab:"28,29"`
+	CalculationCode          int     `cnab:"29,30"`
+	ValueType                int     `cnab:"30,31"`
+	_                        string  `cnab:"31,43"`
+	Beneficiary1Bank         int     `cnab:"43,46"`
+	Beneficiary1Agency       int     `cnab:"46,51"`
+	Beneficiary1AgencyDigit  string  `cnab:"51,52"`
+	Beneficiary1Account      int64   `cnab:"52,64"`
+	Beneficiary1AccountDigit string  `cnab:"64,65"`
+	Beneficiary1Value        float64 `cnab:"65,80"`
+	Beneficiary1Name         string  `cnab:"80,120"`
+	_                        string  `cnab:"120,151"`
+	Beneficiary1Installment  string  `cnab:"151,157"`
+	Beneficiary1Floating     int     `cnab:"157,160"`
+	Beneficiary2Bank         int     `cnab:"160,163"`
+	Beneficiary2Agency       int     `cnab:"163,168"`
+	Beneficiary2AgencyDigit  string  `cnab:"168,169"`
+	Beneficiary2Account      int64   `cnab:"169,181"`
+	Beneficiary2AccountDigit string  `cnab:"181,182"`
+	Beneficiary2Value        float64 `cnab:"182,197"`
+	Beneficiary2Name         string  `cnab:"197,237"`
+	_                        string  `cnab:"237,268"`
+	Beneficiary2Installment  string  `cnab:"268,274"`
+	Beneficiary2Floating     int     `cnab:"274,277"`
+	Beneficiary3Bank         int     `cnab:"277,280"`
+	Beneficiary3Agency       int     `cnab:"280,285"`
+	Beneficiary3AgencyDigit  string  `cnab:"285,286"`
+	Beneficiary3Account      int64   `cnab:"286,298"`
+	Beneficiary3AccountDigit string  `cnab:"298,299"`
+	Beneficiary3Value        float64 `cnab:"299,314"`
+	Beneficiary3Name         string  `cnab:"314,354"`
+	_                        string  `cnab:"354,385"`
+	Beneficiary3Installment  string  `cnab:"385,391"`
+	Beneficiary3Floating     int     `cnab:"391,394"`
+	Sequence                 int     `cnab:"394,400"`
+}
+
+// Trailer is the trailer of a remittance file (registro 9).
+type Trailer struct {
+	_        string `cnab:"0,1,const=9"`
+	_        string `cnab:"1,394"`
+	Sequence int    `cnab:"394,400"`
+}
+
+// Title is a título of a remittance, composed by the consecutive records 1
+// and optionally 2 and 3.
+type Title struct {
+	Detail        Detail         `cnab:"line"`
+	Messages      *Messages      `cnab:"line"`
+	Apportionment *Apportionment `cnab:"line"`
+}
+
+// ReturnHeader is the header of a return file (registro 0).
+type ReturnHeader struct {
+	_             string       `cnab:"0,1,const=0"`
+	_             string       `cnab:"1,2,const=2"`
+	_             string       `cnab:"2,9,const=RETORNO"`
+	_             string       `cnab:"9,11,const=01"`
+	_             string       `cnab:"11,26,const=COBRANCA"`
+	CompanyCode   int64        `cnab:"26,46"`
+	CompanyName   string       `cnab:"46,76"`
+	_             string       `cnab:"76,79,const=237"`
+	_             string       `cnab:"79,94,const=BRADESCO"`
+	RecordingDate cnab400.Date `cnab:"94,100"`
+	Density       int          `cnab:"100,108"`
+	Notice        int          `cnab:"108,113"`
+	_             string       `cnab:"113,379"`
+	CreditDate    cnab400.Date `cnab:"379,385"`
+	_             string       `cnab:"385,394"`
+	Sequence      int          `cnab:"394,400"`
+}
+
+// ReturnDetail contains the occurrence of a título in a return file (registro
+// 1).
+type ReturnDetail struct {
+	_                   string         `cnab:"0,1,const=1"`
+	CompanyDocumentType DocumentType   `cnab:"1,3"`
+	CompanyDocument     int64          `cnab:"3,17"`
+	_                   string         `cnab:"17,20,const=000"`
+	_                   string         `cnab:"20,21,const=0"`
+	Wallet              int            `cnab:"21,24"`
+	Agency              int            `cnab:"24,29"`
+	Account             int            `cnab:"29,36"`
+	AccountDigit        string         `cnab:"36,37"`
+	ControlNumber       string         `cnab:"37,62"`
+	_                   string         `cnab:"62,70"`
+	OurNumber           int64          `cnab:"70,81"`
+	OurNumberDigit      string         `cnab:"81,82"`
+	_                   string         `cnab:"82,104"`
+	Apportionment       string         `cnab:"104,105"`
+	PartialPayment      string         `cnab:"105,107"`
+	WalletCode          int            `cnab:"107,108"`
+	Occurrence          OccurrenceCode `cnab:"108,110"`
+	OccurrenceDate      cnab400.Date   `cnab:"110,116"`
+	DocumentNumber      string         `cnab:"116,126"`
+	BankTitleID         string         `cnab:"126,146"`
+	DueDate             cnab400.Date   `cnab:"146,152"`
+	Amount              float64        `cnab:"152,165"`
+	CollectingBank      int            `cnab:"165,168"`
+	CollectingAgency    int            `cnab:"168,173"`
+	_                   string         `cnab:"173,175"`
+	Fee                 float64        `cnab:"175,188"`
+	OtherExpenses       float64        `cnab:"188,201"`
+	LateInterest        float64        `cnab:"201,214"`
+	IOF                 float64        `cnab:"214,227"`
+	Rebate              float64        `cnab:"227,240"`
+	Discount            float64        `cnab:"240,253"`
+	PaidAmount          float64        `cnab:"253,266"`
+	Interest            float64        `cnab:"266,279"`
+	OtherCredits        float64        `cnab:"279,292"`
+	_                   string         `cnab:"292,294"`
+	OccurrenceReason    string         `cnab:"294,295"`
+	CreditDate          cnab400.Date   `cnab:"295,301"`
+	PaymentOrigin       string         `cnab:"301,304"`
+	_                   string         `cnab:"304,314"`
+	CheckBank           string         `cnab:"314,318"`
+	RejectionReasons    string         `cnab:"318,328"`
+	_                   string         `cnab:"328,368"`
+	Notary              string         `cnab:"368,370"`
+	Protocol            string         `cnab:"370,380"`
+	_                   string         `cnab:"380,394"`
+	Sequence            int            `cnab:"394,400"`
+}
+
+// Reasons returns the codes of the reasons of the occurrence (motivos das
+// rejeições), that are stored in groups of 2 characters. Empty and zeroed
+// codes are ignored.
+func (r ReturnDetail) Reasons() []RejectionCode {
+	var reasons []RejectionCode
+	for i := 0; i+2 <= len(r.RejectionReasons); i += 2 {
+		reason := r.RejectionReasons[i : i+2]
+		if reason != "00" && reason != "  " {
+			reasons = append(reasons, RejectionCode(reason))
+		}
+	}
+	return reasons
+}
+
+// ReturnTrailer is the trailer of a return file (registro 9), with the
+// summary of the occurrences.
+type ReturnTrailer struct {
+	_                      string  `cnab:"0,1,const=9"`
+	_                      string  `cnab:"1,2,const=2"`
+	_                      string  `cnab:"2,4,const=01"`
+	_                      string  `cnab:"4,7,const=237"`
+	_                      string  `cnab:"7,17"`
+	Titles                 int     `cnab:"17,25"`
+	TotalAmount            float64 `cnab:"25,39"`
+	Notice                 int     `cnab:"39,47"`
+	_                      string  `cnab:"47,57"`
+	EntryConfirmedCount    int     `cnab:"57,62"`
+	EntryConfirmedAmount   float64 `cnab:"62,74"`
+	SettlementTotal        float64 `cnab:"74,86"`
+	SettlementCount        int     `cnab:"86,91"`
+	SettlementAmount       float64 `cnab:"91,103"`
+	WriteOffCount          int     `cnab:"103,108"`
+	WriteOffAmount         float64 `cnab:"108,120"`
+	RebateCancelledCount   int     `cnab:"120,125"`
+	RebateCancelledAmount  float64 `cnab:"125,137"`
+	DueDateChangedCount    int     `cnab:"137,142"`
+	DueDateChangedAmount   float64 `cnab:"142,154"`
+	RebateGrantedCount     int     `cnab:"154,159"`
+	RebateGrantedAmount    float64 `cnab:"159,171"`
+	ProtestConfirmedCount  int     `cnab:"171,176"`
+	ProtestConfirmedAmount float64 `cnab:"176,188"`
+	_                      string  `cnab:"188,362"`
+	ApportionmentTotal     float64 `cnab:"362,377"`
+	ApportionmentCount     int     `cnab:"377,385"`
+	_                      string  `cnab:"385,394"`
+	Sequence               int     `cnab:"394,400"`
+}
+
+// NewRemittanceMapper returns a mapper with the remittance record types, to
+// be used with cnab400.Unmarshal. The títulos are decoded as Title, and
+// records that aren't part of one are decoded individually.
+func NewRemittanceMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*Header)(nil), cnab400.MatchRecordType(cnab400.RecordTypeHeader))
+	mapper.Register((*Title)(nil), cnab400.MatchRecordType(RecordTypeDetail))
+	mapper.Register((*Messages)(nil), cnab400.MatchRecordType(RecordTypeMessages))
+	mapper.Register((*Apportionment)(nil), cnab400.MatchRecordType(RecordTypeApportionment))
+	mapper.Register((*Trailer)(nil), cnab400.MatchRecordType(cnab400.RecordTypeTrailer))
+	return mapper
+}
+
+// NewReturnMapper returns a mapper with the return record types, to be used
+// with cnab400.Unmarshal.
+func NewReturnMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*ReturnHeader)(nil), cnab400.MatchRecordType(cnab400.RecordTypeHeader))
+	mapper.Register((*ReturnDetail)(nil), cnab400.MatchRecordType(RecordTypeDetail))
+	mapper.Register((*ReturnTrailer)(nil), cnab400.MatchRecordType(cnab400.RecordTypeTrailer))
+	return mapper
+}
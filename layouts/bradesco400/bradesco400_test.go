@@ -0,0 +1,460 @@
+package bradesco400_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab400"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/bradesco400"
+)
+
+func TestLayouts(t *testing.T) {
+	t.Parallel()
+
+	layouttest.CheckCoverage(t, cnab400.LineSize,
+		bradesco400.Header{},
+		bradesco400.Detail{},
+		bradesco400.Messages{},
+		bradesco400.Apportionment{},
+		bradesco400.Trailer{},
+		bradesco400.ReturnHeader{},
+		bradesco400.ReturnDetail{},
+		bradesco400.ReturnTrailer{},
+	)
+}
+
+func TestRemittance(t *testing.T) {
+	t.Parallel()
+
+	file := cnab400.File{
+		Header: bradesco400.Header{
+			CompanyCode:      4567890,
+			CompanyName:      "EMPRESA EXEMPLO LTDA",
+			RecordingDate:    cnab400.NewDate(2026, 10, 18),
+			RemittanceNumber: 42,
+			Sequence:         1,
+		},
+		Details: []cnab400.Detail{
+			bradesco400.Title{
+				Detail: bradesco400.Detail{
+					Wallet:              9,
+					Agency:              1234,
+					Account:             67890,
+					AccountDigit:        "1",
+					ControlNumber:       "PEDIDO 1001",
+					FineCode:            2,
+					FinePercentage:      2,
+					OurNumber:           1,
+					OurNumberDigit:      "P",
+					PrintCondition:      2,
+					AutomaticDebitPrint: "N",
+					Apportionment:       "R",
+					Occurrence:          bradesco400.InstructionEntry,
+					DocumentNumber:      "NF-1001",
+					DueDate:             cnab400.NewDate(2026, 11, 10),
+					Amount:              1500.75,
+					TitleKind:           "01",
+					IssueDate:           cnab400.NewDate(2026, 10, 18),
+					DailyInterest:       0.5,
+					PayerDocumentType:   bradesco400.DocumentTypeCPF,
+					PayerDocument:       12345678909,
+					PayerName:           "FULANO DE TAL",
+					PayerAddress:        "RUA DAS FLORES 100",
+					PayerZipCode:        1001,
+					Sequence:            2,
+				},
+				Messages: &bradesco400.Messages{
+					Message1:       "NAO RECEBER APOS 30 DIAS DO VENCIMENTO",
+					Message2:       "OBRIGADO PELA PREFERENCIA",
+					DiscountDate2:  cnab400.NewDate(2026, 11, 5),
+					Discount2:      10,
+					Wallet:         9,
+					Agency:         1234,
+					Account:        67890,
+					AccountDigit:   "1",
+					OurNumber:      1,
+					OurNumberDigit: "P",
+					Sequence:       3,
+				},
+				Apportionment: &bradesco400.Apportionment{
+					Wallet:                   9,
+					Agency:                   1234,
+					Account:                  67890,
+					AccountDigit:             "1",
+					OurNumber:                1,
+					OurNumberDigit:           "P",
+					CalculationCode:          1,
+					ValueType:                1,
+					Beneficiary1Bank:         237,
+					Beneficiary1Agency:       4321,
+					Beneficiary1AgencyDigit:  "0",
+					Beneficiary1Account:      123456,
+					Beneficiary1AccountDigit: "7",
+					Beneficiary1Value:        30,
+					Beneficiary1Name:         "PARCEIRO EXEMPLO LTDA",
+					Sequence:                 4,
+				},
+			},
+			bradesco400.Title{
+				Detail: bradesco400.Detail{
+					Wallet:              9,
+					Agency:              1234,
+					Account:             67890,
+					AccountDigit:        "1",
+					ControlNumber:       "PEDIDO 1002",
+					OurNumber:           2,
+					OurNumberDigit:      "8",
+					PrintCondition:      2,
+					AutomaticDebitPrint: "N",
+					Occurrence:          bradesco400.InstructionEntry,
+					DocumentNumber:      "NF-1002",
+					DueDate:             cnab400.NewDate(2026, 11, 20),
+					Amount:              99.9,
+					TitleKind:           "01",
+					IssueDate:           cnab400.NewDate(2026, 10, 18),
+					PayerDocumentType:   bradesco400.DocumentTypeCNPJ,
+					PayerDocument:       98765432000198,
+					PayerName:           "CLIENTE EXEMPLO SA",
+					PayerAddress:        "AVENIDA CENTRAL 200",
+					PayerZipCode:        20040,
+					PayerZipCodeSuffix:  20,
+					Sequence:            5,
+				},
+			},
+		},
+		Trailer: bradesco400.Trailer{
+			Sequence: 6,
+		},
+	}
+
+	data, err := cnab400.Marshal(layouttest.WithoutFields(file, layouttest.CNAB400ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "remessa.golden", data)
+
+	decoded, err := cnab400.Unmarshal(golden, bradesco400.NewRemittanceMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+}
+
+func TestReturn(t *testing.T) {
+	t.Parallel()
+
+	file := cnab400.File{
+		Header: bradesco400.ReturnHeader{
+			CompanyCode:   4567890,
+			CompanyName:   "EMPRESA EXEMPLO LTDA",
+			RecordingDate: cnab400.NewDate(2026, 11, 12),
+			Density:       1600000,
+			Notice:        123,
+			CreditDate:    cnab400.NewDate(2026, 11, 13),
+			Sequence:      1,
+		},
+		Details: []cnab400.Detail{
+			bradesco400.ReturnDetail{
+				CompanyDocumentType: bradesco400.DocumentTypeCNPJ,
+				CompanyDocument:     12345678000195,
+				Wallet:              9,
+				Agency:              1234,
+				Account:             67890,
+				AccountDigit:        "1",
+				ControlNumber:       "PEDIDO 1001",
+				OurNumber:           1,
+				OurNumberDigit:      "P",
+				Apportionment:       "R",
+				WalletCode:          9,
+				Occurrence:          bradesco400.OccurrenceSettlement,
+				OccurrenceDate:      cnab400.NewDate(2026, 11, 12),
+				DocumentNumber:      "NF-1001",
+				BankTitleID:         "0000000001P",
+				DueDate:             cnab400.NewDate(2026, 11, 10),
+				Amount:              1500.75,
+				CollectingBank:      237,
+				CollectingAgency:    4321,
+				Fee:                 2.5,
+				PaidAmount:          1503.75,
+				Interest:            3,
+				CreditDate:          cnab400.NewDate(2026, 11, 13),
+				Sequence:            2,
+			},
+			bradesco400.ReturnDetail{
+				CompanyDocumentType: bradesco400.DocumentTypeCNPJ,
+				CompanyDocument:     12345678000195,
+				Wallet:              9,
+				Agency:              1234,
+				Account:             67890,
+				AccountDigit:        "1",
+				ControlNumber:       "PEDIDO 1002",
+				OurNumber:           2,
+				OurNumberDigit:      "8",
+				WalletCode:          9,
+				Occurrence:          bradesco400.OccurrenceEntryRejected,
+				OccurrenceDate:      cnab400.NewDate(2026, 10, 19),
+				DocumentNumber:      "NF-1002",
+				DueDate:             cnab400.NewDate(2026, 11, 20),
+				Amount:              99.9,
+				RejectionReasons:    "4800000000",
+				Sequence:            3,
+			},
+		},
+		Trailer: bradesco400.ReturnTrailer{
+			Titles:           2,
+			TotalAmount:      1600.65,
+			Notice:           123,
+			SettlementTotal:  1503.75,
+			SettlementCount:  1,
+			SettlementAmount: 1500.75,
+			Sequence:         4,
+		},
+	}
+
+	data, err := cnab400.Marshal(layouttest.WithoutFields(file, layouttest.CNAB400ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "retorno.golden", data)
+
+	decoded, err := cnab400.Unmarshal(golden, bradesco400.NewReturnMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+
+	rejected := decoded.Details[1].(bradesco400.ReturnDetail)
+	if reasons := rejected.Reasons(); !reflect.DeepEqual([]bradesco400.RejectionCode{bradesco400.RejectionInvalidZipCode}, reasons) {
+		t.Errorf("unexpected rejection reasons “%v”", reasons)
+	}
+
+	if description := rejected.Reasons()[0].Description(); description != "CEP inválido" {
+		t.Errorf("unexpected rejection description “%s”", description)
+	}
+
+	if description := rejected.Occurrence.Description(); description != "Entrada rejeitada" {
+		t.Errorf("unexpected occurrence description “%s”", description)
+	}
+}
+
+func TestSpec(t *testing.T) {
+	t.Parallel()
+
+	// lines assembled from the tables of the Bradesco CNAB 400 cobrança
+	// specification, independently of the encoder
+	scenarios := []struct {
+		description string
+		line        func(t *testing.T) []byte
+		expected    interface{}
+	}{
+		{
+			description: "it should decode the remittance detail",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab400.LineSize,
+					layouttest.Text(1, 1, "1"),                      // identificação do registro
+					layouttest.Number(2, 6, 4321),                   // agência de débito
+					layouttest.Text(7, 7, "9"),                      // dígito da agência de débito
+					layouttest.Number(8, 12, 12),                    // razão da conta corrente
+					layouttest.Number(13, 19, 54321),                // conta corrente
+					layouttest.Text(20, 20, "8"),                    // dígito da conta corrente
+					layouttest.Text(21, 21, "0"),                    // identificação da empresa: zero
+					layouttest.Number(22, 24, 9),                    // identificação da empresa: carteira
+					layouttest.Number(25, 29, 1234),                 // identificação da empresa: agência
+					layouttest.Number(30, 36, 67890),                // identificação da empresa: conta
+					layouttest.Text(37, 37, "1"),                    // identificação da empresa: dígito
+					layouttest.Text(38, 62, "PEDIDO 1001"),          // nº de controle do participante
+					layouttest.Number(63, 65, 237),                  // código do banco a ser debitado
+					layouttest.Number(66, 66, 2),                    // campo de multa
+					layouttest.Number(67, 70, 200),                  // percentual de multa
+					layouttest.Number(71, 81, 1),                    // identificação do título no banco
+					layouttest.Text(82, 82, "P"),                    // dígito de autoconferência do nosso número
+					layouttest.Number(83, 92, 150),                  // desconto bonificação por dia
+					layouttest.Number(93, 93, 2),                    // condição para emissão da papeleta
+					layouttest.Text(94, 94, "N"),                    // emite boleto para débito automático
+					layouttest.Text(95, 104, ""),                    // identificação da operação do banco
+					layouttest.Text(105, 105, "R"),                  // indicador de rateio de crédito
+					layouttest.Text(106, 106, "2"),                  // endereçamento para aviso do débito
+					layouttest.Text(107, 108, ""),                   // quantidade de pagamentos
+					layouttest.Text(109, 110, "01"),                 // identificação da ocorrência
+					layouttest.Text(111, 120, "NF-1001"),            // nº do documento
+					layouttest.Text(121, 126, "101126"),             // data do vencimento do título
+					layouttest.Number(127, 139, 150075),             // valor do título
+					layouttest.Number(140, 142, 0),                  // banco encarregado da cobrança
+					layouttest.Number(143, 147, 0),                  // agência depositária
+					layouttest.Text(148, 149, "01"),                 // espécie de título
+					layouttest.Text(150, 150, "N"),                  // identificação
+					layouttest.Text(151, 156, "181026"),             // data da emissão do título
+					layouttest.Text(157, 158, "06"),                 // 1ª instrução
+					layouttest.Text(159, 160, "05"),                 // 2ª instrução
+					layouttest.Number(161, 173, 50),                 // valor a ser cobrado por dia de atraso
+					layouttest.Text(174, 179, "051126"),             // data limite para concessão de desconto
+					layouttest.Number(180, 192, 1000),               // valor do desconto
+					layouttest.Number(193, 205, 0),                  // valor do IOF
+					layouttest.Number(206, 218, 2000),               // valor do abatimento
+					layouttest.Number(219, 220, 1),                  // identificação do tipo de inscrição do pagador
+					layouttest.Number(221, 234, 12345678909),        // nº de inscrição do pagador
+					layouttest.Text(235, 274, "FULANO DE TAL"),      // nome do pagador
+					layouttest.Text(275, 314, "RUA DAS FLORES 100"), // endereço completo
+					layouttest.Text(315, 326, "MENSAGEM 1"),         // 1ª mensagem
+					layouttest.Number(327, 331, 1001),               // CEP
+					layouttest.Number(332, 334, 0),                  // sufixo do CEP
+					layouttest.Text(335, 394, "AVALISTA EXEMPLO"),   // sacador/avalista ou 2ª mensagem
+					layouttest.Number(395, 400, 2),                  // nº sequencial do registro
+				)
+			},
+			expected: bradesco400.Detail{
+				DebitAgency:          4321,
+				DebitAgencyDigit:     "9",
+				DebitAccountPrefix:   12,
+				DebitAccount:         54321,
+				DebitAccountDigit:    "8",
+				Wallet:               9,
+				Agency:               1234,
+				Account:              67890,
+				AccountDigit:         "1",
+				ControlNumber:        "PEDIDO 1001",
+				DebitBank:            237,
+				FineCode:             2,
+				FinePercentage:       2,
+				OurNumber:            1,
+				OurNumberDigit:       "P",
+				DailyBonus:           1.5,
+				PrintCondition:       2,
+				AutomaticDebitPrint:  "N",
+				Apportionment:        "R",
+				AutomaticDebitNotice: "2",
+				Occurrence:           bradesco400.InstructionEntry,
+				DocumentNumber:       "NF-1001",
+				DueDate:              cnab400.NewDate(2026, 11, 10),
+				Amount:               1500.75,
+				TitleKind:            "01",
+				IssueDate:            cnab400.NewDate(2026, 10, 18),
+				Instruction1:         "06",
+				Instruction2:         "05",
+				DailyInterest:        0.5,
+				DiscountDate:         cnab400.NewDate(2026, 11, 5),
+				Discount:             10,
+				Rebate:               20,
+				PayerDocumentType:    bradesco400.DocumentTypeCPF,
+				PayerDocument:        12345678909,
+				PayerName:            "FULANO DE TAL",
+				PayerAddress:         "RUA DAS FLORES 100",
+				Message1:             "MENSAGEM 1",
+				PayerZipCode:         1001,
+				GuarantorOrMessage2:  "AVALISTA EXEMPLO",
+				Sequence:             2,
+			},
+		},
+		{
+			description: "it should decode the return detail",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab400.LineSize,
+					layouttest.Text(1, 1, "1"),                // identificação do registro
+					layouttest.Number(2, 3, 2),                // tipo de inscrição da empresa
+					layouttest.Number(4, 17, 12345678000195),  // nº de inscrição da empresa
+					layouttest.Number(18, 20, 0),              // zeros
+					layouttest.Text(21, 21, "0"),              // identificação da empresa: zero
+					layouttest.Number(22, 24, 9),              // identificação da empresa: carteira
+					layouttest.Number(25, 29, 1234),           // identificação da empresa: agência
+					layouttest.Number(30, 36, 67890),          // identificação da empresa: conta
+					layouttest.Text(37, 37, "1"),              // identificação da empresa: dígito
+					layouttest.Text(38, 62, "PEDIDO 1001"),    // nº de controle do participante
+					layouttest.Number(63, 70, 0),              // zeros
+					layouttest.Number(71, 81, 1),              // identificação do título no banco
+					layouttest.Text(82, 82, "P"),              // dígito do nosso número
+					layouttest.Number(83, 104, 0),             // uso do banco
+					layouttest.Text(105, 105, "R"),            // indicador de rateio de crédito
+					layouttest.Text(106, 107, "00"),           // pagamento parcial
+					layouttest.Number(108, 108, 9),            // carteira
+					layouttest.Text(109, 110, "06"),           // identificação da ocorrência
+					layouttest.Text(111, 116, "121126"),       // data da ocorrência no banco
+					layouttest.Text(117, 126, "NF-1001"),      // nº do documento
+					layouttest.Text(127, 146, "00000000001P"), // identificação do título no banco
+					layouttest.Text(147, 152, "101126"),       // data do vencimento do título
+					layouttest.Number(153, 165, 150075),       // valor do título
+					layouttest.Number(166, 168, 237),          // banco cobrador
+					layouttest.Number(169, 173, 4321),         // agência cobradora
+					layouttest.Text(174, 175, ""),             // espécie do título
+					layouttest.Number(176, 188, 250),          // despesas de cobrança
+					layouttest.Number(189, 201, 100),          // outras despesas/custas de protesto
+					layouttest.Number(202, 214, 0),            // juros da operação em atraso
+					layouttest.Number(215, 227, 0),            // IOF devido
+					layouttest.Number(228, 240, 200),          // abatimento concedido
+					layouttest.Number(241, 253, 300),          // desconto concedido
+					layouttest.Number(254, 266, 150375),       // valor pago
+					layouttest.Number(267, 279, 600),          // juros de mora
+					layouttest.Number(280, 292, 0),            // outros créditos
+					layouttest.Text(295, 295, "A"),            // motivo do código de ocorrência
+					layouttest.Text(296, 301, "131126"),       // data do crédito
+					layouttest.Text(302, 304, "002"),          // origem do pagamento
+					layouttest.Text(315, 318, "1234"),         // cheque Bradesco
+					layouttest.Text(319, 328, "0000000000"),   // motivos das rejeições
+					layouttest.Text(369, 370, "01"),           // nº do cartório
+					layouttest.Text(371, 380, "PROT000001"),   // nº do protocolo
+					layouttest.Number(395, 400, 2),            // nº sequencial do registro
+				)
+			},
+			expected: bradesco400.ReturnDetail{
+				CompanyDocumentType: bradesco400.DocumentTypeCNPJ,
+				CompanyDocument:     12345678000195,
+				Wallet:              9,
+				Agency:              1234,
+				Account:             67890,
+				AccountDigit:        "1",
+				ControlNumber:       "PEDIDO 1001",
+				OurNumber:           1,
+				OurNumberDigit:      "P",
+				Apportionment:       "R",
+				PartialPayment:      "00",
+				WalletCode:          9,
+				Occurrence:          bradesco400.OccurrenceSettlement,
+				OccurrenceDate:      cnab400.NewDate(2026, 11, 12),
+				DocumentNumber:      "NF-1001",
+				BankTitleID:         "00000000001P",
+				DueDate:             cnab400.NewDate(2026, 11, 10),
+				Amount:              1500.75,
+				CollectingBank:      237,
+				CollectingAgency:    4321,
+				Fee:                 2.5,
+				OtherExpenses:       1,
+				Rebate:              2,
+				Discount:            3,
+				PaidAmount:          1503.75,
+				Interest:            6,
+				OccurrenceReason:    "A",
+				CreditDate:          cnab400.NewDate(2026, 11, 13),
+				PaymentOrigin:       "002",
+				CheckBank:           "1234",
+				RejectionReasons:    "0000000000",
+				Notary:              "01",
+				Protocol:            "PROT000001",
+				Sequence:            2,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			decoded := reflect.New(reflect.TypeOf(scenario.expected))
+			if err := gocnab.Unmarshal(scenario.line(t), decoded.Interface()); err != nil {
+				t.Fatalf("error unmarshalling. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(scenario.expected, decoded.Elem().Interface()) {
+				t.Errorf("expected record “%#v” and got “%#v”", scenario.expected, decoded.Elem().Interface())
+			}
+		})
+	}
+}
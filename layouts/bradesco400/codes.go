@@ -0,0 +1,237 @@
+package bradesco400
+
+// InstructionCode is the occurrence code of a título in a remittance
+// (identificação da ocorrência), defining the instruction sent to the bank.
+type InstructionCode string
+
+// List of instruction codes accepted by Bradesco.
+const (
+	InstructionEntry                     InstructionCode = "01"
+	InstructionWriteOff                  InstructionCode = "02"
+	InstructionBankruptcyProtest         InstructionCode = "03"
+	InstructionGrantRebate               InstructionCode = "04"
+	InstructionCancelRebate              InstructionCode = "05"
+	InstructionChangeDueDate             InstructionCode = "06"
+	InstructionChangeControlNumber       InstructionCode = "07"
+	InstructionChangeDocumentNumber      InstructionCode = "08"
+	InstructionProtest                   InstructionCode = "09"
+	InstructionStopProtestAndWriteOff    InstructionCode = "18"
+	InstructionStopProtestAndKeep        InstructionCode = "19"
+	InstructionCreditAssignment          InstructionCode = "22"
+	InstructionTransferWallet            InstructionCode = "23"
+	InstructionReturnWalletTransfer      InstructionCode = "24"
+	InstructionChangeOtherData           InstructionCode = "31"
+	InstructionNegative                  InstructionCode = "45"
+	InstructionRemoveNegativeAndWriteOff InstructionCode = "46"
+	InstructionRemoveNegativeAndKeep     InstructionCode = "47"
+	InstructionChangeApportionment       InstructionCode = "68"
+	InstructionCancelApportionment       InstructionCode = "69"
+)
+
+var instructionDescriptions = map[InstructionCode]string{
+	InstructionEntry:                     "Remessa",
+	InstructionWriteOff:                  "Pedido de baixa",
+	InstructionBankruptcyProtest:         "Pedido de protesto falimentar",
+	InstructionGrantRebate:               "Concessão de abatimento",
+	InstructionCancelRebate:              "Cancelamento de abatimento concedido",
+	InstructionChangeDueDate:             "Alteração de vencimento",
+	InstructionChangeControlNumber:       "Alteração do controle do participante",
+	InstructionChangeDocumentNumber:      "Alteração de seu número",
+	InstructionProtest:                   "Pedido de protesto",
+	InstructionStopProtestAndWriteOff:    "Sustar protesto e baixar título",
+	InstructionStopProtestAndKeep:        "Sustar protesto e manter em carteira",
+	InstructionCreditAssignment:          "Transferência cessão crédito",
+	InstructionTransferWallet:            "Transferência entre carteiras",
+	InstructionReturnWalletTransfer:      "Devolução da transferência entre carteiras",
+	InstructionChangeOtherData:           "Alteração de outros dados",
+	InstructionNegative:                  "Pedido de negativação",
+	InstructionRemoveNegativeAndWriteOff: "Excluir negativação com baixa",
+	InstructionRemoveNegativeAndKeep:     "Excluir negativação e manter pendente",
+	InstructionChangeApportionment:       "Acerto nos dados do rateio de crédito",
+	InstructionCancelApportionment:       "Cancelamento do rateio de crédito",
+}
+
+// Description returns the description of the instruction code, or an empty
+// string for unknown codes.
+func (i InstructionCode) Description() string {
+	return instructionDescriptions[i]
+}
+
+// OccurrenceCode is the occurrence code of a título in a return file
+// (identificação de ocorrência), informing what happened to the título.
+type OccurrenceCode string
+
+// List of occurrence codes returned by Bradesco.
+const (
+	OccurrenceEntryConfirmed            OccurrenceCode = "02"
+	OccurrenceEntryRejected             OccurrenceCode = "03"
+	OccurrenceSettlement                OccurrenceCode = "06"
+	OccurrenceAutomaticWriteOff         OccurrenceCode = "09"
+	OccurrenceAgencyWriteOff            OccurrenceCode = "10"
+	OccurrenceTitleInWallet             OccurrenceCode = "11"
+	OccurrenceRebateGranted             OccurrenceCode = "12"
+	OccurrenceRebateCancelled           OccurrenceCode = "13"
+	OccurrenceDueDateChanged            OccurrenceCode = "14"
+	OccurrenceNotarySettlement          OccurrenceCode = "15"
+	OccurrencePaidWithCheck             OccurrenceCode = "16"
+	OccurrenceSettlementAfterWriteOff   OccurrenceCode = "17"
+	OccurrenceDepositaryAdjustment      OccurrenceCode = "18"
+	OccurrenceProtestConfirmed          OccurrenceCode = "19"
+	OccurrenceStopProtestConfirmed      OccurrenceCode = "20"
+	OccurrenceControlNumberAdjustment   OccurrenceCode = "21"
+	OccurrencePaymentCancelled          OccurrenceCode = "22"
+	OccurrenceSentToNotary              OccurrenceCode = "23"
+	OccurrenceRejectedZipCode           OccurrenceCode = "24"
+	OccurrenceBankruptcyProtest         OccurrenceCode = "25"
+	OccurrenceWriteOffRejected          OccurrenceCode = "27"
+	OccurrenceFeeDebit                  OccurrenceCode = "28"
+	OccurrencePayerOccurrence           OccurrenceCode = "29"
+	OccurrenceDataChangeRejected        OccurrenceCode = "30"
+	OccurrenceInstructionRejected       OccurrenceCode = "32"
+	OccurrenceDataChangeConfirmed       OccurrenceCode = "33"
+	OccurrenceRemovedFromNotary         OccurrenceCode = "34"
+	OccurrenceAutomaticDebitUnscheduled OccurrenceCode = "35"
+	OccurrencePaymentReversal           OccurrenceCode = "40"
+	OccurrenceJudicialStop              OccurrenceCode = "55"
+	OccurrenceApportionmentChanged      OccurrenceCode = "68"
+	OccurrenceApportionmentCancelled    OccurrenceCode = "69"
+	OccurrenceNegativeConfirmed         OccurrenceCode = "73"
+	OccurrenceNegativeRemovalConfirmed  OccurrenceCode = "74"
+)
+
+var occurrenceDescriptions = map[OccurrenceCode]string{
+	OccurrenceEntryConfirmed:            "Entrada confirmada",
+	OccurrenceEntryRejected:             "Entrada rejeitada",
+	OccurrenceSettlement:                "Liquidação normal",
+	OccurrenceAutomaticWriteOff:         "Baixado automaticamente via arquivo",
+	OccurrenceAgencyWriteOff:            "Baixado conforme instruções da agência",
+	OccurrenceTitleInWallet:             "Em ser - arquivo de títulos pendentes",
+	OccurrenceRebateGranted:             "Abatimento concedido",
+	OccurrenceRebateCancelled:           "Abatimento cancelado",
+	OccurrenceDueDateChanged:            "Vencimento alterado",
+	OccurrenceNotarySettlement:          "Liquidação em cartório",
+	OccurrencePaidWithCheck:             "Título pago em cheque - vinculado",
+	OccurrenceSettlementAfterWriteOff:   "Liquidação após baixa ou título não registrado",
+	OccurrenceDepositaryAdjustment:      "Acerto de depositária",
+	OccurrenceProtestConfirmed:          "Confirmação recebimento instrução de protesto",
+	OccurrenceStopProtestConfirmed:      "Confirmação recebimento instrução de sustação de protesto",
+	OccurrenceControlNumberAdjustment:   "Acerto do controle do participante",
+	OccurrencePaymentCancelled:          "Título com pagamento cancelado",
+	OccurrenceSentToNotary:              "Entrada do título em cartório",
+	OccurrenceRejectedZipCode:           "Entrada rejeitada por CEP irregular",
+	OccurrenceBankruptcyProtest:         "Confirmação recebimento instrução de protesto falimentar",
+	OccurrenceWriteOffRejected:          "Baixa rejeitada",
+	OccurrenceFeeDebit:                  "Débito de tarifas/custas",
+	OccurrencePayerOccurrence:           "Ocorrências do pagador",
+	OccurrenceDataChangeRejected:        "Alteração de outros dados rejeitados",
+	OccurrenceInstructionRejected:       "Instrução rejeitada",
+	OccurrenceDataChangeConfirmed:       "Confirmação pedido alteração outros dados",
+	OccurrenceRemovedFromNotary:         "Retirado de cartório e manutenção carteira",
+	OccurrenceAutomaticDebitUnscheduled: "Desagendamento do débito automático",
+	OccurrencePaymentReversal:           "Estorno de pagamento",
+	OccurrenceJudicialStop:              "Sustado judicial",
+	OccurrenceApportionmentChanged:      "Acerto dos dados do rateio de crédito",
+	OccurrenceApportionmentCancelled:    "Cancelamento dos dados do rateio",
+	OccurrenceNegativeConfirmed:         "Confirmação recebimento pedido de negativação",
+	OccurrenceNegativeRemovalConfirmed:  "Confirmação pedido de exclusão de negativação",
+}
+
+// Description returns the description of the occurrence code, or an empty
+// string for unknown codes.
+func (o OccurrenceCode) Description() string {
+	return occurrenceDescriptions[o]
+}
+
+// RejectionCode is the reason of a rejected entry or instruction (motivo da
+// rejeição), returned with the occurrences 03, 24, 27, 30 and 32.
+type RejectionCode string
+
+// List of rejection codes returned by Bradesco for rejected entries
+// (occurrence 03).
+const (
+	RejectionInvalidRecordCode       RejectionCode = "02"
+	RejectionInvalidOccurrence       RejectionCode = "03"
+	RejectionOccurrenceNotAllowed    RejectionCode = "04"
+	RejectionNonNumericOccurrence    RejectionCode = "05"
+	RejectionInvalidAccount          RejectionCode = "07"
+	RejectionInvalidOurNumber        RejectionCode = "08"
+	RejectionDuplicatedOurNumber     RejectionCode = "09"
+	RejectionInvalidWallet           RejectionCode = "10"
+	RejectionInvalidPrintCondition   RejectionCode = "13"
+	RejectionInvalidDueDate          RejectionCode = "16"
+	RejectionDueDateOutOfRange       RejectionCode = "18"
+	RejectionInvalidAmount           RejectionCode = "20"
+	RejectionInvalidTitleKind        RejectionCode = "21"
+	RejectionTitleKindNotAllowed     RejectionCode = "22"
+	RejectionInvalidIssueDate        RejectionCode = "24"
+	RejectionInvalidDiscount         RejectionCode = "28"
+	RejectionInvalidProtestDeadline  RejectionCode = "38"
+	RejectionUnknownAgency           RejectionCode = "44"
+	RejectionMissingPayerName        RejectionCode = "45"
+	RejectionInvalidPayerDocument    RejectionCode = "46"
+	RejectionMissingPayerAddress     RejectionCode = "47"
+	RejectionInvalidZipCode          RejectionCode = "48"
+	RejectionIrregularZipCode        RejectionCode = "50"
+	RejectionTitleAlreadyRegistered  RejectionCode = "63"
+	RejectionLimitExceeded           RejectionCode = "65"
+	RejectionUnknownAuthorization    RejectionCode = "66"
+	RejectionDebitRemittanceError    RejectionCode = "68"
+	RejectionDebitPayerNotRegistered RejectionCode = "69"
+	RejectionDebitNotAuthorized      RejectionCode = "70"
+	RejectionDebitNotParticipating   RejectionCode = "71"
+	RejectionDebitInvalidCurrency    RejectionCode = "72"
+	RejectionDebitInvalidDueDate     RejectionCode = "73"
+	RejectionDebitTitleNotRegistered RejectionCode = "74"
+	RejectionDebitInvalidDocument    RejectionCode = "75"
+	RejectionDDAPayer                RejectionCode = "76"
+	RejectionInvalidDocumentNumber   RejectionCode = "86"
+	RejectionEmailNotRead            RejectionCode = "89"
+	RejectionEmailNotReceived        RejectionCode = "91"
+)
+
+var rejectionDescriptions = map[RejectionCode]string{
+	RejectionInvalidRecordCode:       "Código do registro detalhe inválido",
+	RejectionInvalidOccurrence:       "Código da ocorrência inválida",
+	RejectionOccurrenceNotAllowed:    "Código de ocorrência não permitida para a carteira",
+	RejectionNonNumericOccurrence:    "Código de ocorrência não numérico",
+	RejectionInvalidAccount:          "Agência/conta/dígito inválido",
+	RejectionInvalidOurNumber:        "Nosso número inválido",
+	RejectionDuplicatedOurNumber:     "Nosso número duplicado",
+	RejectionInvalidWallet:           "Carteira inválida",
+	RejectionInvalidPrintCondition:   "Identificação da emissão do boleto inválida",
+	RejectionInvalidDueDate:          "Data de vencimento inválida",
+	RejectionDueDateOutOfRange:       "Vencimento fora do prazo de operação",
+	RejectionInvalidAmount:           "Valor do título inválido",
+	RejectionInvalidTitleKind:        "Espécie do título inválida",
+	RejectionTitleKindNotAllowed:     "Espécie não permitida para a carteira",
+	RejectionInvalidIssueDate:        "Data de emissão inválida",
+	RejectionInvalidDiscount:         "Código do desconto inválido",
+	RejectionInvalidProtestDeadline:  "Prazo para protesto inválido",
+	RejectionUnknownAgency:           "Agência beneficiário não prevista",
+	RejectionMissingPayerName:        "Nome do pagador não informado",
+	RejectionInvalidPayerDocument:    "Tipo/número de inscrição do pagador inválidos",
+	RejectionMissingPayerAddress:     "Endereço do pagador não informado",
+	RejectionInvalidZipCode:          "CEP inválido",
+	RejectionIrregularZipCode:        "CEP irregular - banco correspondente",
+	RejectionTitleAlreadyRegistered:  "Entrada para título já cadastrado",
+	RejectionLimitExceeded:           "Limite excedido",
+	RejectionUnknownAuthorization:    "Número autorização inexistente",
+	RejectionDebitRemittanceError:    "Débito não agendado - erro nos dados da remessa",
+	RejectionDebitPayerNotRegistered: "Débito não agendado - pagador não consta do cadastro de autorizante",
+	RejectionDebitNotAuthorized:      "Débito não agendado - beneficiário não autorizado pelo pagador",
+	RejectionDebitNotParticipating:   "Débito não agendado - beneficiário não participa da modalidade débito automático",
+	RejectionDebitInvalidCurrency:    "Débito não agendado - código de moeda diferente de real (R$)",
+	RejectionDebitInvalidDueDate:     "Débito não agendado - data de vencimento inválida",
+	RejectionDebitTitleNotRegistered: "Débito não agendado - conforme seu pedido, título não registrado",
+	RejectionDebitInvalidDocument:    "Débito não agendado - tipo de número de inscrição do debitado inválido",
+	RejectionDDAPayer:                "Pagador eletrônico DDA",
+	RejectionInvalidDocumentNumber:   "Seu número do documento inválido",
+	RejectionEmailNotRead:            "E-mail pagador não lido no prazo de 5 dias",
+	RejectionEmailNotReceived:        "E-mail pagador não recebido",
+}
+
+// Description returns the description of the rejection code of an entry, or an
+// empty string for unknown codes.
+func (r RejectionCode) Description() string {
+	return rejectionDescriptions[r]
+}
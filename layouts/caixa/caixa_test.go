@@ -0,0 +1,435 @@
+package caixa_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/caixa"
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+)
+
+func TestLayouts(t *testing.T) {
+	t.Parallel()
+
+	layouttest.CheckCoverage(t, cnab240.LineSize,
+		caixa.FileHeader{},
+		caixa.CobrancaBatchHeader{},
+		caixa.SegmentP{},
+		caixa.SegmentT{},
+	)
+}
+
+func fileHeader(fileCode febraban240.FileCode) caixa.FileHeader {
+	return caixa.FileHeader{
+		CompanyDocumentType: caixa.DocumentTypeCNPJ,
+		CompanyDocument:     12345678000195,
+		Agency:              1234,
+		AgencyDigit:         "5",
+		BeneficiaryCode:     123456,
+		CompanyName:         "EMPRESA EXEMPLO LTDA",
+		FileCode:            fileCode,
+		GenerationDate:      cnab240.NewDate(2026, 10, 18),
+		GenerationTime:      cnab240.NewTime(10, 30, 15),
+		FileSequence:        42,
+		LayoutVersion:       "107",
+		CompanyReserved:     "REMESSA-PRODUCAO",
+	}
+}
+
+func batchHeader(operation string) caixa.CobrancaBatchHeader {
+	return caixa.CobrancaBatchHeader{
+		Batch:               1,
+		Operation:           operation,
+		LayoutVersion:       "067",
+		CompanyDocumentType: caixa.DocumentTypeCNPJ,
+		CompanyDocument:     12345678000195,
+		BeneficiaryCode:     123456,
+		Agency:              1234,
+		AgencyDigit:         "5",
+		Agreement:           123456,
+		CompanyName:         "EMPRESA EXEMPLO LTDA",
+		RemittanceNumber:    42,
+		RecordingDate:       cnab240.NewDate(2026, 10, 18),
+	}
+}
+
+func TestRemittance(t *testing.T) {
+	t.Parallel()
+
+	file := cnab240.File{
+		Header: fileHeader(febraban240.FileCodeRemittance),
+		Batches: []cnab240.Batch{
+			{
+				Header: batchHeader(febraban240.OperationRemittance),
+				Segments: []cnab240.Segment{
+					caixa.Boleto{
+						P: caixa.SegmentP{
+							Batch:            1,
+							Sequence:         1,
+							Movement:         febraban240.InstructionEntry,
+							Agency:           1234,
+							AgencyDigit:      "5",
+							BeneficiaryCode:  123456,
+							Modality:         caixa.ModalityRegisteredCompany,
+							OurNumber:        1,
+							Wallet:           1,
+							RegistrationType: 1,
+							DocumentKind:     2,
+							IssuanceType:     2,
+							DistributionType: "0",
+							DocumentNumber:   "NF-1001",
+							DueDate:          cnab240.NewDate(2026, 11, 10),
+							Amount:           1500.75,
+							TitleKind:        2,
+							Acceptance:       "N",
+							IssueDate:        cnab240.NewDate(2026, 10, 18),
+							InterestCode:     1,
+							InterestDate:     cnab240.NewDate(2026, 11, 11),
+							Interest:         0.5,
+							CompanyTitleID:   "PEDIDO 1001",
+							ProtestCode:      3,
+							WriteOffCode:     1,
+							WriteOffDays:     60,
+							Currency:         9,
+						},
+						Q: febraban240.SegmentQ{
+							Bank:              104,
+							Batch:             1,
+							Sequence:          2,
+							Movement:          febraban240.InstructionEntry,
+							PayerDocumentType: caixa.DocumentTypeCPF,
+							PayerDocument:     12345678909,
+							PayerName:         "FULANO DE TAL",
+							PayerAddress:      "RUA DAS FLORES 100",
+							PayerDistrict:     "CENTRO",
+							PayerZipCode:      1001,
+							PayerCity:         "SAO PAULO",
+							PayerState:        "SP",
+						},
+						R: &febraban240.SegmentR{
+							Bank:     104,
+							Batch:    1,
+							Sequence: 3,
+							Movement: febraban240.InstructionEntry,
+							FineCode: 2,
+							FineDate: cnab240.NewDate(2026, 11, 11),
+							Fine:     2,
+						},
+					},
+				},
+				Trailer: febraban240.CobrancaBatchTrailer{
+					Bank:    104,
+					Batch:   1,
+					Records: 5,
+				},
+			},
+		},
+		Trailer: febraban240.FileTrailer{
+			Bank:    104,
+			Batches: 1,
+			Records: 7,
+		},
+	}
+
+	data, err := cnab240.Marshal(layouttest.WithoutFields(file, layouttest.CNAB240ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "remessa.golden", data)
+
+	decoded, err := cnab240.Unmarshal(golden, caixa.NewCobrancaMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+}
+
+func TestReturn(t *testing.T) {
+	t.Parallel()
+
+	file := cnab240.File{
+		Header: fileHeader(febraban240.FileCodeReturn),
+		Batches: []cnab240.Batch{
+			{
+				Header: batchHeader(febraban240.OperationReturn),
+				Segments: []cnab240.Segment{
+					caixa.BoletoReturn{
+						T: caixa.SegmentT{
+							Batch:             1,
+							Sequence:          1,
+							Occurrence:        febraban240.OccurrenceSettlement,
+							Agency:            1234,
+							AgencyDigit:       "5",
+							BeneficiaryCode:   123456,
+							Modality:          caixa.ModalityRegisteredCompany,
+							OurNumber:         1,
+							Wallet:            1,
+							DocumentNumber:    "NF-1001",
+							DueDate:           cnab240.NewDate(2026, 11, 10),
+							Amount:            1500.75,
+							CollectingBank:    104,
+							CollectingAgency:  4321,
+							CompanyTitleID:    "PEDIDO 1001",
+							Currency:          9,
+							PayerDocumentType: caixa.DocumentTypeCPF,
+							PayerDocument:     12345678909,
+							PayerName:         "FULANO DE TAL",
+							Fee:               2.5,
+						},
+						U: febraban240.SegmentU{
+							Bank:           104,
+							Batch:          1,
+							Sequence:       2,
+							Occurrence:     febraban240.OccurrenceSettlement,
+							PaidAmount:     1500.75,
+							NetAmount:      1498.25,
+							OccurrenceDate: cnab240.NewDate(2026, 11, 12),
+							CreditDate:     cnab240.NewDate(2026, 11, 13),
+						},
+					},
+					caixa.BoletoReturn{
+						T: caixa.SegmentT{
+							Batch:             1,
+							Sequence:          3,
+							Occurrence:        febraban240.OccurrenceEntryRejected,
+							Agency:            1234,
+							AgencyDigit:       "5",
+							BeneficiaryCode:   123456,
+							Modality:          caixa.ModalityRegisteredCompany,
+							OurNumber:         2,
+							Wallet:            1,
+							DocumentNumber:    "NF-1002",
+							DueDate:           cnab240.NewDate(2026, 11, 20),
+							Amount:            99.9,
+							Currency:          9,
+							PayerDocumentType: caixa.DocumentTypeCNPJ,
+							PayerDocument:     98765432000198,
+							PayerName:         "CLIENTE EXEMPLO SA",
+							OccurrenceReasons: "0816",
+						},
+						U: febraban240.SegmentU{
+							Bank:           104,
+							Batch:          1,
+							Sequence:       4,
+							Occurrence:     febraban240.OccurrenceEntryRejected,
+							OccurrenceDate: cnab240.NewDate(2026, 10, 19),
+						},
+					},
+				},
+				Trailer: febraban240.CobrancaBatchTrailer{
+					Bank:         104,
+					Batch:        1,
+					Records:      6,
+					SimpleCount:  1,
+					SimpleAmount: 1500.75,
+				},
+			},
+		},
+		Trailer: febraban240.FileTrailer{
+			Bank:    104,
+			Batches: 1,
+			Records: 8,
+		},
+	}
+
+	data, err := cnab240.Marshal(layouttest.WithoutFields(file, layouttest.CNAB240ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "retorno.golden", data)
+
+	decoded, err := cnab240.Unmarshal(golden, caixa.NewCobrancaMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+
+	rejected := decoded.Batches[0].Segments[1].(caixa.BoletoReturn)
+	if reasons := rejected.T.Reasons(); !reflect.DeepEqual([]string{"08", "16"}, reasons) {
+		t.Errorf("unexpected occurrence reasons “%v”", reasons)
+	}
+}
+
+func TestSpec(t *testing.T) {
+	t.Parallel()
+
+	// lines assembled from the tables of the CAIXA SIGCB CNAB 240
+	// specification, independently of the encoder
+	scenarios := []struct {
+		description string
+		line        func(t *testing.T) []byte
+		expected    interface{}
+	}{
+		{
+			description: "it should decode the segment P",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab240.LineSize,
+					layouttest.Number(1, 3, 104),             // código do banco na compensação
+					layouttest.Number(4, 7, 1),               // lote de serviço
+					layouttest.Text(8, 8, "3"),               // tipo de registro
+					layouttest.Number(9, 13, 1),              // nº sequencial do registro no lote
+					layouttest.Text(14, 14, "P"),             // código do segmento do registro detalhe
+					layouttest.Text(15, 15, ""),              // uso exclusivo FEBRABAN/CNAB
+					layouttest.Number(16, 17, 1),             // código de movimento remessa
+					layouttest.Number(18, 22, 1234),          // agência mantenedora da conta
+					layouttest.Text(23, 23, "5"),             // dígito verificador da agência
+					layouttest.Number(24, 29, 654321),        // código do convênio no banco
+					layouttest.Number(30, 40, 0),             // uso exclusivo CAIXA
+					layouttest.Number(41, 42, 14),            // modalidade da carteira
+					layouttest.Number(43, 57, 1),             // identificação do título no banco
+					layouttest.Number(58, 58, 1),             // código da carteira
+					layouttest.Number(59, 59, 1),             // forma de cadastramento do título no banco
+					layouttest.Number(60, 60, 2),             // tipo de documento
+					layouttest.Number(61, 61, 2),             // identificação da emissão do boleto
+					layouttest.Text(62, 62, "0"),             // identificação da entrega do boleto
+					layouttest.Text(63, 73, "NF-1001"),       // número do documento de cobrança
+					layouttest.Text(74, 77, ""),              // uso exclusivo CAIXA
+					layouttest.Text(78, 85, "10112026"),      // data de vencimento do título
+					layouttest.Number(86, 100, 150075),       // valor nominal do título
+					layouttest.Number(101, 105, 0),           // agência encarregada da cobrança
+					layouttest.Number(106, 106, 0),           // dígito verificador da agência
+					layouttest.Number(107, 108, 2),           // espécie do título
+					layouttest.Text(109, 109, "N"),           // identificação de título aceito/não aceito
+					layouttest.Text(110, 117, "20102026"),    // data da emissão do título
+					layouttest.Number(118, 118, 1),           // código do juros de mora
+					layouttest.Text(119, 126, "11112026"),    // data do juros de mora
+					layouttest.Number(127, 141, 50),          // juros de mora por dia/taxa
+					layouttest.Number(142, 142, 1),           // código do desconto 1
+					layouttest.Text(143, 150, "05112026"),    // data do desconto 1
+					layouttest.Number(151, 165, 1000),        // valor/percentual a ser concedido
+					layouttest.Number(166, 180, 0),           // valor do IOF a ser recolhido
+					layouttest.Number(181, 195, 2000),        // valor do abatimento
+					layouttest.Text(196, 220, "PEDIDO 1001"), // identificação do título na empresa
+					layouttest.Number(221, 221, 1),           // código para protesto
+					layouttest.Number(222, 223, 5),           // número de dias para protesto
+					layouttest.Number(224, 224, 1),           // código para baixa/devolução
+					layouttest.Number(225, 227, 60),          // número de dias para baixa/devolução
+					layouttest.Number(228, 229, 9),           // código da moeda
+					layouttest.Number(230, 239, 0),           // uso exclusivo CAIXA
+					layouttest.Text(240, 240, ""),            // uso exclusivo FEBRABAN/CNAB
+				)
+			},
+			expected: caixa.SegmentP{
+				Batch:            1,
+				Sequence:         1,
+				Movement:         febraban240.InstructionEntry,
+				Agency:           1234,
+				AgencyDigit:      "5",
+				BeneficiaryCode:  654321,
+				Modality:         caixa.ModalityRegisteredCompany,
+				OurNumber:        1,
+				Wallet:           1,
+				RegistrationType: 1,
+				DocumentKind:     2,
+				IssuanceType:     2,
+				DistributionType: "0",
+				DocumentNumber:   "NF-1001",
+				DueDate:          cnab240.NewDate(2026, 11, 10),
+				Amount:           1500.75,
+				TitleKind:        2,
+				Acceptance:       "N",
+				IssueDate:        cnab240.NewDate(2026, 10, 20),
+				InterestCode:     1,
+				InterestDate:     cnab240.NewDate(2026, 11, 11),
+				Interest:         0.5,
+				DiscountCode:     1,
+				DiscountDate:     cnab240.NewDate(2026, 11, 5),
+				Discount:         10,
+				Rebate:           20,
+				CompanyTitleID:   "PEDIDO 1001",
+				ProtestCode:      1,
+				ProtestDays:      5,
+				WriteOffCode:     1,
+				WriteOffDays:     60,
+				Currency:         9,
+			},
+		},
+		{
+			description: "it should decode the segment T",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab240.LineSize,
+					layouttest.Number(1, 3, 104),               // código do banco na compensação
+					layouttest.Number(4, 7, 1),                 // lote de serviço
+					layouttest.Text(8, 8, "3"),                 // tipo de registro
+					layouttest.Number(9, 13, 1),                // nº sequencial do registro no lote
+					layouttest.Text(14, 14, "T"),               // código do segmento do registro detalhe
+					layouttest.Text(15, 15, ""),                // uso exclusivo FEBRABAN/CNAB
+					layouttest.Number(16, 17, 6),               // código de movimento retorno
+					layouttest.Number(18, 22, 1234),            // agência mantenedora da conta
+					layouttest.Text(23, 23, "5"),               // dígito verificador da agência
+					layouttest.Number(24, 29, 654321),          // código do convênio no banco
+					layouttest.Number(30, 40, 0),               // uso exclusivo CAIXA
+					layouttest.Number(41, 42, 14),              // modalidade do nosso número
+					layouttest.Number(43, 57, 1),               // identificação do título no banco
+					layouttest.Number(58, 58, 1),               // código da carteira
+					layouttest.Text(59, 69, "NF-1001"),         // número do documento de cobrança
+					layouttest.Text(70, 73, ""),                // uso exclusivo CAIXA
+					layouttest.Text(74, 81, "10112026"),        // data do vencimento do título
+					layouttest.Number(82, 96, 150075),          // valor nominal do título
+					layouttest.Number(97, 99, 104),             // código do banco cobrador/recebedor
+					layouttest.Number(100, 104, 4321),          // agência cobradora/recebedora
+					layouttest.Text(105, 105, "0"),             // dígito verificador da agência
+					layouttest.Text(106, 130, "PEDIDO 1001"),   // identificação do título na empresa
+					layouttest.Number(131, 132, 9),             // código da moeda
+					layouttest.Number(133, 133, 1),             // tipo de inscrição do pagador
+					layouttest.Number(134, 148, 12345678909),   // número de inscrição do pagador
+					layouttest.Text(149, 188, "FULANO DE TAL"), // nome do pagador
+					layouttest.Text(189, 198, ""),              // uso exclusivo FEBRABAN/CNAB
+					layouttest.Number(199, 213, 250),           // valor da tarifa/custas
+					layouttest.Text(214, 223, "A1B2"),          // motivo da ocorrência
+					layouttest.Text(224, 240, ""),              // uso exclusivo FEBRABAN/CNAB
+				)
+			},
+			expected: caixa.SegmentT{
+				Batch:                 1,
+				Sequence:              1,
+				Occurrence:            febraban240.OccurrenceSettlement,
+				Agency:                1234,
+				AgencyDigit:           "5",
+				BeneficiaryCode:       654321,
+				Modality:              caixa.ModalityRegisteredCompany,
+				OurNumber:             1,
+				Wallet:                1,
+				DocumentNumber:        "NF-1001",
+				DueDate:               cnab240.NewDate(2026, 11, 10),
+				Amount:                1500.75,
+				CollectingBank:        104,
+				CollectingAgency:      4321,
+				CollectingAgencyDigit: "0",
+				CompanyTitleID:        "PEDIDO 1001",
+				Currency:              9,
+				PayerDocumentType:     caixa.DocumentTypeCPF,
+				PayerDocument:         12345678909,
+				PayerName:             "FULANO DE TAL",
+				Fee:                   2.5,
+				OccurrenceReasons:     "A1B2",
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			decoded := reflect.New(reflect.TypeOf(scenario.expected))
+			if err := gocnab.Unmarshal(scenario.line(t), decoded.Interface()); err != nil {
+				t.Fatalf("error unmarshalling. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(scenario.expected, decoded.Elem().Interface()) {
+				t.Errorf("expected record “%#v” and got “%#v”", scenario.expected, decoded.Elem().Interface())
+			}
+		})
+	}
+}
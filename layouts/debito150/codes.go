@@ -0,0 +1,98 @@
+package debito150
+
+// FileCode identifies the direction of the file in the header.
+type FileCode string
+
+// List of file codes.
+const (
+	FileCodeRemittance FileCode = "1"
+	FileCodeReturn     FileCode = "2"
+)
+
+// MovementCode is the code of the last column of the customer records, with a
+// meaning that depends on the record type: in the debits it is a normal debit
+// or a cancellation, in the registrations it is an exclusion or an inclusion
+// of the customer.
+type MovementCode string
+
+// List of movement codes.
+const (
+	MovementNormal    MovementCode = "0"
+	MovementExclusion MovementCode = "1"
+	MovementInclusion MovementCode = "2"
+)
+
+// CurrencyCode is the currency of the debit amount.
+type CurrencyCode string
+
+// List of currency codes.
+const (
+	CurrencyUFIR CurrencyCode = "01"
+	CurrencyReal CurrencyCode = "03"
+)
+
+// DocumentType identifies the type of the document (CNPJ or CPF) of a
+// customer.
+type DocumentType string
+
+// List of document types.
+const (
+	DocumentTypeCNPJ DocumentType = "1"
+	DocumentTypeCPF  DocumentType = "2"
+)
+
+// ReturnCode is the result of a débito automático request, informed by the
+// bank in the registro F.
+type ReturnCode string
+
+// List of return codes.
+const (
+	ReturnDebited                  ReturnCode = "00"
+	ReturnInsufficientFunds        ReturnCode = "01"
+	ReturnAccountNotRegistered     ReturnCode = "02"
+	ReturnOtherRestrictions        ReturnCode = "04"
+	ReturnLimitExceeded            ReturnCode = "05"
+	ReturnAgencyClosing            ReturnCode = "10"
+	ReturnInvalidAmount            ReturnCode = "12"
+	ReturnInvalidDate              ReturnCode = "13"
+	ReturnInvalidAgency            ReturnCode = "14"
+	ReturnInvalidAccount           ReturnCode = "15"
+	ReturnDateBeforeProcessing     ReturnCode = "18"
+	ReturnNoAuthorization          ReturnCode = "30"
+	ReturnDebitedOnAnotherDate     ReturnCode = "31"
+	ReturnRegistrationMaintenance  ReturnCode = "96"
+	ReturnCancellationNotFound     ReturnCode = "97"
+	ReturnCancellationNotPerformed ReturnCode = "98"
+	ReturnCancellationPerformed    ReturnCode = "99"
+)
+
+var returnDescriptions = map[ReturnCode]string{
+	ReturnDebited:                  "Débito efetuado",
+	ReturnInsufficientFunds:        "Insuficiência de fundos",
+	ReturnAccountNotRegistered:     "Conta corrente não cadastrada",
+	ReturnOtherRestrictions:        "Outras restrições",
+	ReturnLimitExceeded:            "Valor do débito excede o valor limite aprovado",
+	ReturnAgencyClosing:            "Agência em regime de encerramento",
+	ReturnInvalidAmount:            "Valor inválido",
+	ReturnInvalidDate:              "Data de lançamento inválida",
+	ReturnInvalidAgency:            "Agência inválida",
+	ReturnInvalidAccount:           "Conta corrente inválida",
+	ReturnDateBeforeProcessing:     "Data do débito anterior à do processamento",
+	ReturnNoAuthorization:          "Sem contrato de débito automático",
+	ReturnDebitedOnAnotherDate:     "Débito efetuado em data diferente da informada (feriado na praça de débito)",
+	ReturnRegistrationMaintenance:  "Manutenção do cadastro",
+	ReturnCancellationNotFound:     "Cancelamento não efetuado, débito não encontrado",
+	ReturnCancellationNotPerformed: "Cancelamento não efetuado, fora do tempo hábil",
+	ReturnCancellationPerformed:    "Cancelamento efetuado conforme solicitação",
+}
+
+// Description returns the description of the return code, or an empty string
+// for unknown codes.
+func (r ReturnCode) Description() string {
+	return returnDescriptions[r]
+}
+
+// Debited returns true when the debit was made, even if in another date.
+func (r ReturnCode) Debited() bool {
+	return r == ReturnDebited || r == ReturnDebitedOnAnotherDate
+}
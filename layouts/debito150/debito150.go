@@ -0,0 +1,69 @@
+// Package debito150 contains the record types of the FEBRABAN CNAB 150 layout
+// of débito automático (version 05), ready to be used with gocnab.Marshal150
+// and a gocnab.Mapper.
+//
+// Each line of the file is identified by the code of its first column (A for
+// the header, E for the debits, Z for the trailer, etc.), so the records can
+// be mixed in any order between the header and the trailer. The totals of the
+// trailer are filled automatically when the whole file is marshaled in the
+// same call, and verified when it is decoded with the mapper:
+//
+//	data, err := gocnab.Marshal150(
+//	  debito150.Header{...},
+//	  []debito150.Debit{...},
+//	  debito150.Trailer{},
+//	)
+//
+//	records, err := debito150.NewMapper().Decode(data)
+package debito150
+
+import "github.com/rafaeljusto/gocnab"
+
+// LineSize number of characters of each CNAB 150 line.
+const LineSize = 150
+
+// Record codes, stored in the first column of every line.
+const (
+	RecordCodeHeader                         = "A"
+	RecordCodeRegistration                   = "B"
+	RecordCodeRegistrationOccurrence         = "C"
+	RecordCodeIdentificationChange           = "D"
+	RecordCodeDebit                          = "E"
+	RecordCodeDebitReturn                    = "F"
+	RecordCodeIdentificationChangeOccurrence = "H"
+	RecordCodeIncentive                      = "I"
+	RecordCodeConfirmation                   = "J"
+	RecordCodeEntry                          = "K"
+	RecordCodeBillingSchedule                = "L"
+	RecordCodeDebitedTotal                   = "T"
+	RecordCodeAgency                         = "X"
+	RecordCodeTrailer                        = "Z"
+)
+
+// MatchRecordCode detects lines of a record code, to be used when registering
+// the record types in the mapper.
+func MatchRecordCode(code string) gocnab.Discriminator {
+	return gocnab.MatchRange(0, 1, code)
+}
+
+// NewMapper returns a mapper that decodes all the record types of the CNAB 150
+// débito automático layout, for remittance and return files. Use
+// gocnab.Mapper.Decode to get the records in the order of the file.
+func NewMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*Header)(nil), MatchRecordCode(RecordCodeHeader))
+	mapper.Register((*Registration)(nil), MatchRecordCode(RecordCodeRegistration))
+	mapper.Register((*RegistrationOccurrence)(nil), MatchRecordCode(RecordCodeRegistrationOccurrence))
+	mapper.Register((*IdentificationChange)(nil), MatchRecordCode(RecordCodeIdentificationChange))
+	mapper.Register((*Debit)(nil), MatchRecordCode(RecordCodeDebit))
+	mapper.Register((*DebitReturn)(nil), MatchRecordCode(RecordCodeDebitReturn))
+	mapper.Register((*IdentificationChangeOccurrence)(nil), MatchRecordCode(RecordCodeIdentificationChangeOccurrence))
+	mapper.Register((*Incentive)(nil), MatchRecordCode(RecordCodeIncentive))
+	mapper.Register((*Confirmation)(nil), MatchRecordCode(RecordCodeConfirmation))
+	mapper.Register((*Entry)(nil), MatchRecordCode(RecordCodeEntry))
+	mapper.Register((*BillingSchedule)(nil), MatchRecordCode(RecordCodeBillingSchedule))
+	mapper.Register((*DebitedTotal)(nil), MatchRecordCode(RecordCodeDebitedTotal))
+	mapper.Register((*Agency)(nil), MatchRecordCode(RecordCodeAgency))
+	mapper.Register((*Trailer)(nil), MatchRecordCode(RecordCodeTrailer))
+	return mapper
+}
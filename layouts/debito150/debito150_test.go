@@ -0,0 +1,262 @@
+package debito150_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnabdate"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/debito150"
+)
+
+// decodeValues decodes the file with the débito automático mapper, returning
+// only the records.
+func decodeValues(t *testing.T, data []byte) []interface{} {
+	t.Helper()
+
+	records, err := debito150.NewMapper().Decode(data, gocnab.WithUnknownLineError())
+	if err != nil {
+		t.Fatalf("error decoding. details: %s", err)
+	}
+
+	values := make([]interface{}, len(records))
+	for i, record := range records {
+		values[i] = record.Value
+	}
+	return values
+}
+
+func TestLayouts(t *testing.T) {
+	t.Parallel()
+
+	layouttest.CheckCoverage(t, debito150.LineSize,
+		debito150.Header{},
+		debito150.Registration{},
+		debito150.RegistrationOccurrence{},
+		debito150.IdentificationChange{},
+		debito150.Debit{},
+		debito150.DebitReturn{},
+		debito150.IdentificationChangeOccurrence{},
+		debito150.Incentive{},
+		debito150.Confirmation{},
+		debito150.Entry{},
+		debito150.BillingSchedule{},
+		debito150.DebitedTotal{},
+		debito150.Agency{},
+		debito150.Trailer{},
+	)
+}
+
+func TestRemittance(t *testing.T) {
+	t.Parallel()
+
+	header := debito150.Header{
+		FileCode:       debito150.FileCodeRemittance,
+		Agreement:      "CONV123456",
+		CompanyName:    "EMPRESA DE ENERGIA",
+		BankCode:       237,
+		BankName:       "BRADESCO",
+		GenerationDate: cnabdate.NewDate(2026, 10, 18),
+		FileSequence:   15,
+	}
+
+	debits := []debito150.Debit{
+		{
+			CompanyCustomer: "UC0001234",
+			Agency:          1234,
+			BankCustomer:    "00000000123456",
+			DueDate:         cnabdate.NewDate(2026, 11, 10),
+			Amount:          150.75,
+			Currency:        debito150.CurrencyReal,
+			CompanyUse:      "FATURA 10/2026",
+			DocumentType:    debito150.DocumentTypeCPF,
+			Document:        12345678909,
+			Movement:        debito150.MovementNormal,
+		},
+		{
+			CompanyCustomer: "UC0005678",
+			Agency:          4321,
+			BankCustomer:    "00000000654321",
+			DueDate:         cnabdate.NewDate(2026, 11, 10),
+			Amount:          89.9,
+			Currency:        debito150.CurrencyReal,
+			DocumentType:    debito150.DocumentTypeCNPJ,
+			Document:        98765432000198,
+			Movement:        debito150.MovementNormal,
+		},
+	}
+
+	change := debito150.IdentificationChange{
+		CompanyCustomer:    "UC0009999",
+		Agency:             1234,
+		BankCustomer:       "00000000999999",
+		NewCompanyCustomer: "UC0010000",
+		Movement:           debito150.MovementNormal,
+	}
+
+	schedule := debito150.BillingSchedule{
+		BillingDate:    cnabdate.NewDate(2026, 10, 25),
+		DueDate:        cnabdate.NewDate(2026, 11, 10),
+		RemittanceDate: cnabdate.NewDate(2026, 11, 1),
+	}
+
+	data, err := gocnab.Marshal150(header, debits, change, schedule, debito150.Trailer{})
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "remessa.golden", data)
+
+	expected := []interface{}{
+		header,
+		debits[0],
+		debits[1],
+		change,
+		schedule,
+		debito150.Trailer{
+			Records: 6,
+			Total:   240.65,
+		},
+	}
+
+	if values := decodeValues(t, golden); !reflect.DeepEqual(expected, values) {
+		t.Errorf("expected records “%#v” and got “%#v”", expected, values)
+	}
+}
+
+func TestReturn(t *testing.T) {
+	t.Parallel()
+
+	header := debito150.Header{
+		FileCode:       debito150.FileCodeReturn,
+		Agreement:      "CONV123456",
+		CompanyName:    "EMPRESA DE ENERGIA",
+		BankCode:       237,
+		BankName:       "BRADESCO",
+		GenerationDate: cnabdate.NewDate(2026, 11, 11),
+		FileSequence:   15,
+	}
+
+	registration := debito150.Registration{
+		CompanyCustomer: "UC0007777",
+		Agency:          1234,
+		BankCustomer:    "00000000777777",
+		OptionDate:      cnabdate.NewDate(2026, 11, 3),
+		Movement:        debito150.MovementInclusion,
+	}
+
+	returns := []debito150.DebitReturn{
+		{
+			CompanyCustomer: "UC0001234",
+			Agency:          1234,
+			BankCustomer:    "00000000123456",
+			Date:            cnabdate.NewDate(2026, 11, 10),
+			Amount:          150.75,
+			Return:          debito150.ReturnDebited,
+			CompanyUse:      "FATURA 10/2026",
+			DocumentType:    debito150.DocumentTypeCPF,
+			Document:        12345678909,
+			Movement:        debito150.MovementNormal,
+		},
+		{
+			CompanyCustomer: "UC0005678",
+			Agency:          4321,
+			BankCustomer:    "00000000654321",
+			Date:            cnabdate.NewDate(2026, 11, 10),
+			Amount:          89.9,
+			Return:          debito150.ReturnInsufficientFunds,
+			DocumentType:    debito150.DocumentTypeCNPJ,
+			Document:        98765432000198,
+			Movement:        debito150.MovementNormal,
+		},
+	}
+
+	confirmation := debito150.Confirmation{
+		FileSequence:   15,
+		GenerationDate: cnabdate.NewDate(2026, 10, 18),
+		Records:        6,
+		Total:          240.65,
+		ProcessingDate: cnabdate.NewDate(2026, 10, 19),
+	}
+
+	total := debito150.DebitedTotal{
+		Customers: 1,
+		Total:     150.75,
+	}
+
+	agency := debito150.Agency{
+		Code:    1234,
+		Name:    "AGENCIA CENTRO",
+		Address: "RUA DIREITA",
+		Number:  100,
+		ZipCode: 1001,
+		City:    "SAO PAULO",
+		State:   "SP",
+		Status:  "A",
+	}
+
+	data, err := gocnab.Marshal150(header, registration, returns, confirmation, total, agency, debito150.Trailer{})
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "retorno.golden", data)
+
+	expected := []interface{}{
+		header,
+		registration,
+		returns[0],
+		returns[1],
+		confirmation,
+		total,
+		agency,
+		debito150.Trailer{
+			Records: 8,
+			Total:   240.65,
+		},
+	}
+
+	values := decodeValues(t, golden)
+	if !reflect.DeepEqual(expected, values) {
+		t.Fatalf("expected records “%#v” and got “%#v”", expected, values)
+	}
+
+	failed := values[3].(debito150.DebitReturn)
+	if failed.Return.Debited() {
+		t.Error("expected a debit not made")
+	}
+
+	if description := failed.Return.Description(); description != "Insuficiência de fundos" {
+		t.Errorf("unexpected return description “%s”", description)
+	}
+}
+
+func TestTrailer(t *testing.T) {
+	t.Parallel()
+
+	data, err := gocnab.Marshal150(
+		debito150.Header{FileCode: debito150.FileCodeRemittance},
+		debito150.Debit{Amount: 10, Currency: debito150.CurrencyReal, Movement: debito150.MovementNormal},
+		debito150.Trailer{Records: 2, Total: 5},
+	)
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	lines := bytes.Split(data, []byte(gocnab.LineBreak))
+	if trailer := string(lines[2][:24]); trailer != "Z00000300000000000001000" {
+		t.Errorf("unexpected trailer totals “%s”", trailer)
+	}
+
+	// change the number of records of the trailer
+	lines[2][6] = '2'
+
+	_, err = debito150.NewMapper().Decode(bytes.Join(lines, []byte(gocnab.LineBreak)))
+	var fieldErr gocnab.UnmarshalFieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "Records" || fieldErr.Err != gocnab.ErrAggregateMismatch {
+		t.Errorf("expected an aggregate mismatch and got “%v”", err)
+	}
+}
@@ -0,0 +1,202 @@
+package debito150
+
+import "github.com/rafaeljusto/gocnab/cnabdate"
+
+// Header is the first record of a remittance or return file (registro A). The
+// agreement code (código do convênio) is given by the bank, and the file
+// sequence number (NSA) must be incremented for each file sent.
+type Header struct {
+	_              string        `cnab:"0,1,const=A"`
+	FileCode       FileCode      `cnab:"1,2,enum=1|2"`
+	Agreement      string        `cnab:"2,22"`
+	CompanyName    string        `cnab:"22,42"`
+	BankCode       int           `cnab:"42,45"`
+	BankName       string        `cnab:"45,65"`
+	GenerationDate cnabdate.Date `cnab:"65,73"`
+	FileSequence   int           `cnab:"73,79"`
+	_              string        `cnab:"79,81,const=05"`
+	_              string        `cnab:"81,98,const=DEBITO AUTOMATICO"`
+	_              string        `cnab:"98,150"`
+}
+
+// Registration informs the company that a customer opted in or out of the
+// débito automático directly with the bank (registro B, sent by the bank). The
+// movement is MovementExclusion or MovementInclusion.
+type Registration struct {
+	_               string        `cnab:"0,1,const=B"`
+	CompanyCustomer string        `cnab:"1,26"`
+	Agency          int           `cnab:"26,30"`
+	BankCustomer    string        `cnab:"30,44"`
+	OptionDate      cnabdate.Date `cnab:"44,52"`
+	_               string        `cnab:"52,149"`
+	Movement        MovementCode  `cnab:"149,150,enum=1|2"`
+}
+
+// RegistrationOccurrence informs the bank about the problems of the customer
+// registrations received from it (registro C, sent by the company). The
+// movement is MovementExclusion or MovementInclusion.
+type RegistrationOccurrence struct {
+	_               string       `cnab:"0,1,const=C"`
+	CompanyCustomer string       `cnab:"1,26"`
+	Agency          int          `cnab:"26,30"`
+	BankCustomer    string       `cnab:"30,44"`
+	Occurrence1     string       `cnab:"44,84"`
+	Occurrence2     string       `cnab:"84,124"`
+	_               string       `cnab:"124,149"`
+	Movement        MovementCode `cnab:"149,150,enum=1|2"`
+}
+
+// IdentificationChange replaces the identification of a customer in the
+// company (registro D, sent by the company). The movement is MovementNormal to
+// change the identification or MovementExclusion to remove the customer.
+type IdentificationChange struct {
+	_                  string       `cnab:"0,1,const=D"`
+	CompanyCustomer    string       `cnab:"1,26"`
+	Agency             int          `cnab:"26,30"`
+	BankCustomer       string       `cnab:"30,44"`
+	NewCompanyCustomer string       `cnab:"44,69"`
+	Occurrence         string       `cnab:"69,129"`
+	_                  string       `cnab:"129,149"`
+	Movement           MovementCode `cnab:"149,150,enum=0|1"`
+}
+
+// Debit is a débito automático request in the account of a customer (registro
+// E, sent by the company). The movement is MovementNormal for new debits or
+// MovementExclusion to cancel a debit sent before.
+type Debit struct {
+	_               string        `cnab:"0,1,const=E"`
+	CompanyCustomer string        `cnab:"1,26"`
+	Agency          int           `cnab:"26,30"`
+	BankCustomer    string        `cnab:"30,44"`
+	DueDate         cnabdate.Date `cnab:"44,52"`
+	Amount          float64       `cnab:"52,67"`
+	Currency        CurrencyCode  `cnab:"67,69,enum=01|03"`
+	CompanyUse      string        `cnab:"69,118"`
+	DocumentType    DocumentType  `cnab:"118,119"`
+	Document        int64         `cnab:"119,134"`
+	_               string        `cnab:"134,149"`
+	Movement        MovementCode  `cnab:"149,150,enum=0|1"`
+}
+
+// DebitReturn is the result of a débito automático request (registro F, sent
+// by the bank). The date and the amount are the ones of the debit when it was
+// made, or the ones of the request otherwise.
+type DebitReturn struct {
+	_               string        `cnab:"0,1,const=F"`
+	CompanyCustomer string        `cnab:"1,26"`
+	Agency          int           `cnab:"26,30"`
+	BankCustomer    string        `cnab:"30,44"`
+	Date            cnabdate.Date `cnab:"44,52"`
+	Amount          float64       `cnab:"52,67"`
+	Return          ReturnCode    `cnab:"67,69"`
+	CompanyUse      string        `cnab:"69,118"`
+	DocumentType    DocumentType  `cnab:"118,119"`
+	Document        int64         `cnab:"119,134"`
+	_               string        `cnab:"134,149"`
+	Movement        MovementCode  `cnab:"149,150,enum=0|1"`
+}
+
+// IdentificationChangeOccurrence informs the company about the problems of the
+// identification changes received from it (registro H, sent by the bank).
+type IdentificationChangeOccurrence struct {
+	_                  string       `cnab:"0,1,const=H"`
+	CompanyCustomer    string       `cnab:"1,26"`
+	Agency             int          `cnab:"26,30"`
+	BankCustomer       string       `cnab:"30,44"`
+	NewCompanyCustomer string       `cnab:"44,69"`
+	Occurrence         string       `cnab:"69,127"`
+	_                  string       `cnab:"127,149"`
+	Movement           MovementCode `cnab:"149,150,enum=0|1"`
+}
+
+// Incentive informs the bank about a customer that could be invited to the
+// débito automático (registro I, sent by the company).
+type Incentive struct {
+	_               string       `cnab:"0,1,const=I"`
+	CompanyCustomer string       `cnab:"1,26"`
+	DocumentType    DocumentType `cnab:"26,27,enum=1|2"`
+	Document        int64        `cnab:"27,41"`
+	Name            string       `cnab:"41,81"`
+	City            string       `cnab:"81,111"`
+	State           string       `cnab:"111,113"`
+	_               string       `cnab:"113,150"`
+}
+
+// Confirmation confirms that the bank processed a file sent by the company
+// (registro J, sent by the bank), repeating the totals of its trailer.
+type Confirmation struct {
+	_              string        `cnab:"0,1,const=J"`
+	FileSequence   int           `cnab:"1,7"`
+	GenerationDate cnabdate.Date `cnab:"7,15"`
+	Records        int           `cnab:"15,21"`
+	Total          float64       `cnab:"21,38"`
+	ProcessingDate cnabdate.Date `cnab:"38,46"`
+	_              string        `cnab:"46,150"`
+}
+
+// Entry is a debit or credit in the account of a customer that isn't a
+// débito automático of the company (registro K, sent by the company), like
+// taxes collected by the company. The treatment type and the revenue code
+// are agreed with the bank.
+type Entry struct {
+	_               string       `cnab:"0,1,const=K"`
+	CompanyCustomer string       `cnab:"1,26"`
+	Agency          int          `cnab:"26,30"`
+	BankCustomer    string       `cnab:"30,44"`
+	TreatmentType   int          `cnab:"44,46"`
+	Amount          float64      `cnab:"46,61"`
+	RevenueCode     int          `cnab:"61,65"`
+	DocumentType    DocumentType `cnab:"65,66"`
+	Document        int64        `cnab:"66,81"`
+	_               string       `cnab:"81,149"`
+	Movement        MovementCode `cnab:"149,150,enum=0|1"`
+}
+
+// BillingSchedule informs the bank the schedule of the bills of the company
+// (registro L, sent by the company): when the bills are generated, when they
+// are due and when the file with the debits is sent.
+type BillingSchedule struct {
+	_              string        `cnab:"0,1,const=L"`
+	BillingDate    cnabdate.Date `cnab:"1,9"`
+	DueDate        cnabdate.Date `cnab:"9,17"`
+	RemittanceDate cnabdate.Date `cnab:"17,25"`
+	_              string        `cnab:"25,150"`
+}
+
+// DebitedTotal contains the number of customers debited and the amount
+// debited in a return file (registro T, sent by the bank).
+type DebitedTotal struct {
+	_         string  `cnab:"0,1,const=T"`
+	Customers int     `cnab:"1,7"`
+	Total     float64 `cnab:"7,24"`
+	_         string  `cnab:"24,150"`
+}
+
+// Agency is an agency of the bank where the customers could opt in the débito
+// automático (registro X, sent by the bank). The status is "A" for active
+// agencies and "B" for agencies being closed.
+type Agency struct {
+	_             string `cnab:"0,1,const=X"`
+	Code          int    `cnab:"1,5"`
+	Name          string `cnab:"5,35"`
+	Address       string `cnab:"35,65"`
+	Number        int    `cnab:"65,70"`
+	ZipCode       int    `cnab:"70,75"`
+	ZipCodeSuffix int    `cnab:"75,78"`
+	City          string `cnab:"78,98"`
+	State         string `cnab:"98,100"`
+	Status        string `cnab:"100,101,enum=A|B"`
+	_             string `cnab:"101,150"`
+}
+
+// Trailer is the last record of a remittance or return file (registro Z). The
+// number of records of the file (including the header and the trailer) and
+// the total amount of the debits (registros E and F) are filled when the file
+// is marshaled in a single gocnab.Marshal150 call, and verified by the
+// mapper.
+type Trailer struct {
+	_       string  `cnab:"0,1,const=Z"`
+	Records int     `cnab:"1,7,count=Header|Registration|RegistrationOccurrence|IdentificationChange|Debit|DebitReturn|IdentificationChangeOccurrence|Incentive|Confirmation|Entry|BillingSchedule|DebitedTotal|Agency|Trailer"`
+	Total   float64 `cnab:"7,24,sum=Debit.Amount|DebitReturn.Amount"`
+	_       string  `cnab:"24,150"`
+}
@@ -0,0 +1,317 @@
+package febraban240
+
+import (
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+)
+
+// ServiceCobranca is the service type of the cobrança batches.
+const ServiceCobranca = "01"
+
+// CobrancaBatchHeader is the header of a cobrança batch (registro 1).
+type CobrancaBatchHeader struct {
+	Bank                int          `cnab:"0,3"`
+	Batch               int          `cnab:"3,7"`
+	_                   string       `cnab:"7,8,const=1"`
+	Operation           string       `cnab:"8,9"`
+	_                   string       `cnab:"9,11,const=01"`
+	_                   string       `cnab:"11,13"`
+	LayoutVersion       string       `cnab:"13,16"`
+	_                   string       `cnab:"16,17"`
+	CompanyDocumentType DocumentType `cnab:"17,18"`
+	CompanyDocument     int64        `cnab:"18,33"`
+	Agreement           string       `cnab:"33,53"`
+	Agency              int          `cnab:"53,58"`
+	AgencyDigit         string       `cnab:"58,59"`
+	Account             int64        `cnab:"59,71"`
+	AccountDigit        string       `cnab:"71,72"`
+	AgencyAccountDigit  string       `cnab:"72,73"`
+	CompanyName         string       `cnab:"73,103"`
+	Message1            string       `cnab:"103,143"`
+	Message2            string       `cnab:"143,183"`
+	RemittanceNumber    int          `cnab:"183,191"`
+	RecordingDate       cnab240.Date `cnab:"191,199"`
+	CreditDate          cnab240.Date `cnab:"199,207"`
+	_                   string       `cnab:"207,240"`
+}
+
+// CobrancaBatchTrailer is the trailer of a cobrança batch (registro 5). The
+// number of records is filled by cnab240.Marshal.
+type CobrancaBatchTrailer struct {
+	Bank             int     `cnab:"0,3"`
+	Batch            int     `cnab:"3,7"`
+	_                string  `cnab:"7,8,const=5"`
+	_                string  `cnab:"8,17"`
+	Records          int     `cnab:"17,23"`
+	SimpleCount      int     `cnab:"23,29"`
+	SimpleAmount     float64 `cnab:"29,46"`
+	LinkedCount      int     `cnab:"46,52"`
+	LinkedAmount     float64 `cnab:"52,69"`
+	PledgedCount     int     `cnab:"69,75"`
+	PledgedAmount    float64 `cnab:"75,92"`
+	DiscountedCount  int     `cnab:"92,98"`
+	DiscountedAmount float64 `cnab:"98,115"`
+	NoticeNumber     string  `cnab:"115,123"`
+	_                string  `cnab:"123,240"`
+}
+
+// SegmentP contains the main data of a título in a remittance (segmento P).
+type SegmentP struct {
+	Bank                  int             `cnab:"0,3"`
+	Batch                 int             `cnab:"3,7"`
+	_                     string          `cnab:"7,8,const=3"`
+	Sequence              int             `cnab:"8,13"`
+	_                     string          `cnab:"13,14,const=P"`
+	_                     string          `cnab:"14,15"`
+	Movement              InstructionCode `cnab:"15,17"`
+	Agency                int             `cnab:"17,22"`
+	AgencyDigit           string          `cnab:"22,23"`
+	Account               int64           `cnab:"23,35"`
+	AccountDigit          string          `cnab:"35,36"`
+	AgencyAccountDigit    string          `cnab:"36,37"`
+	OurNumber             string          `cnab:"37,57"`
+	Wallet                int             `cnab:"57,58"`
+	RegistrationType      int             `cnab:"58,59"`
+	DocumentKind          int             `cnab:"59,60"`
+	IssuanceType          int             `cnab:"60,61"`
+	DistributionType      string          `cnab:"61,62"`
+	DocumentNumber        string          `cnab:"62,77"`
+	DueDate               cnab240.Date    `cnab:"77,85"`
+	Amount                float64         `cnab:"85,100"`
+	CollectingAgency      int             `cnab:"100,105"`
+	CollectingAgencyDigit string          `cnab:"105,106"`
+	TitleKind             int             `cnab:"106,108"`
+	Acceptance            string          `cnab:"108,109"`
+	IssueDate             cnab240.Date    `cnab:"109,117"`
+	InterestCode          int             `cnab:"117,118"`
+	InterestDate          cnab240.Date    `cnab:"118,126"`
+	Interest              float64         `cnab:"126,141"`
+	DiscountCode          int             `cnab:"141,142"`
+	DiscountDate          cnab240.Date    `cnab:"142,150"`
+	Discount              float64         `cnab:"150,165"`
+	IOF                   float64         `cnab:"165,180"`
+	Rebate                float64         `cnab:"180,195"`
+	CompanyTitleID        string          `cnab:"195,220"`
+	ProtestCode           int             `cnab:"220,221"`
+	ProtestDays           int             `cnab:"221,223"`
+	WriteOffCode          int             `cnab:"223,224"`
+	WriteOffDays          int             `cnab:"224,227"`
+	Currency              int             `cnab:"227,229"`
+	Contract              int64           `cnab:"229,239"`
+	PartialPayment        string          `cnab:"239,240"`
+}
+
+// SegmentQ contains the payer (pagador) and the guarantor (sacador/avalista)
+// of a título in a remittance (segmento Q).
+type SegmentQ struct {
+	Bank                   int             `cnab:"0,3"`
+	Batch                  int             `cnab:"3,7"`
+	_                      string          `cnab:"7,8,const=3"`
+	Sequence               int             `cnab:"8,13"`
+	_                      string          `cnab:"13,14,const=Q"`
+	_                      string          `cnab:"14,15"`
+	Movement               InstructionCode `cnab:"15,17"`
+	PayerDocumentType      DocumentType    `cnab:"17,18"`
+	PayerDocument          int64           `cnab:"18,33"`
+	PayerName              string          `cnab:"33,73"`
+	PayerAddress           string          `cnab:"73,113"`
+	PayerDistrict          string          `cnab:"113,128"`
+	PayerZipCode           int             `cnab:"128,133"`
+	PayerZipCodeSuffix     int             `cnab:"133,136"`
+	PayerCity              string          `cnab:"136,151"`
+	PayerState             string          `cnab:"151,153"`
+	GuarantorDocumentType  DocumentType    `cnab:"153,154"`
+	GuarantorDocument      int64           `cnab:"154,169"`
+	GuarantorName          string          `cnab:"169,209"`
+	CorrespondentBank      int             `cnab:"209,212"`
+	CorrespondentOurNumber string          `cnab:"212,232"`
+	_                      string          `cnab:"232,240"`
+}
+
+// SegmentR contains the additional discounts, the fine and the messages of a
+// título in a remittance (segmento R).
+type SegmentR struct {
+	Bank                    int             `cnab:"0,3"`
+	Batch                   int             `cnab:"3,7"`
+	_                       string          `cnab:"7,8,const=3"`
+	Sequence                int             `cnab:"8,13"`
+	_                       string          `cnab:"13,14,const=R"`
+	_                       string          `cnab:"14,15"`
+	Movement                InstructionCode `cnab:"15,17"`
+	Discount2Code           int             `cnab:"17,18"`
+	Discount2Date           cnab240.Date    `cnab:"18,26"`
+	Discount2               float64         `cnab:"26,41"`
+	Discount3Code           int             `cnab:"41,42"`
+	Discount3Date           cnab240.Date    `cnab:"42,50"`
+	Discount3               float64         `cnab:"50,65"`
+	FineCode                int             `cnab:"65,66"`
+	FineDate                cnab240.Date    `cnab:"66,74"`
+	Fine                    float64         `cnab:"74,89"`
+	PayerInformation        string          `cnab:"89,99"`
+	Message3                string          `cnab:"99,139"`
+	Message4                string          `cnab:"139,179"`
+	_                       string          `cnab:"179,199"`
+	PayerOccurrenceCode     string          `cnab:"199,207"`
+	DebitBank               int             `cnab:"207,210"`
+	DebitAgency             int             `cnab:"210,215"`
+	DebitAgencyDigit        string          `cnab:"215,216"`
+	DebitAccount            int64           `cnab:"216,228"`
+	DebitAccountDigit       string          `cnab:"228,229"`
+	DebitAgencyAccountDigit string          `cnab:"229,230"`
+	DebitNotice             int             `cnab:"230,231"`
+	_                       string          `cnab:"231,240"`
+}
+
+// SegmentS contains a message to be printed in the boleto (segmento S, print
+// types 1 and 2).
+type SegmentS struct {
+	Bank          int             `cnab:"0,3"`
+	Batch         int             `cnab:"3,7"`
+	_             string          `cnab:"7,8,const=3"`
+	Sequence      int             `cnab:"8,13"`
+	_             string          `cnab:"13,14,const=S"`
+	_             string          `cnab:"14,15"`
+	Movement      InstructionCode `cnab:"15,17"`
+	PrintType     int             `cnab:"17,18"`
+	LineNumber    int             `cnab:"18,20"`
+	Message       string          `cnab:"20,160"`
+	CharacterType int             `cnab:"160,162"`
+	_             string          `cnab:"162,240"`
+}
+
+// SegmentSMessages contains the messages 5 to 9 to be printed in the boleto
+// (segmento S, print type 3).
+type SegmentSMessages struct {
+	Bank     int             `cnab:"0,3"`
+	Batch    int             `cnab:"3,7"`
+	_        string          `cnab:"7,8,const=3"`
+	Sequence int             `cnab:"8,13"`
+	_        string          `cnab:"13,14,const=S"`
+	_        string          `cnab:"14,15"`
+	Movement InstructionCode `cnab:"15,17"`
+	_        string          `cnab:"17,18,const=3"`
+	Message5 string          `cnab:"18,58"`
+	Message6 string          `cnab:"58,98"`
+	Message7 string          `cnab:"98,138"`
+	Message8 string          `cnab:"138,178"`
+	Message9 string          `cnab:"178,218"`
+	_        string          `cnab:"218,240"`
+}
+
+// SegmentT contains the main data of a título in a return file (segmento T).
+type SegmentT struct {
+	Bank                  int            `cnab:"0,3"`
+	Batch                 int            `cnab:"3,7"`
+	_                     string         `cnab:"7,8,const=3"`
+	Sequence              int            `cnab:"8,13"`
+	_                     string         `cnab:"13,14,const=T"`
+	_                     string         `cnab:"14,15"`
+	Occurrence            OccurrenceCode `cnab:"15,17"`
+	Agency                int            `cnab:"17,22"`
+	AgencyDigit           string         `cnab:"22,23"`
+	Account               int64          `cnab:"23,35"`
+	AccountDigit          string         `cnab:"35,36"`
+	AgencyAccountDigit    string         `cnab:"36,37"`
+	OurNumber             string         `cnab:"37,57"`
+	Wallet                int            `cnab:"57,58"`
+	DocumentNumber        string         `cnab:"58,73"`
+	DueDate               cnab240.Date   `cnab:"73,81"`
+	Amount                float64        `cnab:"81,96"`
+	CollectingBank        int            `cnab:"96,99"`
+	CollectingAgency      int            `cnab:"99,104"`
+	CollectingAgencyDigit string         `cnab:"104,105"`
+	CompanyTitleID        string         `cnab:"105,130"`
+	Currency              int            `cnab:"130,132"`
+	PayerDocumentType     DocumentType   `cnab:"132,133"`
+	PayerDocument         int64          `cnab:"133,148"`
+	PayerName             string         `cnab:"148,188"`
+	Contract              int64          `cnab:"188,198"`
+	Fee                   float64        `cnab:"198,213"`
+	OccurrenceReasons     string         `cnab:"213,223"`
+	_                     string         `cnab:"223,240"`
+}
+
+// Reasons returns the codes of the reasons of the occurrence (motivos da
+// ocorrência), that are stored in groups of 2 characters. Empty and zeroed
+// codes are ignored.
+func (s SegmentT) Reasons() []string {
+	var reasons []string
+	for i := 0; i+2 <= len(s.OccurrenceReasons); i += 2 {
+		reason := s.OccurrenceReasons[i : i+2]
+		if reason != "00" && reason != "  " {
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons
+}
+
+// SegmentU contains the amounts and dates of a título in a return file
+// (segmento U).
+type SegmentU struct {
+	Bank                      int            `cnab:"0,3"`
+	Batch                     int            `cnab:"3,7"`
+	_                         string         `cnab:"7,8,const=3"`
+	Sequence                  int            `cnab:"8,13"`
+	_                         string         `cnab:"13,14,const=U"`
+	_                         string         `cnab:"14,15"`
+	Occurrence                OccurrenceCode `cnab:"15,17"`
+	Charges                   float64        `cnab:"17,32"`
+	Discount                  float64        `cnab:"32,47"`
+	Rebate                    float64        `cnab:"47,62"`
+	IOF                       float64        `cnab:"62,77"`
+	PaidAmount                float64        `cnab:"77,92"`
+	NetAmount                 float64        `cnab:"92,107"`
+	OtherExpenses             float64        `cnab:"107,122"`
+	OtherCredits              float64        `cnab:"122,137"`
+	OccurrenceDate            cnab240.Date   `cnab:"137,145"`
+	CreditDate                cnab240.Date   `cnab:"145,153"`
+	PayerOccurrenceCode       string         `cnab:"153,157"`
+	PayerOccurrenceDate       cnab240.Date   `cnab:"157,165"`
+	PayerOccurrenceAmount     float64        `cnab:"165,180"`
+	PayerOccurrenceComplement string         `cnab:"180,210"`
+	CorrespondentBank         int            `cnab:"210,213"`
+	CorrespondentOurNumber    string         `cnab:"213,233"`
+	_                         string         `cnab:"233,240"`
+}
+
+// Boleto is a título of a remittance, composed by the consecutive segments P,
+// Q and optionally R.
+type Boleto struct {
+	P SegmentP  `cnab:"line"`
+	Q SegmentQ  `cnab:"line"`
+	R *SegmentR `cnab:"line"`
+}
+
+// BoletoReturn is a título of a return file, composed by the consecutive
+// segments T and U.
+type BoletoReturn struct {
+	T SegmentT `cnab:"line"`
+	U SegmentU `cnab:"line"`
+}
+
+// NewCobrancaMapper returns a mapper with the cobrança record types, to be
+// used with cnab240.Unmarshal. The títulos are decoded as Boleto (remittance)
+// and BoletoReturn (return file), and segments that aren't part of them (like
+// the segment S) are decoded individually.
+func NewCobrancaMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*FileHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileHeader))
+	mapper.Register((*CobrancaBatchHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchHeader))
+	mapper.Register((*Boleto)(nil), cnab240.MatchSegment("P"))
+	mapper.Register((*SegmentQ)(nil), cnab240.MatchSegment("Q"))
+	mapper.Register((*SegmentR)(nil), cnab240.MatchSegment("R"))
+	mapper.Register((*SegmentS)(nil), cnab240.MatchSegment("S"))
+	mapper.RegisterWithPriority((*SegmentSMessages)(nil), MatchSegmentSMessages, 1)
+	mapper.Register((*BoletoReturn)(nil), cnab240.MatchSegment("T"))
+	mapper.Register((*SegmentU)(nil), cnab240.MatchSegment("U"))
+	mapper.Register((*CobrancaBatchTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchTrailer))
+	mapper.Register((*FileTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileTrailer))
+	return mapper
+}
+
+// MatchSegmentSMessages detects the segment S with the print type 3, that
+// contains the messages 5 to 9.
+var MatchSegmentSMessages = gocnab.MatchAll(
+	cnab240.MatchSegment("S"),
+	gocnab.MatchRange(17, 18, "3"),
+)
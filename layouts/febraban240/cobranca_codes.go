@@ -0,0 +1,185 @@
+package febraban240
+
+// InstructionCode is the movement code of a título in a remittance (código de
+// movimento remessa), defining the instruction sent to the bank.
+type InstructionCode string
+
+// List of instruction codes defined by FEBRABAN. Banks could support only a
+// subset of them.
+const (
+	InstructionEntry                    InstructionCode = "01"
+	InstructionWriteOff                 InstructionCode = "02"
+	InstructionGrantRebate              InstructionCode = "04"
+	InstructionCancelRebate             InstructionCode = "05"
+	InstructionChangeDueDate            InstructionCode = "06"
+	InstructionGrantDiscount            InstructionCode = "07"
+	InstructionCancelDiscount           InstructionCode = "08"
+	InstructionProtest                  InstructionCode = "09"
+	InstructionStopProtestAndWriteOff   InstructionCode = "10"
+	InstructionStopProtestAndKeep       InstructionCode = "11"
+	InstructionChangeInterest           InstructionCode = "12"
+	InstructionWaiveInterest            InstructionCode = "13"
+	InstructionChangeFine               InstructionCode = "14"
+	InstructionWaiveFine                InstructionCode = "15"
+	InstructionChangeDiscount           InstructionCode = "16"
+	InstructionDontGrantDiscount        InstructionCode = "17"
+	InstructionChangeRebate             InstructionCode = "18"
+	InstructionChangePaymentDeadline    InstructionCode = "19"
+	InstructionWaivePaymentDeadline     InstructionCode = "20"
+	InstructionChangeCompanyTitleNumber InstructionCode = "21"
+	InstructionChangeControlNumber      InstructionCode = "22"
+	InstructionChangePayer              InstructionCode = "23"
+	InstructionChangeGuarantor          InstructionCode = "24"
+	InstructionRefusePayerAllegation    InstructionCode = "30"
+	InstructionChangeOtherData          InstructionCode = "31"
+	InstructionChangeCreditSplit        InstructionCode = "33"
+	InstructionCancelCreditSplit        InstructionCode = "34"
+	InstructionUnscheduleAutomaticDebit InstructionCode = "35"
+	InstructionChangeWallet             InstructionCode = "40"
+	InstructionCancelProtest            InstructionCode = "41"
+	InstructionChangeTitleKind          InstructionCode = "42"
+	InstructionTransferWallet           InstructionCode = "43"
+	InstructionChangeContract           InstructionCode = "44"
+	InstructionNegativeWithoutProtest   InstructionCode = "45"
+	InstructionWriteOffNegativeTitle    InstructionCode = "46"
+	InstructionChangeNominalAmount      InstructionCode = "47"
+	InstructionChangeMinimumAmount      InstructionCode = "48"
+	InstructionChangeMaximumAmount      InstructionCode = "49"
+)
+
+var instructionDescriptions = map[InstructionCode]string{
+	InstructionEntry:                    "Entrada de títulos",
+	InstructionWriteOff:                 "Pedido de baixa",
+	InstructionGrantRebate:              "Concessão de abatimento",
+	InstructionCancelRebate:             "Cancelamento de abatimento",
+	InstructionChangeDueDate:            "Alteração de vencimento",
+	InstructionGrantDiscount:            "Concessão de desconto",
+	InstructionCancelDiscount:           "Cancelamento de desconto",
+	InstructionProtest:                  "Protestar",
+	InstructionStopProtestAndWriteOff:   "Sustar protesto e baixar título",
+	InstructionStopProtestAndKeep:       "Sustar protesto e manter em carteira",
+	InstructionChangeInterest:           "Alteração de juros de mora",
+	InstructionWaiveInterest:            "Dispensar cobrança de juros de mora",
+	InstructionChangeFine:               "Alteração de valor/percentual de multa",
+	InstructionWaiveFine:                "Dispensar cobrança de multa",
+	InstructionChangeDiscount:           "Alteração do valor de desconto",
+	InstructionDontGrantDiscount:        "Não conceder desconto",
+	InstructionChangeRebate:             "Alteração do valor de abatimento",
+	InstructionChangePaymentDeadline:    "Prazo limite de recebimento - alterar",
+	InstructionWaivePaymentDeadline:     "Prazo limite de recebimento - dispensar",
+	InstructionChangeCompanyTitleNumber: "Alterar número do título dado pelo beneficiário",
+	InstructionChangeControlNumber:      "Alterar número de controle do participante",
+	InstructionChangePayer:              "Alterar dados do pagador",
+	InstructionChangeGuarantor:          "Alterar dados do sacador/avalista",
+	InstructionRefusePayerAllegation:    "Recusa da alegação do pagador",
+	InstructionChangeOtherData:          "Alteração de outros dados",
+	InstructionChangeCreditSplit:        "Alteração dos dados do rateio de crédito",
+	InstructionCancelCreditSplit:        "Pedido de cancelamento dos dados do rateio de crédito",
+	InstructionUnscheduleAutomaticDebit: "Pedido de desagendamento do débito automático",
+	InstructionChangeWallet:             "Alteração de carteira",
+	InstructionCancelProtest:            "Cancelar protesto",
+	InstructionChangeTitleKind:          "Alteração de espécie de título",
+	InstructionTransferWallet:           "Transferência de carteira/modalidade de cobrança",
+	InstructionChangeContract:           "Alteração de contrato de cobrança",
+	InstructionNegativeWithoutProtest:   "Negativação sem protesto",
+	InstructionWriteOffNegativeTitle:    "Solicitação de baixa de título negativado sem protesto",
+	InstructionChangeNominalAmount:      "Alteração do valor nominal do título",
+	InstructionChangeMinimumAmount:      "Alteração do valor mínimo/percentual",
+	InstructionChangeMaximumAmount:      "Alteração do valor máximo/percentual",
+}
+
+// Description returns the description of the instruction code defined by
+// FEBRABAN, or an empty string for unknown codes.
+func (i InstructionCode) Description() string {
+	return instructionDescriptions[i]
+}
+
+// OccurrenceCode is the movement code of a título in a return file (código de
+// movimento retorno), informing what happened to the título.
+type OccurrenceCode string
+
+// List of occurrence codes defined by FEBRABAN. Banks could return only a
+// subset of them.
+const (
+	OccurrenceEntryConfirmed             OccurrenceCode = "02"
+	OccurrenceEntryRejected              OccurrenceCode = "03"
+	OccurrenceWalletTransferEntry        OccurrenceCode = "04"
+	OccurrenceWalletTransferWriteOff     OccurrenceCode = "05"
+	OccurrenceSettlement                 OccurrenceCode = "06"
+	OccurrenceDiscountConfirmed          OccurrenceCode = "07"
+	OccurrenceDiscountCancelConfirmed    OccurrenceCode = "08"
+	OccurrenceWriteOff                   OccurrenceCode = "09"
+	OccurrenceTitleInWallet              OccurrenceCode = "11"
+	OccurrenceRebateConfirmed            OccurrenceCode = "12"
+	OccurrenceRebateCancelConfirmed      OccurrenceCode = "13"
+	OccurrenceDueDateChangeConfirmed     OccurrenceCode = "14"
+	OccurrenceFreeOfPayment              OccurrenceCode = "15"
+	OccurrenceSettlementAfterWriteOff    OccurrenceCode = "17"
+	OccurrenceProtestConfirmed           OccurrenceCode = "19"
+	OccurrenceStopProtestConfirmed       OccurrenceCode = "20"
+	OccurrenceSentToNotary               OccurrenceCode = "23"
+	OccurrenceRemovedFromNotary          OccurrenceCode = "24"
+	OccurrenceProtestedAndWrittenOff     OccurrenceCode = "25"
+	OccurrenceInstructionRejected        OccurrenceCode = "26"
+	OccurrenceOtherDataChangeConfirmed   OccurrenceCode = "27"
+	OccurrenceFeeDebit                   OccurrenceCode = "28"
+	OccurrencePayerOccurrence            OccurrenceCode = "29"
+	OccurrenceDataChangeRejected         OccurrenceCode = "30"
+	OccurrenceCreditSplitChangeConfirmed OccurrenceCode = "33"
+	OccurrenceCreditSplitCancelConfirmed OccurrenceCode = "34"
+	OccurrenceAutomaticDebitUnscheduled  OccurrenceCode = "35"
+	OccurrenceNotificationSent           OccurrenceCode = "36"
+	OccurrenceNotificationRejected       OccurrenceCode = "37"
+	OccurrencePaidWithReturnedCheck      OccurrenceCode = "44"
+	OccurrencePaidWithClearedCheck       OccurrenceCode = "45"
+	OccurrenceProtestCancelConfirmed     OccurrenceCode = "46"
+	OccurrencePaidWithPendingCheck       OccurrenceCode = "50"
+	OccurrenceDDARecognized              OccurrenceCode = "51"
+	OccurrenceDDANotRecognized           OccurrenceCode = "52"
+	OccurrenceDDARefused                 OccurrenceCode = "53"
+)
+
+var occurrenceDescriptions = map[OccurrenceCode]string{
+	OccurrenceEntryConfirmed:             "Entrada confirmada",
+	OccurrenceEntryRejected:              "Entrada rejeitada",
+	OccurrenceWalletTransferEntry:        "Transferência de carteira/entrada",
+	OccurrenceWalletTransferWriteOff:     "Transferência de carteira/baixa",
+	OccurrenceSettlement:                 "Liquidação",
+	OccurrenceDiscountConfirmed:          "Confirmação do recebimento da instrução de desconto",
+	OccurrenceDiscountCancelConfirmed:    "Confirmação do recebimento do cancelamento do desconto",
+	OccurrenceWriteOff:                   "Baixa",
+	OccurrenceTitleInWallet:              "Títulos em carteira (em ser)",
+	OccurrenceRebateConfirmed:            "Confirmação recebimento instrução de abatimento",
+	OccurrenceRebateCancelConfirmed:      "Confirmação recebimento instrução de cancelamento abatimento",
+	OccurrenceDueDateChangeConfirmed:     "Confirmação recebimento instrução alteração de vencimento",
+	OccurrenceFreeOfPayment:              "Franco de pagamento",
+	OccurrenceSettlementAfterWriteOff:    "Liquidação após baixa ou liquidação título não registrado",
+	OccurrenceProtestConfirmed:           "Confirmação recebimento instrução de protesto",
+	OccurrenceStopProtestConfirmed:       "Confirmação recebimento instrução de sustação/cancelamento de protesto",
+	OccurrenceSentToNotary:               "Remessa a cartório (aponte em cartório)",
+	OccurrenceRemovedFromNotary:          "Retirada de cartório e manutenção em carteira",
+	OccurrenceProtestedAndWrittenOff:     "Protestado e baixado (baixa por ter sido protestado)",
+	OccurrenceInstructionRejected:        "Instrução rejeitada",
+	OccurrenceOtherDataChangeConfirmed:   "Confirmação do pedido de alteração de outros dados",
+	OccurrenceFeeDebit:                   "Débito de tarifas/custas",
+	OccurrencePayerOccurrence:            "Ocorrências do pagador",
+	OccurrenceDataChangeRejected:         "Alteração de dados rejeitada",
+	OccurrenceCreditSplitChangeConfirmed: "Confirmação da alteração dos dados do rateio de crédito",
+	OccurrenceCreditSplitCancelConfirmed: "Confirmação do cancelamento dos dados do rateio de crédito",
+	OccurrenceAutomaticDebitUnscheduled:  "Confirmação do desagendamento do débito automático",
+	OccurrenceNotificationSent:           "Confirmação de envio de e-mail/SMS",
+	OccurrenceNotificationRejected:       "Envio de e-mail/SMS rejeitado",
+	OccurrencePaidWithReturnedCheck:      "Título pago com cheque devolvido",
+	OccurrencePaidWithClearedCheck:       "Título pago com cheque compensado",
+	OccurrenceProtestCancelConfirmed:     "Instrução para cancelar protesto confirmada",
+	OccurrencePaidWithPendingCheck:       "Título pago com cheque pendente de liquidação",
+	OccurrenceDDARecognized:              "Título DDA reconhecido pelo pagador",
+	OccurrenceDDANotRecognized:           "Título DDA não reconhecido pelo pagador",
+	OccurrenceDDARefused:                 "Título DDA recusado pela CIP",
+}
+
+// Description returns the description of the occurrence code defined by
+// FEBRABAN, or an empty string for unknown codes.
+func (o OccurrenceCode) Description() string {
+	return occurrenceDescriptions[o]
+}
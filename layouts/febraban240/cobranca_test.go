@@ -0,0 +1,580 @@
+package febraban240_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+)
+
+func TestLayouts(t *testing.T) {
+	t.Parallel()
+
+	layouttest.CheckCoverage(t, cnab240.LineSize,
+		febraban240.FileHeader{},
+		febraban240.FileTrailer{},
+		febraban240.CobrancaBatchHeader{},
+		febraban240.CobrancaBatchTrailer{},
+		febraban240.SegmentP{},
+		febraban240.SegmentQ{},
+		febraban240.SegmentR{},
+		febraban240.SegmentS{},
+		febraban240.SegmentSMessages{},
+		febraban240.SegmentT{},
+		febraban240.SegmentU{},
+		febraban240.PagamentoBatchHeader{},
+		febraban240.PagamentoBatchTrailer{},
+		febraban240.SegmentA{},
+		febraban240.SegmentB{},
+		febraban240.SegmentC{},
+		febraban240.SegmentJ{},
+		febraban240.SegmentJ52{},
+		febraban240.SegmentN{},
+		febraban240.SegmentO{},
+		febraban240.SegmentW{},
+		febraban240.SegmentZ{},
+		febraban240.SegmentBPix{},
+		febraban240.SegmentJ52Pix{},
+		febraban240.ExtratoBatchHeader{},
+		febraban240.ExtratoBatchTrailer{},
+		febraban240.SegmentE{},
+	)
+}
+
+func cobrancaFileHeader(fileCode febraban240.FileCode) febraban240.FileHeader {
+	return febraban240.FileHeader{
+		Bank:                341,
+		CompanyDocumentType: febraban240.DocumentTypeCNPJ,
+		CompanyDocument:     12345678000195,
+		Agreement:           "000000000000123456",
+		Agency:              1234,
+		AgencyDigit:         "5",
+		Account:             67890,
+		AccountDigit:        "1",
+		CompanyName:         "EMPRESA EXEMPLO LTDA",
+		BankName:            "BANCO EXEMPLO",
+		FileCode:            fileCode,
+		GenerationDate:      cnab240.NewDate(2026, 10, 18),
+		GenerationTime:      cnab240.NewTime(10, 30, 15),
+		FileSequence:        42,
+		LayoutVersion:       "107",
+	}
+}
+
+func cobrancaBatchHeader(operation string) febraban240.CobrancaBatchHeader {
+	return febraban240.CobrancaBatchHeader{
+		Bank:                341,
+		Batch:               1,
+		Operation:           operation,
+		LayoutVersion:       "060",
+		CompanyDocumentType: febraban240.DocumentTypeCNPJ,
+		CompanyDocument:     12345678000195,
+		Agreement:           "000000000000123456",
+		Agency:              1234,
+		AgencyDigit:         "5",
+		Account:             67890,
+		AccountDigit:        "1",
+		CompanyName:         "EMPRESA EXEMPLO LTDA",
+		RemittanceNumber:    42,
+		RecordingDate:       cnab240.NewDate(2026, 10, 18),
+	}
+}
+
+func TestCobranca_remittance(t *testing.T) {
+	t.Parallel()
+
+	file := cnab240.File{
+		Header: cobrancaFileHeader(febraban240.FileCodeRemittance),
+		Batches: []cnab240.Batch{
+			{
+				Header: cobrancaBatchHeader(febraban240.OperationRemittance),
+				Segments: []cnab240.Segment{
+					febraban240.Boleto{
+						P: febraban240.SegmentP{
+							Bank:           341,
+							Batch:          1,
+							Sequence:       1,
+							Movement:       febraban240.InstructionEntry,
+							Agency:         1234,
+							AgencyDigit:    "5",
+							Account:        67890,
+							AccountDigit:   "1",
+							OurNumber:      "00000000001",
+							Wallet:         1,
+							DocumentNumber: "NF-1001",
+							DueDate:        cnab240.NewDate(2026, 11, 10),
+							Amount:         1500.75,
+							TitleKind:      2,
+							Acceptance:     "N",
+							IssueDate:      cnab240.NewDate(2026, 10, 18),
+							InterestCode:   1,
+							InterestDate:   cnab240.NewDate(2026, 11, 11),
+							Interest:       0.5,
+							CompanyTitleID: "PEDIDO 1001",
+							ProtestCode:    3,
+							WriteOffCode:   1,
+							WriteOffDays:   60,
+							Currency:       9,
+						},
+						Q: febraban240.SegmentQ{
+							Bank:               341,
+							Batch:              1,
+							Sequence:           2,
+							Movement:           febraban240.InstructionEntry,
+							PayerDocumentType:  febraban240.DocumentTypeCPF,
+							PayerDocument:      12345678909,
+							PayerName:          "FULANO DE TAL",
+							PayerAddress:       "RUA DAS FLORES 100",
+							PayerDistrict:      "CENTRO",
+							PayerZipCode:       1001,
+							PayerZipCodeSuffix: 0,
+							PayerCity:          "SAO PAULO",
+							PayerState:         "SP",
+						},
+						R: &febraban240.SegmentR{
+							Bank:     341,
+							Batch:    1,
+							Sequence: 3,
+							Movement: febraban240.InstructionEntry,
+							FineCode: 2,
+							FineDate: cnab240.NewDate(2026, 11, 11),
+							Fine:     2,
+							Message3: "NAO RECEBER APOS 30 DIAS DO VENCIMENTO",
+						},
+					},
+					febraban240.SegmentS{
+						Bank:       341,
+						Batch:      1,
+						Sequence:   4,
+						Movement:   febraban240.InstructionEntry,
+						PrintType:  1,
+						LineNumber: 1,
+						Message:    "OBRIGADO PELA PREFERENCIA",
+					},
+					febraban240.SegmentSMessages{
+						Bank:     341,
+						Batch:    1,
+						Sequence: 5,
+						Movement: febraban240.InstructionEntry,
+						Message5: "MENSAGEM 5",
+						Message9: "MENSAGEM 9",
+					},
+				},
+				Trailer: febraban240.CobrancaBatchTrailer{
+					Bank:         341,
+					Batch:        1,
+					Records:      7,
+					SimpleCount:  1,
+					SimpleAmount: 1500.75,
+				},
+			},
+		},
+		Trailer: febraban240.FileTrailer{
+			Bank:    341,
+			Batches: 1,
+			Records: 9,
+		},
+	}
+
+	data, err := cnab240.Marshal(layouttest.WithoutFields(file, layouttest.CNAB240ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "cobranca_remessa.golden", data)
+
+	decoded, err := cnab240.Unmarshal(golden, febraban240.NewCobrancaMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+}
+
+func TestCobranca_return(t *testing.T) {
+	t.Parallel()
+
+	file := cnab240.File{
+		Header: cobrancaFileHeader(febraban240.FileCodeReturn),
+		Batches: []cnab240.Batch{
+			{
+				Header: cobrancaBatchHeader(febraban240.OperationReturn),
+				Segments: []cnab240.Segment{
+					febraban240.BoletoReturn{
+						T: febraban240.SegmentT{
+							Bank:              341,
+							Batch:             1,
+							Sequence:          1,
+							Occurrence:        febraban240.OccurrenceSettlement,
+							Agency:            1234,
+							AgencyDigit:       "5",
+							Account:           67890,
+							AccountDigit:      "1",
+							OurNumber:         "00000000001",
+							Wallet:            1,
+							DocumentNumber:    "NF-1001",
+							DueDate:           cnab240.NewDate(2026, 11, 10),
+							Amount:            1500.75,
+							CollectingBank:    341,
+							CollectingAgency:  4321,
+							CompanyTitleID:    "PEDIDO 1001",
+							Currency:          9,
+							PayerDocumentType: febraban240.DocumentTypeCPF,
+							PayerDocument:     12345678909,
+							PayerName:         "FULANO DE TAL",
+							Fee:               2.5,
+						},
+						U: febraban240.SegmentU{
+							Bank:           341,
+							Batch:          1,
+							Sequence:       2,
+							Occurrence:     febraban240.OccurrenceSettlement,
+							Charges:        3,
+							PaidAmount:     1503.75,
+							NetAmount:      1501.25,
+							OccurrenceDate: cnab240.NewDate(2026, 11, 12),
+							CreditDate:     cnab240.NewDate(2026, 11, 13),
+						},
+					},
+					febraban240.BoletoReturn{
+						T: febraban240.SegmentT{
+							Bank:              341,
+							Batch:             1,
+							Sequence:          3,
+							Occurrence:        febraban240.OccurrenceEntryRejected,
+							Agency:            1234,
+							AgencyDigit:       "5",
+							Account:           67890,
+							AccountDigit:      "1",
+							OurNumber:         "00000000002",
+							Wallet:            1,
+							DocumentNumber:    "NF-1002",
+							DueDate:           cnab240.NewDate(2026, 11, 20),
+							Amount:            99.9,
+							Currency:          9,
+							PayerDocumentType: febraban240.DocumentTypeCNPJ,
+							PayerDocument:     98765432000198,
+							PayerName:         "CLIENTE EXEMPLO SA",
+							OccurrenceReasons: "0816",
+						},
+						U: febraban240.SegmentU{
+							Bank:           341,
+							Batch:          1,
+							Sequence:       4,
+							Occurrence:     febraban240.OccurrenceEntryRejected,
+							OccurrenceDate: cnab240.NewDate(2026, 10, 19),
+						},
+					},
+				},
+				Trailer: febraban240.CobrancaBatchTrailer{
+					Bank:         341,
+					Batch:        1,
+					Records:      6,
+					SimpleCount:  1,
+					SimpleAmount: 1500.75,
+				},
+			},
+		},
+		Trailer: febraban240.FileTrailer{
+			Bank:    341,
+			Batches: 1,
+			Records: 8,
+		},
+	}
+
+	data, err := cnab240.Marshal(layouttest.WithoutFields(file, layouttest.CNAB240ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "cobranca_retorno.golden", data)
+
+	decoded, err := cnab240.Unmarshal(golden, febraban240.NewCobrancaMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+
+	rejected := decoded.Batches[0].Segments[1].(febraban240.BoletoReturn)
+	if reasons := rejected.T.Reasons(); !reflect.DeepEqual([]string{"08", "16"}, reasons) {
+		t.Errorf("unexpected occurrence reasons “%v”", reasons)
+	}
+
+	if description := rejected.T.Occurrence.Description(); description != "Entrada rejeitada" {
+		t.Errorf("unexpected occurrence description “%s”", description)
+	}
+}
+
+func TestCobranca_spec(t *testing.T) {
+	t.Parallel()
+
+	// lines assembled from the tables of the FEBRABAN CNAB 240 specification
+	// (version 10.7), independently of the encoder
+	segmentP := func(t *testing.T) []byte {
+		return layouttest.SpecLine(t, cnab240.LineSize,
+			layouttest.Number(1, 3, 341),             // código do banco
+			layouttest.Number(4, 7, 1),               // lote de serviço
+			layouttest.Text(8, 8, "3"),               // tipo de registro
+			layouttest.Number(9, 13, 1),              // nº sequencial do registro no lote
+			layouttest.Text(14, 14, "P"),             // código do segmento
+			layouttest.Number(16, 17, 1),             // código de movimento remessa
+			layouttest.Number(18, 22, 1234),          // agência mantenedora da conta
+			layouttest.Text(23, 23, "5"),             // dígito verificador da agência
+			layouttest.Number(24, 35, 67890),         // número da conta corrente
+			layouttest.Text(36, 36, "1"),             // dígito verificador da conta
+			layouttest.Text(37, 37, "2"),             // dígito verificador da agência/conta
+			layouttest.Text(38, 57, "00000000001"),   // identificação do título no banco
+			layouttest.Number(58, 58, 1),             // código da carteira
+			layouttest.Number(59, 59, 1),             // forma de cadastramento do título
+			layouttest.Number(60, 60, 1),             // tipo de documento
+			layouttest.Number(61, 61, 2),             // identificação da emissão do boleto
+			layouttest.Text(62, 62, "2"),             // identificação da distribuição
+			layouttest.Text(63, 77, "NF-1001"),       // número do documento de cobrança
+			layouttest.Text(78, 85, "10112026"),      // data de vencimento do título
+			layouttest.Number(86, 100, 150075),       // valor nominal do título
+			layouttest.Number(101, 105, 0),           // agência encarregada da cobrança
+			layouttest.Text(106, 106, " "),           // dígito verificador da agência
+			layouttest.Number(107, 108, 2),           // espécie do título
+			layouttest.Text(109, 109, "N"),           // identificação de título aceito/não aceito
+			layouttest.Text(110, 117, "20102026"),    // data da emissão do título
+			layouttest.Number(118, 118, 1),           // código do juros de mora
+			layouttest.Text(119, 126, "11112026"),    // data do juros de mora
+			layouttest.Number(127, 141, 50),          // juros de mora por dia/taxa
+			layouttest.Number(142, 142, 0),           // código do desconto 1
+			layouttest.Text(143, 150, "00000000"),    // data do desconto 1
+			layouttest.Number(151, 165, 0),           // valor/percentual a ser concedido
+			layouttest.Number(166, 180, 0),           // valor do IOF a ser recolhido
+			layouttest.Number(181, 195, 1000),        // valor do abatimento
+			layouttest.Text(196, 220, "PEDIDO 1001"), // identificação do título na empresa
+			layouttest.Number(221, 221, 1),           // código para protesto
+			layouttest.Number(222, 223, 5),           // número de dias para protesto
+			layouttest.Number(224, 224, 1),           // código para baixa/devolução
+			layouttest.Number(225, 227, 30),          // número de dias para baixa/devolução
+			layouttest.Number(228, 229, 9),           // código da moeda
+			layouttest.Number(230, 239, 0),           // nº do contrato da operação de crédito
+		)
+	}
+
+	segmentQ := func(t *testing.T) []byte {
+		return layouttest.SpecLine(t, cnab240.LineSize,
+			layouttest.Number(1, 3, 341),                  // código do banco
+			layouttest.Number(4, 7, 1),                    // lote de serviço
+			layouttest.Text(8, 8, "3"),                    // tipo de registro
+			layouttest.Number(9, 13, 2),                   // nº sequencial do registro no lote
+			layouttest.Text(14, 14, "Q"),                  // código do segmento
+			layouttest.Number(16, 17, 1),                  // código de movimento remessa
+			layouttest.Number(18, 18, 1),                  // tipo de inscrição do pagador
+			layouttest.Number(19, 33, 12345678909),        // número de inscrição do pagador
+			layouttest.Text(34, 73, "FULANO DE TAL"),      // nome do pagador
+			layouttest.Text(74, 113, "RUA EXEMPLO 100"),   // endereço do pagador
+			layouttest.Text(114, 128, "CENTRO"),           // bairro do pagador
+			layouttest.Number(129, 133, 1310),             // CEP do pagador
+			layouttest.Number(134, 136, 100),              // sufixo do CEP do pagador
+			layouttest.Text(137, 151, "SAO PAULO"),        // cidade do pagador
+			layouttest.Text(152, 153, "SP"),               // unidade da federação do pagador
+			layouttest.Number(154, 154, 2),                // tipo de inscrição do sacador/avalista
+			layouttest.Number(155, 169, 98765432000198),   // número de inscrição do sacador/avalista
+			layouttest.Text(170, 209, "AVALISTA EXEMPLO"), // nome do sacador/avalista
+			layouttest.Number(210, 212, 0),                // código do banco correspondente
+			layouttest.Text(213, 232, ""),                 // nosso nº no banco correspondente
+		)
+	}
+
+	segmentT := func(t *testing.T) []byte {
+		return layouttest.SpecLine(t, cnab240.LineSize,
+			layouttest.Number(1, 3, 341),               // código do banco
+			layouttest.Number(4, 7, 1),                 // lote de serviço
+			layouttest.Text(8, 8, "3"),                 // tipo de registro
+			layouttest.Number(9, 13, 1),                // nº sequencial do registro no lote
+			layouttest.Text(14, 14, "T"),               // código do segmento
+			layouttest.Number(16, 17, 6),               // código de movimento retorno
+			layouttest.Number(18, 22, 1234),            // agência mantenedora da conta
+			layouttest.Text(23, 23, "5"),               // dígito verificador da agência
+			layouttest.Number(24, 35, 67890),           // número da conta corrente
+			layouttest.Text(36, 36, "1"),               // dígito verificador da conta
+			layouttest.Text(37, 37, " "),               // dígito verificador da agência/conta
+			layouttest.Text(38, 57, "00000000001"),     // identificação do título no banco
+			layouttest.Number(58, 58, 1),               // código da carteira
+			layouttest.Text(59, 73, "NF-1001"),         // número do documento de cobrança
+			layouttest.Text(74, 81, "10112026"),        // data do vencimento do título
+			layouttest.Number(82, 96, 150075),          // valor nominal do título
+			layouttest.Number(97, 99, 341),             // número do banco cobrador/recebedor
+			layouttest.Number(100, 104, 4321),          // agência cobradora/recebedora
+			layouttest.Text(105, 105, "0"),             // dígito verificador da agência
+			layouttest.Text(106, 130, "PEDIDO 1001"),   // identificação do título na empresa
+			layouttest.Number(131, 132, 9),             // código da moeda
+			layouttest.Number(133, 133, 1),             // tipo de inscrição do pagador
+			layouttest.Number(134, 148, 12345678909),   // número de inscrição do pagador
+			layouttest.Text(149, 188, "FULANO DE TAL"), // nome do pagador
+			layouttest.Number(189, 198, 0),             // nº do contrato da operação de crédito
+			layouttest.Number(199, 213, 250),           // valor da tarifa/custas
+			layouttest.Text(214, 223, "A1B2"),          // motivo da ocorrência
+		)
+	}
+
+	segmentU := func(t *testing.T) []byte {
+		return layouttest.SpecLine(t, cnab240.LineSize,
+			layouttest.Number(1, 3, 341),          // código do banco
+			layouttest.Number(4, 7, 1),            // lote de serviço
+			layouttest.Text(8, 8, "3"),            // tipo de registro
+			layouttest.Number(9, 13, 2),           // nº sequencial do registro no lote
+			layouttest.Text(14, 14, "U"),          // código do segmento
+			layouttest.Number(16, 17, 6),          // código de movimento retorno
+			layouttest.Number(18, 32, 300),        // juros/multa/encargos
+			layouttest.Number(33, 47, 100),        // valor do desconto concedido
+			layouttest.Number(48, 62, 200),        // valor do abatimento concedido/cancelado
+			layouttest.Number(63, 77, 0),          // valor do IOF recolhido
+			layouttest.Number(78, 92, 150375),     // valor pago pelo pagador
+			layouttest.Number(93, 107, 150125),    // valor líquido a ser creditado
+			layouttest.Number(108, 122, 400),      // valor de outras despesas
+			layouttest.Number(123, 137, 0),        // valor de outros créditos
+			layouttest.Text(138, 145, "12112026"), // data da ocorrência
+			layouttest.Text(146, 153, "13112026"), // data da efetivação do crédito
+			layouttest.Text(154, 157, ""),         // código da ocorrência do pagador
+			layouttest.Text(158, 165, "00000000"), // data da ocorrência do pagador
+			layouttest.Number(166, 180, 0),        // valor da ocorrência do pagador
+			layouttest.Text(181, 210, ""),         // complemento da ocorrência do pagador
+			layouttest.Number(211, 213, 0),        // código do banco correspondente
+			layouttest.Text(214, 233, ""),         // nosso nº no banco correspondente
+		)
+	}
+
+	scenarios := []struct {
+		description string
+		lines       func(t *testing.T) [][]byte
+		expected    interface{}
+	}{
+		{
+			description: "it should decode the segments P and Q",
+			lines: func(t *testing.T) [][]byte {
+				return [][]byte{segmentP(t), segmentQ(t)}
+			},
+			expected: febraban240.Boleto{
+				P: febraban240.SegmentP{
+					Bank:               341,
+					Batch:              1,
+					Sequence:           1,
+					Movement:           febraban240.InstructionEntry,
+					Agency:             1234,
+					AgencyDigit:        "5",
+					Account:            67890,
+					AccountDigit:       "1",
+					AgencyAccountDigit: "2",
+					OurNumber:          "00000000001",
+					Wallet:             1,
+					RegistrationType:   1,
+					DocumentKind:       1,
+					IssuanceType:       2,
+					DistributionType:   "2",
+					DocumentNumber:     "NF-1001",
+					DueDate:            cnab240.NewDate(2026, 11, 10),
+					Amount:             1500.75,
+					TitleKind:          2,
+					Acceptance:         "N",
+					IssueDate:          cnab240.NewDate(2026, 10, 20),
+					InterestCode:       1,
+					InterestDate:       cnab240.NewDate(2026, 11, 11),
+					Interest:           0.5,
+					Rebate:             10,
+					CompanyTitleID:     "PEDIDO 1001",
+					ProtestCode:        1,
+					ProtestDays:        5,
+					WriteOffCode:       1,
+					WriteOffDays:       30,
+					Currency:           9,
+				},
+				Q: febraban240.SegmentQ{
+					Bank:                  341,
+					Batch:                 1,
+					Sequence:              2,
+					Movement:              febraban240.InstructionEntry,
+					PayerDocumentType:     febraban240.DocumentTypeCPF,
+					PayerDocument:         12345678909,
+					PayerName:             "FULANO DE TAL",
+					PayerAddress:          "RUA EXEMPLO 100",
+					PayerDistrict:         "CENTRO",
+					PayerZipCode:          1310,
+					PayerZipCodeSuffix:    100,
+					PayerCity:             "SAO PAULO",
+					PayerState:            "SP",
+					GuarantorDocumentType: febraban240.DocumentTypeCNPJ,
+					GuarantorDocument:     98765432000198,
+					GuarantorName:         "AVALISTA EXEMPLO",
+				},
+			},
+		},
+		{
+			description: "it should decode the segments T and U",
+			lines: func(t *testing.T) [][]byte {
+				return [][]byte{segmentT(t), segmentU(t)}
+			},
+			expected: febraban240.BoletoReturn{
+				T: febraban240.SegmentT{
+					Bank:                  341,
+					Batch:                 1,
+					Sequence:              1,
+					Occurrence:            febraban240.OccurrenceSettlement,
+					Agency:                1234,
+					AgencyDigit:           "5",
+					Account:               67890,
+					AccountDigit:          "1",
+					OurNumber:             "00000000001",
+					Wallet:                1,
+					DocumentNumber:        "NF-1001",
+					DueDate:               cnab240.NewDate(2026, 11, 10),
+					Amount:                1500.75,
+					CollectingBank:        341,
+					CollectingAgency:      4321,
+					CollectingAgencyDigit: "0",
+					CompanyTitleID:        "PEDIDO 1001",
+					Currency:              9,
+					PayerDocumentType:     febraban240.DocumentTypeCPF,
+					PayerDocument:         12345678909,
+					PayerName:             "FULANO DE TAL",
+					Fee:                   2.5,
+					OccurrenceReasons:     "A1B2",
+				},
+				U: febraban240.SegmentU{
+					Bank:           341,
+					Batch:          1,
+					Sequence:       2,
+					Occurrence:     febraban240.OccurrenceSettlement,
+					Charges:        3,
+					Discount:       1,
+					Rebate:         2,
+					PaidAmount:     1503.75,
+					NetAmount:      1501.25,
+					OtherExpenses:  4,
+					OccurrenceDate: cnab240.NewDate(2026, 11, 12),
+					CreditDate:     cnab240.NewDate(2026, 11, 13),
+				},
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			data := bytes.Join(scenario.lines(t), []byte(gocnab.LineBreak))
+
+			decoded := reflect.New(reflect.TypeOf(scenario.expected))
+			if err := gocnab.Unmarshal(data, decoded.Interface()); err != nil {
+				t.Fatalf("error unmarshalling. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(scenario.expected, decoded.Elem().Interface()) {
+				t.Errorf("expected record “%#v” and got “%#v”", scenario.expected, decoded.Elem().Interface())
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package febraban240
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+)
+
+// ErrNotStatementBatch raised when the records of a batch aren't the ones of a
+// bank statement batch.
+var ErrNotStatementBatch = errors.New("febraban240: batch isn't a bank statement batch")
+
+// ErrBalanceMismatch raised when the transactions of a statement batch don't
+// lead from its initial balance to the final balance of the batch trailer, or
+// don't match the debit and credit totals of the trailer.
+var ErrBalanceMismatch = errors.New("febraban240: statement transactions don't match the batch trailer")
+
+// ExtratoBatchHeader is the header of a bank statement batch for
+// reconciliation (extrato para conciliação bancária, registro 1), with the
+// initial balance of the account. The operation (E), service (04) and
+// entry form (40) of the statement batches are constants.
+type ExtratoBatchHeader struct {
+	Bank                   int             `cnab:"0,3"`
+	Batch                  int             `cnab:"3,7"`
+	_                      string          `cnab:"7,8,const=1"`
+	_                      string          `cnab:"8,9,const=E"`
+	_                      string          `cnab:"9,11,const=04"`
+	_                      string          `cnab:"11,13,const=40"`
+	LayoutVersion          string          `cnab:"13,16"`
+	_                      string          `cnab:"16,17"`
+	CompanyDocumentType    DocumentType    `cnab:"17,18"`
+	CompanyDocument        int64           `cnab:"18,32"`
+	Agreement              string          `cnab:"32,52"`
+	Agency                 int             `cnab:"52,57"`
+	AgencyDigit            string          `cnab:"57,58"`
+	Account                int64           `cnab:"58,70"`
+	AccountDigit           string          `cnab:"70,71"`
+	AgencyAccountDigit     string          `cnab:"71,72"`
+	CompanyName            string          `cnab:"72,102"`
+	_                      string          `cnab:"102,142"`
+	InitialBalanceDate     cnab240.Date    `cnab:"142,150"`
+	InitialBalance         float64         `cnab:"150,168"`
+	InitialBalanceStatus   BalanceStatus   `cnab:"168,169"`
+	InitialBalancePosition BalancePosition `cnab:"169,170"`
+	Currency               string          `cnab:"170,173"`
+	StatementSequence      int             `cnab:"173,178"`
+	_                      string          `cnab:"178,240"`
+}
+
+// ExtratoBatchTrailer is the trailer of a bank statement batch (registro 5),
+// with the final balance of the account and the totals of the debits and
+// credits of the batch. The number of records is stored in a different
+// position of the other batch trailers, and it is filled by cnab240.Marshal.
+type ExtratoBatchTrailer struct {
+	Bank                 int             `cnab:"0,3"`
+	Batch                int             `cnab:"3,7"`
+	_                    string          `cnab:"7,8,const=5"`
+	_                    string          `cnab:"8,17"`
+	CompanyDocumentType  DocumentType    `cnab:"17,18"`
+	CompanyDocument      int64           `cnab:"18,32"`
+	Agreement            string          `cnab:"32,52"`
+	Agency               int             `cnab:"52,57"`
+	AgencyDigit          string          `cnab:"57,58"`
+	Account              int64           `cnab:"58,70"`
+	AccountDigit         string          `cnab:"70,71"`
+	AgencyAccountDigit   string          `cnab:"71,72"`
+	_                    string          `cnab:"72,88"`
+	LinkedBalance        float64         `cnab:"88,106"`
+	Limit                float64         `cnab:"106,124"`
+	BlockedBalance       float64         `cnab:"124,142"`
+	FinalBalanceDate     cnab240.Date    `cnab:"142,150"`
+	FinalBalance         float64         `cnab:"150,168"`
+	FinalBalanceStatus   BalanceStatus   `cnab:"168,169"`
+	FinalBalancePosition BalancePosition `cnab:"169,170"`
+	Records              int             `cnab:"170,176"`
+	TotalDebits          float64         `cnab:"176,194"`
+	TotalCredits         float64         `cnab:"194,212"`
+	_                    string          `cnab:"212,240"`
+}
+
+// BatchRecordsPosition returns the position of the number of records of the
+// batch, used by cnab240.Marshal and cnab240.Unmarshal.
+func (ExtratoBatchTrailer) BatchRecordsPosition() (begin, end int) {
+	return 170, 176
+}
+
+// SegmentE contains a transaction of the account (segmento E), a debit or a
+// credit, with its category and the description (histórico) of the bank.
+type SegmentE struct {
+	Bank                int           `cnab:"0,3"`
+	Batch               int           `cnab:"3,7"`
+	_                   string        `cnab:"7,8,const=3"`
+	Sequence            int           `cnab:"8,13"`
+	_                   string        `cnab:"13,14,const=E"`
+	_                   string        `cnab:"14,17"`
+	CompanyDocumentType DocumentType  `cnab:"17,18"`
+	CompanyDocument     int64         `cnab:"18,32"`
+	Agreement           string        `cnab:"32,52"`
+	Agency              int           `cnab:"52,57"`
+	AgencyDigit         string        `cnab:"57,58"`
+	Account             int64         `cnab:"58,70"`
+	AccountDigit        string        `cnab:"70,71"`
+	AgencyAccountDigit  string        `cnab:"71,72"`
+	CompanyName         string        `cnab:"72,102"`
+	_                   string        `cnab:"102,108"`
+	Nature              EntryNature   `cnab:"108,111"`
+	ComplementType      string        `cnab:"111,113"`
+	Complement          string        `cnab:"113,133"`
+	CPMFExemption       string        `cnab:"133,134"`
+	AccountingDate      cnab240.Date  `cnab:"134,142"`
+	EntryDate           cnab240.Date  `cnab:"142,150"`
+	Amount              float64       `cnab:"150,168"`
+	Type                EntryType     `cnab:"168,169,enum=D|C"`
+	Category            EntryCategory `cnab:"169,172"`
+	HistoryCode         string        `cnab:"172,176"`
+	History             string        `cnab:"176,201"`
+	DocumentNumber      string        `cnab:"201,240"`
+}
+
+// SignedAmount returns the amount of the transaction, negative for debits.
+func (s SegmentE) SignedAmount() float64 {
+	if s.Type == EntryTypeDebit {
+		return -s.Amount
+	}
+	return s.Amount
+}
+
+// NewExtratoMapper returns a mapper with the bank statement record types, to
+// be used with cnab240.Unmarshal.
+func NewExtratoMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*FileHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileHeader))
+	mapper.Register((*ExtratoBatchHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchHeader))
+	mapper.Register((*SegmentE)(nil), cnab240.MatchSegment("E"))
+	mapper.Register((*ExtratoBatchTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchTrailer))
+	mapper.Register((*FileTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileTrailer))
+	return mapper
+}
+
+// Statement is the bank statement of an account, built from a statement batch
+// by NewStatement. The balances are negative when the account is overdrawn.
+type Statement struct {
+	InitialBalanceDate cnab240.Date
+	InitialBalance     float64
+	FinalBalanceDate   cnab240.Date
+	FinalBalance       float64
+	Transactions       []Transaction
+}
+
+// Transaction is a transaction of the statement, with the balance of the
+// account right after it.
+type Transaction struct {
+	SegmentE
+	Balance float64
+}
+
+// NewStatement returns the transactions of a statement batch in the order of
+// the file, with the running balance of the account. The final balance and
+// the debit and credit totals of the batch trailer are checked against the
+// transactions, and ErrBalanceMismatch is returned when they differ.
+func NewStatement(batch cnab240.Batch) (Statement, error) {
+	header, ok := batch.Header.(ExtratoBatchHeader)
+	if !ok {
+		return Statement{}, fmt.Errorf("%w: unknown batch header %T", ErrNotStatementBatch, batch.Header)
+	}
+
+	trailer, ok := batch.Trailer.(ExtratoBatchTrailer)
+	if !ok {
+		return Statement{}, fmt.Errorf("%w: unknown batch trailer %T", ErrNotStatementBatch, batch.Trailer)
+	}
+
+	statement := Statement{
+		InitialBalanceDate: header.InitialBalanceDate,
+		InitialBalance:     header.InitialBalanceStatus.sign(header.InitialBalance),
+		FinalBalanceDate:   trailer.FinalBalanceDate,
+		FinalBalance:       trailer.FinalBalanceStatus.sign(trailer.FinalBalance),
+	}
+
+	// the amounts are summed in cents to avoid rounding errors
+	balance := cents(statement.InitialBalance)
+	var debits, credits int64
+
+	for i, segment := range batch.Segments {
+		entry, ok := segment.(SegmentE)
+		if !ok {
+			return Statement{}, fmt.Errorf("%w: segment %d (%T)", ErrNotStatementBatch, i+1, segment)
+		}
+
+		amount := cents(entry.Amount)
+		if entry.Type == EntryTypeDebit {
+			debits += amount
+			balance -= amount
+		} else {
+			credits += amount
+			balance += amount
+		}
+
+		statement.Transactions = append(statement.Transactions, Transaction{
+			SegmentE: entry,
+			Balance:  float64(balance) / 100,
+		})
+	}
+
+	switch {
+	case balance != cents(statement.FinalBalance):
+		return Statement{}, fmt.Errorf("%w: final balance %.2f, calculated %.2f", ErrBalanceMismatch, statement.FinalBalance, float64(balance)/100)
+	case debits != cents(trailer.TotalDebits):
+		return Statement{}, fmt.Errorf("%w: total debits %.2f, calculated %.2f", ErrBalanceMismatch, trailer.TotalDebits, float64(debits)/100)
+	case credits != cents(trailer.TotalCredits):
+		return Statement{}, fmt.Errorf("%w: total credits %.2f, calculated %.2f", ErrBalanceMismatch, trailer.TotalCredits, float64(credits)/100)
+	}
+
+	return statement, nil
+}
+
+// cents converts an amount to cents, the precision of the CNAB amounts.
+func cents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
@@ -0,0 +1,151 @@
+package febraban240
+
+// BalanceStatus informs if a balance of the statement is positive (credor)
+// or negative (devedor).
+type BalanceStatus string
+
+// List of balance statuses.
+const (
+	BalanceStatusDebit  BalanceStatus = "D"
+	BalanceStatusCredit BalanceStatus = "C"
+)
+
+// sign returns the balance negative when it is a debit balance.
+func (b BalanceStatus) sign(balance float64) float64 {
+	if b == BalanceStatusDebit {
+		return -balance
+	}
+	return balance
+}
+
+// BalancePosition informs when the balance of the statement was taken.
+type BalancePosition string
+
+// List of balance positions.
+const (
+	BalancePositionFinal    BalancePosition = "F"
+	BalancePositionPartial  BalancePosition = "P"
+	BalancePositionIntraday BalancePosition = "I"
+)
+
+// EntryType informs if a transaction of the statement is a debit or a credit.
+type EntryType string
+
+// List of entry types.
+const (
+	EntryTypeDebit  EntryType = "D"
+	EntryTypeCredit EntryType = "C"
+)
+
+// EntryNature is the nature of a transaction (natureza do lançamento),
+// informing the balance affected by it.
+type EntryNature string
+
+// List of entry natures defined by FEBRABAN.
+const (
+	EntryNatureAvailable  EntryNature = "DPV"
+	EntryNatureLinked     EntryNature = "SCR"
+	EntryNatureBlocked    EntryNature = "SSR"
+	EntryNatureCPMFExempt EntryNature = "CPV"
+)
+
+var entryNatureDescriptions = map[EntryNature]string{
+	EntryNatureAvailable:  "Disponível",
+	EntryNatureLinked:     "Vinculado",
+	EntryNatureBlocked:    "Bloqueado",
+	EntryNatureCPMFExempt: "Disponível isento de CPMF",
+}
+
+// Description returns the description of the entry nature defined by
+// FEBRABAN, or an empty string for unknown codes.
+func (e EntryNature) Description() string {
+	return entryNatureDescriptions[e]
+}
+
+// EntryCategory is the category of a transaction (categoria do lançamento).
+// The categories starting with 1 are debits and the ones starting with 2 are
+// credits.
+type EntryCategory string
+
+// List of entry categories defined by FEBRABAN.
+const (
+	CategoryCheques                   EntryCategory = "101"
+	CategoryCharges                   EntryCategory = "102"
+	CategoryDebitReversal             EntryCategory = "103"
+	CategoryNotifiedDebit             EntryCategory = "104"
+	CategoryFees                      EntryCategory = "105"
+	CategoryInvestment                EntryCategory = "106"
+	CategoryLoanDebit                 EntryCategory = "107"
+	CategoryExchangeDebit             EntryCategory = "108"
+	CategoryCPMF                      EntryCategory = "109"
+	CategoryIOF                       EntryCategory = "110"
+	CategoryIncomeTax                 EntryCategory = "111"
+	CategorySupplierPayment           EntryCategory = "112"
+	CategorySalaryPayment             EntryCategory = "113"
+	CategoryWithdrawal                EntryCategory = "114"
+	CategoryStocksDebit               EntryCategory = "115"
+	CategoryTransferBetweenAccounts   EntryCategory = "117"
+	CategoryClearingReturn            EntryCategory = "118"
+	CategoryDepositedChequeReturn     EntryCategory = "119"
+	CategoryInterbankTransferDebit    EntryCategory = "120"
+	CategorySupplierAdvance           EntryCategory = "121"
+	CategoryDeposits                  EntryCategory = "201"
+	CategoryCollection                EntryCategory = "202"
+	CategoryChequeReturn              EntryCategory = "203"
+	CategoryCreditReversal            EntryCategory = "204"
+	CategoryNotifiedCredit            EntryCategory = "205"
+	CategoryInvestmentRedemption      EntryCategory = "206"
+	CategoryLoanCredit                EntryCategory = "207"
+	CategoryExchangeCredit            EntryCategory = "208"
+	CategoryInterbankTransferCredit   EntryCategory = "209"
+	CategoryStocksCredit              EntryCategory = "210"
+	CategoryDividends                 EntryCategory = "211"
+	CategoryInsurance                 EntryCategory = "212"
+	CategoryTransferBetweenAccountsIn EntryCategory = "213"
+	CategorySpecialDeposits           EntryCategory = "214"
+	CategoryClearingReturnCredit      EntryCategory = "215"
+)
+
+var entryCategoryDescriptions = map[EntryCategory]string{
+	CategoryCheques:                   "Cheques",
+	CategoryCharges:                   "Encargos",
+	CategoryDebitReversal:             "Estornos",
+	CategoryNotifiedDebit:             "Lançamento avisado",
+	CategoryFees:                      "Tarifas",
+	CategoryInvestment:                "Aplicação",
+	CategoryLoanDebit:                 "Empréstimo / Financiamento",
+	CategoryExchangeDebit:             "Câmbio",
+	CategoryCPMF:                      "CPMF",
+	CategoryIOF:                       "IOF",
+	CategoryIncomeTax:                 "Imposto de renda",
+	CategorySupplierPayment:           "Pagamento fornecedores",
+	CategorySalaryPayment:             "Pagamento salários",
+	CategoryWithdrawal:                "Saque eletrônico",
+	CategoryStocksDebit:               "Ações",
+	CategoryTransferBetweenAccounts:   "Transferência entre contas",
+	CategoryClearingReturn:            "Devolução da compensação",
+	CategoryDepositedChequeReturn:     "Devolução de cheque depositado",
+	CategoryInterbankTransferDebit:    "Transferência interbancária (DOC, TED)",
+	CategorySupplierAdvance:           "Antecipação a fornecedores",
+	CategoryDeposits:                  "Depósitos",
+	CategoryCollection:                "Líquido de cobrança",
+	CategoryChequeReturn:              "Devolução de cheques",
+	CategoryCreditReversal:            "Estornos",
+	CategoryNotifiedCredit:            "Lançamento avisado",
+	CategoryInvestmentRedemption:      "Resgate de aplicação",
+	CategoryLoanCredit:                "Empréstimo / Financiamento",
+	CategoryExchangeCredit:            "Câmbio",
+	CategoryInterbankTransferCredit:   "Transferência interbancária (DOC, TED)",
+	CategoryStocksCredit:              "Ações",
+	CategoryDividends:                 "Dividendos",
+	CategoryInsurance:                 "Seguro",
+	CategoryTransferBetweenAccountsIn: "Transferência entre contas",
+	CategorySpecialDeposits:           "Depósitos especiais",
+	CategoryClearingReturnCredit:      "Devolução da compensação",
+}
+
+// Description returns the description of the entry category defined by
+// FEBRABAN, or an empty string for unknown codes.
+func (e EntryCategory) Description() string {
+	return entryCategoryDescriptions[e]
+}
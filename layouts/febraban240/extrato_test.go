@@ -0,0 +1,229 @@
+package febraban240_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+)
+
+func extratoEntry(sequence int, entryType febraban240.EntryType, category febraban240.EntryCategory, amount float64, history string) febraban240.SegmentE {
+	return febraban240.SegmentE{
+		Bank:                341,
+		Batch:               1,
+		Sequence:            sequence,
+		CompanyDocumentType: febraban240.DocumentTypeCNPJ,
+		CompanyDocument:     12345678000195,
+		Agreement:           "000000000000123456",
+		Agency:              1234,
+		AgencyDigit:         "5",
+		Account:             67890,
+		AccountDigit:        "1",
+		CompanyName:         "EMPRESA EXEMPLO LTDA",
+		Nature:              febraban240.EntryNatureAvailable,
+		CPMFExemption:       "S",
+		AccountingDate:      cnab240.NewDate(2026, 10, 16),
+		EntryDate:           cnab240.NewDate(2026, 10, 16),
+		Amount:              amount,
+		Type:                entryType,
+		Category:            category,
+		HistoryCode:         "0042",
+		History:             history,
+		DocumentNumber:      "000123",
+	}
+}
+
+func extratoBatch() cnab240.Batch {
+	return cnab240.Batch{
+		Header: febraban240.ExtratoBatchHeader{
+			Bank:                   341,
+			Batch:                  1,
+			LayoutVersion:          "033",
+			CompanyDocumentType:    febraban240.DocumentTypeCNPJ,
+			CompanyDocument:        12345678000195,
+			Agreement:              "000000000000123456",
+			Agency:                 1234,
+			AgencyDigit:            "5",
+			Account:                67890,
+			AccountDigit:           "1",
+			CompanyName:            "EMPRESA EXEMPLO LTDA",
+			InitialBalanceDate:     cnab240.NewDate(2026, 10, 15),
+			InitialBalance:         100,
+			InitialBalanceStatus:   febraban240.BalanceStatusCredit,
+			InitialBalancePosition: febraban240.BalancePositionFinal,
+			Currency:               "BRL",
+			StatementSequence:      7,
+		},
+		Segments: []cnab240.Segment{
+			extratoEntry(1, febraban240.EntryTypeCredit, febraban240.CategoryCollection, 1500.75, "LIQUIDACAO COBRANCA"),
+			extratoEntry(2, febraban240.EntryTypeDebit, febraban240.CategorySupplierPayment, 1650.5, "PAGTO FORNECEDOR"),
+			extratoEntry(3, febraban240.EntryTypeDebit, febraban240.CategoryFees, 12.3, "TARIFA BANCARIA"),
+		},
+		Trailer: febraban240.ExtratoBatchTrailer{
+			Bank:                 341,
+			Batch:                1,
+			CompanyDocumentType:  febraban240.DocumentTypeCNPJ,
+			CompanyDocument:      12345678000195,
+			Agreement:            "000000000000123456",
+			Agency:               1234,
+			AgencyDigit:          "5",
+			Account:              67890,
+			AccountDigit:         "1",
+			Limit:                5000,
+			FinalBalanceDate:     cnab240.NewDate(2026, 10, 16),
+			FinalBalance:         62.05,
+			FinalBalanceStatus:   febraban240.BalanceStatusDebit,
+			FinalBalancePosition: febraban240.BalancePositionFinal,
+			Records:              5,
+			TotalDebits:          1662.8,
+			TotalCredits:         1500.75,
+		},
+	}
+}
+
+func TestExtrato(t *testing.T) {
+	t.Parallel()
+
+	file := cnab240.File{
+		Header:  cobrancaFileHeader(febraban240.FileCodeReturn),
+		Batches: []cnab240.Batch{extratoBatch()},
+		Trailer: febraban240.FileTrailer{
+			Bank:    341,
+			Batches: 1,
+			Records: 7,
+		},
+	}
+
+	data, err := cnab240.Marshal(layouttest.WithoutFields(file, layouttest.CNAB240ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "extrato.golden", data)
+
+	decoded, err := cnab240.Unmarshal(golden, febraban240.NewExtratoMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Fatalf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+
+	statement, err := febraban240.NewStatement(decoded.Batches[0])
+	if err != nil {
+		t.Fatalf("unexpected error. details: %s", err)
+	}
+
+	if statement.InitialBalance != 100 || statement.FinalBalance != -62.05 {
+		t.Errorf("unexpected balances %.2f and %.2f", statement.InitialBalance, statement.FinalBalance)
+	}
+
+	var balances []float64
+	for _, transaction := range statement.Transactions {
+		balances = append(balances, transaction.Balance)
+	}
+
+	if expected := []float64{1600.75, -49.75, -62.05}; !reflect.DeepEqual(expected, balances) {
+		t.Errorf("expected balances “%v” and got “%v”", expected, balances)
+	}
+
+	if description := statement.Transactions[2].Category.Description(); description != "Tarifas" {
+		t.Errorf("unexpected category description “%s”", description)
+	}
+}
+
+func TestNewStatement(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description   string
+		batch         func() cnab240.Batch
+		expectedError error
+	}{
+		{
+			description: "it should accept a statement matching the trailer",
+			batch:       extratoBatch,
+		},
+		{
+			description: "it should accept a statement without transactions",
+			batch: func() cnab240.Batch {
+				batch := extratoBatch()
+				batch.Segments = nil
+
+				trailer := batch.Trailer.(febraban240.ExtratoBatchTrailer)
+				trailer.FinalBalance = 100
+				trailer.FinalBalanceStatus = febraban240.BalanceStatusCredit
+				trailer.TotalDebits = 0
+				trailer.TotalCredits = 0
+				batch.Trailer = trailer
+				return batch
+			},
+		},
+		{
+			description: "it should detect a wrong final balance",
+			batch: func() cnab240.Batch {
+				batch := extratoBatch()
+				trailer := batch.Trailer.(febraban240.ExtratoBatchTrailer)
+				trailer.FinalBalanceStatus = febraban240.BalanceStatusCredit
+				batch.Trailer = trailer
+				return batch
+			},
+			expectedError: febraban240.ErrBalanceMismatch,
+		},
+		{
+			description: "it should detect a wrong debit total",
+			batch: func() cnab240.Batch {
+				batch := extratoBatch()
+				trailer := batch.Trailer.(febraban240.ExtratoBatchTrailer)
+				trailer.TotalDebits = 1650.5
+				batch.Trailer = trailer
+				return batch
+			},
+			expectedError: febraban240.ErrBalanceMismatch,
+		},
+		{
+			description: "it should detect a wrong credit total",
+			batch: func() cnab240.Batch {
+				batch := extratoBatch()
+				trailer := batch.Trailer.(febraban240.ExtratoBatchTrailer)
+				trailer.TotalCredits = 1500
+				batch.Trailer = trailer
+				return batch
+			},
+			expectedError: febraban240.ErrBalanceMismatch,
+		},
+		{
+			description: "it should detect a segment that isn't a transaction",
+			batch: func() cnab240.Batch {
+				batch := extratoBatch()
+				batch.Segments = append(batch.Segments, febraban240.SegmentZ{})
+				return batch
+			},
+			expectedError: febraban240.ErrNotStatementBatch,
+		},
+		{
+			description: "it should detect a batch that isn't a statement batch",
+			batch: func() cnab240.Batch {
+				return cnab240.Batch{
+					Header:  febraban240.CobrancaBatchHeader{},
+					Trailer: febraban240.CobrancaBatchTrailer{},
+				}
+			},
+			expectedError: febraban240.ErrNotStatementBatch,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			_, err := febraban240.NewStatement(scenario.batch())
+
+			if !errors.Is(err, scenario.expectedError) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Package febraban240 contains the record types of the CNAB 240 layouts
+// defined by FEBRABAN (version 10), ready to be used with the cnab240
+// package. The cobrança batches (service 01), the payment batches
+// (pagamentos) and the bank statement batches (extrato para conciliação
+// bancária, service 04) are supported.
+//
+// The control fields shared by all CNAB 240 records (batch number, record type,
+// sequence number and the trailer counters) are filled by cnab240.Marshal, so
+// they don't need to be filled. The constant fields of each record (like the
+// segment code) are declared as blank fields with the const option, and the
+// positions reserved for FEBRABAN (CNAB) are blank fields that are always
+// written with spaces.
+//
+//	file := cnab240.File{
+//	  Header: febraban240.FileHeader{...},
+//	  Batches: []cnab240.Batch{
+//	    {
+//	      Header: febraban240.CobrancaBatchHeader{...},
+//	      Segments: []cnab240.Segment{
+//	        febraban240.Boleto{P: febraban240.SegmentP{...}, Q: febraban240.SegmentQ{...}},
+//	      },
+//	      Trailer: febraban240.CobrancaBatchTrailer{...},
+//	    },
+//	  },
+//	  Trailer: febraban240.FileTrailer{...},
+//	}
+//
+//	data, err := cnab240.Marshal(file)
+package febraban240
+
+import (
+	"github.com/rafaeljusto/gocnab/cnab240"
+)
+
+// DocumentType identifies the type of the document (inscrição) of a company or
+// person.
+type DocumentType int
+
+// List of document types.
+const (
+	DocumentTypeNone DocumentType = 0
+	DocumentTypeCPF  DocumentType = 1
+	DocumentTypeCNPJ DocumentType = 2
+)
+
+// FileCode identifies the direction of the file.
+type FileCode int
+
+// List of file codes.
+const (
+	FileCodeRemittance FileCode = 1
+	FileCodeReturn     FileCode = 2
+)
+
+// Operation types of the batch header.
+const (
+	OperationRemittance = "R"
+	OperationReturn     = "T"
+)
+
+// FileHeader is the header of a CNAB 240 file (registro 0), shared by all
+// services.
+type FileHeader struct {
+	Bank                int          `cnab:"0,3"`
+	_                   string       `cnab:"3,7,const=0000"`
+	_                   string       `cnab:"7,8,const=0"`
+	_                   string       `cnab:"8,17"`
+	CompanyDocumentType DocumentType `cnab:"17,18"`
+	CompanyDocument     int64        `cnab:"18,32"`
+	Agreement           string       `cnab:"32,52"`
+	Agency              int          `cnab:"52,57"`
+	AgencyDigit         string       `cnab:"57,58"`
+	Account             int64        `cnab:"58,70"`
+	AccountDigit        string       `cnab:"70,71"`
+	AgencyAccountDigit  string       `cnab:"71,72"`
+	CompanyName         string       `cnab:"72,102"`
+	BankName            string       `cnab:"102,132"`
+	_                   string       `cnab:"132,142"`
+	FileCode            FileCode     `cnab:"142,143"`
+	GenerationDate      cnab240.Date `cnab:"143,151"`
+	GenerationTime      cnab240.Time `cnab:"151,157"`
+	FileSequence        int          `cnab:"157,163"`
+	LayoutVersion       string       `cnab:"163,166"`
+	Density             int          `cnab:"166,171"`
+	BankReserved        string       `cnab:"171,191"`
+	CompanyReserved     string       `cnab:"191,211"`
+	_                   string       `cnab:"211,240"`
+}
+
+// FileTrailer is the trailer of a CNAB 240 file (registro 9), shared by all
+// services. The number of batches and records are filled by cnab240.Marshal.
+type FileTrailer struct {
+	Bank     int    `cnab:"0,3"`
+	_        string `cnab:"3,7,const=9999"`
+	_        string `cnab:"7,8,const=9"`
+	_        string `cnab:"8,17"`
+	Batches  int    `cnab:"17,23"`
+	Records  int    `cnab:"23,29"`
+	Accounts int    `cnab:"29,35"`
+	_        string `cnab:"35,240"`
+}
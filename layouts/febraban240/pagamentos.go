@@ -0,0 +1,367 @@
+package febraban240
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+)
+
+// ErrUnexpectedSegment raised when a segment of a payment batch doesn't
+// belong to the payment form of the batch.
+var ErrUnexpectedSegment = errors.New("febraban240: segment doesn't belong to the payment form of the batch")
+
+// Operation type of the payment batches (crédito).
+const OperationCredit = "C"
+
+// PagamentoBatchHeader is the header of a payment batch (registro 1). The
+// service type and the payment form define the segments of the batch.
+type PagamentoBatchHeader struct {
+	Bank                int          `cnab:"0,3"`
+	Batch               int          `cnab:"3,7"`
+	_                   string       `cnab:"7,8,const=1"`
+	Operation           string       `cnab:"8,9"`
+	Service             ServiceType  `cnab:"9,11"`
+	PaymentForm         PaymentForm  `cnab:"11,13"`
+	LayoutVersion       string       `cnab:"13,16"`
+	_                   string       `cnab:"16,17"`
+	CompanyDocumentType DocumentType `cnab:"17,18"`
+	CompanyDocument     int64        `cnab:"18,32"`
+	Agreement           string       `cnab:"32,52"`
+	Agency              int          `cnab:"52,57"`
+	AgencyDigit         string       `cnab:"57,58"`
+	Account             int64        `cnab:"58,70"`
+	AccountDigit        string       `cnab:"70,71"`
+	AgencyAccountDigit  string       `cnab:"71,72"`
+	CompanyName         string       `cnab:"72,102"`
+	Message             string       `cnab:"102,142"`
+	Street              string       `cnab:"142,172"`
+	Number              int          `cnab:"172,177"`
+	Complement          string       `cnab:"177,192"`
+	City                string       `cnab:"192,212"`
+	ZipCode             int          `cnab:"212,217"`
+	ZipCodeSuffix       int          `cnab:"217,220"`
+	State               string       `cnab:"220,222"`
+	ServicePaymentForm  string       `cnab:"222,224"`
+	_                   string       `cnab:"224,230"`
+	Occurrences         string       `cnab:"230,240"`
+}
+
+// PagamentoBatchTrailer is the trailer of a payment batch (registro 5). The
+// number of records is filled by cnab240.Marshal.
+type PagamentoBatchTrailer struct {
+	Bank          int     `cnab:"0,3"`
+	Batch         int     `cnab:"3,7"`
+	_             string  `cnab:"7,8,const=5"`
+	_             string  `cnab:"8,17"`
+	Records       int     `cnab:"17,23"`
+	TotalAmount   float64 `cnab:"23,41"`
+	TotalCurrency int64   `cnab:"41,59"`
+	DebitNotice   int     `cnab:"59,65"`
+	_             string  `cnab:"65,230"`
+	Occurrences   string  `cnab:"230,240"`
+}
+
+// SegmentA contains a credit to a beneficiary (segmento A), like a transfer
+// to an account, a TED or a salary payment.
+type SegmentA struct {
+	Bank                          int                `cnab:"0,3"`
+	Batch                         int                `cnab:"3,7"`
+	_                             string             `cnab:"7,8,const=3"`
+	Sequence                      int                `cnab:"8,13"`
+	_                             string             `cnab:"13,14,const=A"`
+	MovementType                  MovementType       `cnab:"14,15"`
+	Instruction                   PaymentInstruction `cnab:"15,17"`
+	ClearingHouse                 int                `cnab:"17,20"`
+	BeneficiaryBank               int                `cnab:"20,23"`
+	BeneficiaryAgency             int                `cnab:"23,28"`
+	BeneficiaryAgencyDigit        string             `cnab:"28,29"`
+	BeneficiaryAccount            int64              `cnab:"29,41"`
+	BeneficiaryAccountDigit       string             `cnab:"41,42"`
+	BeneficiaryAgencyAccountDigit string             `cnab:"42,43"`
+	BeneficiaryName               string             `cnab:"43,73"`
+	CompanyNumber                 string             `cnab:"73,93"`
+	PaymentDate                   cnab240.Date       `cnab:"93,101"`
+	CurrencyType                  string             `cnab:"101,104"`
+	CurrencyQuantity              int64              `cnab:"104,119"`
+	Amount                        float64            `cnab:"119,134"`
+	BankNumber                    string             `cnab:"134,154"`
+	EffectiveDate                 cnab240.Date       `cnab:"154,162"`
+	EffectiveAmount               float64            `cnab:"162,177"`
+	Information                   string             `cnab:"177,217"`
+	ServiceComplement             string             `cnab:"217,219"`
+	TEDPurpose                    string             `cnab:"219,224"`
+	PaymentPurpose                string             `cnab:"224,226"`
+	_                             string             `cnab:"226,229"`
+	Notice                        int                `cnab:"229,230"`
+	Occurrences                   string             `cnab:"230,240"`
+}
+
+// SegmentB contains the beneficiary data of a credit (segmento B). The
+// positions [14,17) are blank, unlike in the segment B Pix, where they contain
+// the key type.
+type SegmentB struct {
+	Bank                    int          `cnab:"0,3"`
+	Batch                   int          `cnab:"3,7"`
+	_                       string       `cnab:"7,8,const=3"`
+	Sequence                int          `cnab:"8,13"`
+	_                       string       `cnab:"13,14,const=B"`
+	_                       string       `cnab:"14,17,const="`
+	BeneficiaryDocumentType DocumentType `cnab:"17,18"`
+	BeneficiaryDocument     int64        `cnab:"18,32"`
+	Street                  string       `cnab:"32,62"`
+	Number                  int          `cnab:"62,67"`
+	Complement              string       `cnab:"67,82"`
+	District                string       `cnab:"82,97"`
+	City                    string       `cnab:"97,117"`
+	ZipCode                 int          `cnab:"117,122"`
+	ZipCodeSuffix           int          `cnab:"122,125"`
+	State                   string       `cnab:"125,127"`
+	DueDate                 cnab240.Date `cnab:"127,135"`
+	DocumentAmount          float64      `cnab:"135,150"`
+	Rebate                  float64      `cnab:"150,165"`
+	Discount                float64      `cnab:"165,180"`
+	Interest                float64      `cnab:"180,195"`
+	Fine                    float64      `cnab:"195,210"`
+	BeneficiaryCode         string       `cnab:"210,225"`
+	Notice                  int          `cnab:"225,226"`
+	UGCode                  int          `cnab:"226,232"`
+	ISPB                    int          `cnab:"232,240"`
+}
+
+// SegmentC contains the deductions and additions of a credit (segmento C),
+// like the taxes withheld from a supplier payment.
+type SegmentC struct {
+	Bank                          int     `cnab:"0,3"`
+	Batch                         int     `cnab:"3,7"`
+	_                             string  `cnab:"7,8,const=3"`
+	Sequence                      int     `cnab:"8,13"`
+	_                             string  `cnab:"13,14,const=C"`
+	_                             string  `cnab:"14,17"`
+	IR                            float64 `cnab:"17,32"`
+	ISS                           float64 `cnab:"32,47"`
+	IOF                           float64 `cnab:"47,62"`
+	OtherDeductions               float64 `cnab:"62,77"`
+	OtherAdditions                float64 `cnab:"77,92"`
+	ReplacementAgency             int     `cnab:"92,97"`
+	ReplacementAgencyDigit        string  `cnab:"97,98"`
+	ReplacementAccount            int64   `cnab:"98,110"`
+	ReplacementAccountDigit       string  `cnab:"110,111"`
+	ReplacementAgencyAccountDigit string  `cnab:"111,112"`
+	INSS                          float64 `cnab:"112,127"`
+	_                             string  `cnab:"127,240"`
+}
+
+// SegmentJ contains the payment of a boleto (segmento J).
+type SegmentJ struct {
+	Bank             int                `cnab:"0,3"`
+	Batch            int                `cnab:"3,7"`
+	_                string             `cnab:"7,8,const=3"`
+	Sequence         int                `cnab:"8,13"`
+	_                string             `cnab:"13,14,const=J"`
+	MovementType     MovementType       `cnab:"14,15"`
+	Instruction      PaymentInstruction `cnab:"15,17"`
+	Barcode          string             `cnab:"17,61"`
+	AssignorName     string             `cnab:"61,91"`
+	DueDate          cnab240.Date       `cnab:"91,99"`
+	TitleAmount      float64            `cnab:"99,114"`
+	Discount         float64            `cnab:"114,129"`
+	Additions        float64            `cnab:"129,144"`
+	PaymentDate      cnab240.Date       `cnab:"144,152"`
+	Amount           float64            `cnab:"152,167"`
+	CurrencyQuantity int64              `cnab:"167,182"`
+	CompanyNumber    string             `cnab:"182,202"`
+	BankNumber       string             `cnab:"202,222"`
+	Currency         int                `cnab:"222,224"`
+	_                string             `cnab:"224,230"`
+	Occurrences      string             `cnab:"230,240"`
+}
+
+// SegmentJ52 contains the payer, beneficiary and guarantor of a boleto payment
+// (segmento J-52), required for boletos registered in the CIP.
+type SegmentJ52 struct {
+	Bank                    int                `cnab:"0,3"`
+	Batch                   int                `cnab:"3,7"`
+	_                       string             `cnab:"7,8,const=3"`
+	Sequence                int                `cnab:"8,13"`
+	_                       string             `cnab:"13,14,const=J"`
+	_                       string             `cnab:"14,15"`
+	Instruction             PaymentInstruction `cnab:"15,17"`
+	_                       string             `cnab:"17,19,const=52"`
+	PayerDocumentType       DocumentType       `cnab:"19,20"`
+	PayerDocument           int64              `cnab:"20,35"`
+	PayerName               string             `cnab:"35,75"`
+	BeneficiaryDocumentType DocumentType       `cnab:"75,76"`
+	BeneficiaryDocument     int64              `cnab:"76,91"`
+	BeneficiaryName         string             `cnab:"91,131"`
+	GuarantorDocumentType   DocumentType       `cnab:"131,132"`
+	GuarantorDocument       int64              `cnab:"132,147"`
+	GuarantorName           string             `cnab:"147,187"`
+	_                       string             `cnab:"187,240"`
+}
+
+// SegmentN contains the payment of a tax without barcode (segmento N), like
+// DARF, GPS and GARE. The information of each tax is kept in TaxInformation,
+// following the layout of the tax.
+type SegmentN struct {
+	Bank           int                `cnab:"0,3"`
+	Batch          int                `cnab:"3,7"`
+	_              string             `cnab:"7,8,const=3"`
+	Sequence       int                `cnab:"8,13"`
+	_              string             `cnab:"13,14,const=N"`
+	MovementType   MovementType       `cnab:"14,15"`
+	Instruction    PaymentInstruction `cnab:"15,17"`
+	CompanyNumber  string             `cnab:"17,37"`
+	BankNumber     string             `cnab:"37,57"`
+	TaxpayerName   string             `cnab:"57,87"`
+	PaymentDate    cnab240.Date       `cnab:"87,95"`
+	Amount         float64            `cnab:"95,110"`
+	TaxInformation string             `cnab:"110,230"`
+	Occurrences    string             `cnab:"230,240"`
+}
+
+// SegmentO contains the payment of a bill or tax with barcode (segmento O),
+// like utilities (concessionárias).
+type SegmentO struct {
+	Bank          int                `cnab:"0,3"`
+	Batch         int                `cnab:"3,7"`
+	_             string             `cnab:"7,8,const=3"`
+	Sequence      int                `cnab:"8,13"`
+	_             string             `cnab:"13,14,const=O"`
+	MovementType  MovementType       `cnab:"14,15"`
+	Instruction   PaymentInstruction `cnab:"15,17"`
+	Barcode       string             `cnab:"17,61"`
+	CompanyName   string             `cnab:"61,91"`
+	DueDate       cnab240.Date       `cnab:"91,99"`
+	PaymentDate   cnab240.Date       `cnab:"99,107"`
+	Amount        float64            `cnab:"107,122"`
+	CompanyNumber string             `cnab:"122,142"`
+	BankNumber    string             `cnab:"142,162"`
+	_             string             `cnab:"162,230"`
+	Occurrences   string             `cnab:"230,240"`
+}
+
+// SegmentW contains complementary information of a payment (segmento W),
+// like the information of a tax paid with the segments N or O.
+type SegmentW struct {
+	Bank               int    `cnab:"0,3"`
+	Batch              int    `cnab:"3,7"`
+	_                  string `cnab:"7,8,const=3"`
+	Sequence           int    `cnab:"8,13"`
+	_                  string `cnab:"13,14,const=W"`
+	ComplementSequence int    `cnab:"14,15"`
+	InformationUsage   string `cnab:"15,16"`
+	Information1       string `cnab:"16,96"`
+	Information2       string `cnab:"96,176"`
+	TaxIdentifier      string `cnab:"176,178"`
+	TaxInformation     string `cnab:"178,230"`
+	Occurrences        string `cnab:"230,240"`
+}
+
+// SegmentZ contains the authentication of a payment (segmento Z), returned by
+// the bank as the proof of payment.
+type SegmentZ struct {
+	Bank           int    `cnab:"0,3"`
+	Batch          int    `cnab:"3,7"`
+	_              string `cnab:"7,8,const=3"`
+	Sequence       int    `cnab:"8,13"`
+	_              string `cnab:"13,14,const=Z"`
+	Authentication string `cnab:"14,78"`
+	Protocol       string `cnab:"78,103"`
+	_              string `cnab:"103,230"`
+	Occurrences    string `cnab:"230,240"`
+}
+
+// Credit is a credit to a beneficiary, composed by the consecutive segments A
+// and optionally B, C and Z.
+type Credit struct {
+	A SegmentA  `cnab:"line"`
+	B *SegmentB `cnab:"line"`
+	C *SegmentC `cnab:"line"`
+	Z *SegmentZ `cnab:"line"`
+}
+
+// TitlePayment is the payment of a boleto, composed by the consecutive
+// segments J and optionally J-52 and Z.
+type TitlePayment struct {
+	J   SegmentJ    `cnab:"line"`
+	J52 *SegmentJ52 `cnab:"line"`
+	Z   *SegmentZ   `cnab:"line"`
+}
+
+// MatchSegmentJ52 detects the segment J-52, that shares the segment code with
+// the segment J.
+var MatchSegmentJ52 = gocnab.MatchAll(
+	cnab240.MatchSegment("J"),
+	gocnab.MatchRange(17, 19, "52"),
+)
+
+// NewPagamentoMapper returns a mapper with the payment record types, to be
+// used with cnab240.Unmarshal. The payments are decoded as Credit (segment A),
+// PixCredit (segment A with the Pix clearing house) and TitlePayment (segment
+// J), and the other segments are decoded individually. Pix QR code payments
+// are decoded with NewPixMapper, as the segments J-52 and J-52 Pix can't be
+// told apart.
+func NewPagamentoMapper() *gocnab.Mapper {
+	return newPagamentoMapper((*TitlePayment)(nil), (*SegmentJ52)(nil))
+}
+
+// newPagamentoMapper returns a mapper with the payment record types, using
+// the given record types for the segments J and J-52.
+func newPagamentoMapper(titlePayment, segmentJ52 interface{}) *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*FileHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileHeader))
+	mapper.Register((*PagamentoBatchHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchHeader))
+	mapper.Register((*Credit)(nil), cnab240.MatchSegment("A"))
+	mapper.RegisterWithPriority((*PixCredit)(nil), MatchSegmentAPix, 1)
+	mapper.Register((*SegmentB)(nil), cnab240.MatchSegment("B"))
+	mapper.RegisterWithPriority((*SegmentBPix)(nil), MatchSegmentBPix, 1)
+	mapper.Register((*SegmentC)(nil), cnab240.MatchSegment("C"))
+	mapper.Register(titlePayment, cnab240.MatchSegment("J"))
+	mapper.RegisterWithPriority(segmentJ52, MatchSegmentJ52, 1)
+	mapper.Register((*SegmentN)(nil), cnab240.MatchSegment("N"))
+	mapper.Register((*SegmentO)(nil), cnab240.MatchSegment("O"))
+	mapper.Register((*SegmentW)(nil), cnab240.MatchSegment("W"))
+	mapper.Register((*SegmentZ)(nil), cnab240.MatchSegment("Z"))
+	mapper.Register((*PagamentoBatchTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchTrailer))
+	mapper.Register((*FileTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileTrailer))
+	return mapper
+}
+
+// CheckPagamentoBatch verifies if the segments of a payment batch belong to
+// the payment form of the batch header: credits (segments A, B and C) for
+// transfers, boleto payments (segments J and J-52) for títulos, and tax
+// payments (segments N, O and W) for bills and taxes. Pix transfers are
+// credits and Pix QR code payments are boleto payments. The segment Z can be
+// used in any batch. Segments of other files (e.g. cobrança segments) are
+// rejected in any batch.
+func CheckPagamentoBatch(batch cnab240.Batch) error {
+	header, ok := batch.Header.(PagamentoBatchHeader)
+	if !ok {
+		return fmt.Errorf("%w: unknown batch header %T", ErrUnexpectedSegment, batch.Header)
+	}
+
+	group := header.PaymentForm.group()
+	for i, segment := range batch.Segments {
+		var segmentGroup paymentGroup
+		switch segment.(type) {
+		case Credit, PixCredit, SegmentA, SegmentB, SegmentBPix, SegmentC:
+			segmentGroup = paymentGroupCredit
+		case TitlePayment, PixTitlePayment, SegmentJ, SegmentJ52, SegmentJ52Pix:
+			segmentGroup = paymentGroupTitle
+		case SegmentN, SegmentO, SegmentW:
+			segmentGroup = paymentGroupTax
+		case SegmentZ:
+			continue
+		default:
+			return fmt.Errorf("%w: segment %d (%T) isn't a payment segment", ErrUnexpectedSegment, i+1, segment)
+		}
+
+		if segmentGroup != group {
+			return fmt.Errorf("%w: segment %d (%T) in a batch with payment form %s", ErrUnexpectedSegment, i+1, segment, header.PaymentForm)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package febraban240
+
+// ServiceType is the service of a batch (tipo de serviço), informed in the
+// batch header.
+type ServiceType string
+
+// List of service types of the payment batches defined by FEBRABAN.
+const (
+	ServiceSupplierPayment     ServiceType = "20"
+	ServiceBillAndTaxPayment   ServiceType = "22"
+	ServiceInteroperability    ServiceType = "23"
+	ServiceCompror             ServiceType = "25"
+	ServiceRevolvingCompror    ServiceType = "26"
+	ServiceSalaryPayment       ServiceType = "30"
+	ServiceFeePayment          ServiceType = "32"
+	ServiceScholarshipPayment  ServiceType = "33"
+	ServicePrebendPayment      ServiceType = "34"
+	ServiceVendor              ServiceType = "40"
+	ServiceTermVendor          ServiceType = "41"
+	ServiceClaimPayment        ServiceType = "50"
+	ServiceTravelExpenses      ServiceType = "60"
+	ServiceAuthorizedPayment   ServiceType = "70"
+	ServiceAccreditedPayment   ServiceType = "75"
+	ServiceRemunerationPayment ServiceType = "77"
+	ServiceSellerPayment       ServiceType = "80"
+	ServiceBenefitPayment      ServiceType = "90"
+	ServiceOtherPayments       ServiceType = "98"
+)
+
+var serviceDescriptions = map[ServiceType]string{
+	ServiceSupplierPayment:     "Pagamento fornecedor",
+	ServiceBillAndTaxPayment:   "Pagamento de contas, tributos e impostos",
+	ServiceInteroperability:    "Interoperabilidade entre contas de instituições de pagamentos",
+	ServiceCompror:             "Compror",
+	ServiceRevolvingCompror:    "Compror rotativo",
+	ServiceSalaryPayment:       "Pagamento salários",
+	ServiceFeePayment:          "Pagamento de honorários",
+	ServiceScholarshipPayment:  "Pagamento de bolsa auxílio",
+	ServicePrebendPayment:      "Pagamento de prebenda",
+	ServiceVendor:              "Vendor",
+	ServiceTermVendor:          "Vendor a termo",
+	ServiceClaimPayment:        "Pagamento sinistros segurados",
+	ServiceTravelExpenses:      "Pagamento despesas viajante em trânsito",
+	ServiceAuthorizedPayment:   "Pagamento autorizado",
+	ServiceAccreditedPayment:   "Pagamento credenciados",
+	ServiceRemunerationPayment: "Pagamento de remuneração",
+	ServiceSellerPayment:       "Pagamento representantes/vendedores autorizados",
+	ServiceBenefitPayment:      "Pagamento benefícios",
+	ServiceOtherPayments:       "Pagamentos diversos",
+}
+
+// Description returns the description of the service type defined by
+// FEBRABAN, or an empty string for unknown codes.
+func (s ServiceType) Description() string {
+	return serviceDescriptions[s]
+}
+
+// PaymentForm is the payment form of a batch (forma de lançamento), informed
+// in the batch header. It defines the segments used in the batch.
+type PaymentForm string
+
+// List of payment forms defined by FEBRABAN. Banks could support only a subset
+// of them.
+const (
+	PaymentFormCheckingAccount     PaymentForm = "01"
+	PaymentFormCheck               PaymentForm = "02"
+	PaymentFormDOCTED              PaymentForm = "03"
+	PaymentFormSalaryCard          PaymentForm = "04"
+	PaymentFormSavingsAccount      PaymentForm = "05"
+	PaymentFormPaymentOrder        PaymentForm = "10"
+	PaymentFormBarcodeBill         PaymentForm = "11"
+	PaymentFormDARF                PaymentForm = "16"
+	PaymentFormGPS                 PaymentForm = "17"
+	PaymentFormDARFSimples         PaymentForm = "18"
+	PaymentFormIPTU                PaymentForm = "19"
+	PaymentFormAuthentication      PaymentForm = "20"
+	PaymentFormDARJ                PaymentForm = "21"
+	PaymentFormGAREICMS            PaymentForm = "22"
+	PaymentFormGAREDR              PaymentForm = "23"
+	PaymentFormGAREITCMD           PaymentForm = "24"
+	PaymentFormIPVA                PaymentForm = "25"
+	PaymentFormLicensing           PaymentForm = "26"
+	PaymentFormDPVAT               PaymentForm = "27"
+	PaymentFormOwnBankTitle        PaymentForm = "30"
+	PaymentFormOtherBankTitle      PaymentForm = "31"
+	PaymentFormTEDOtherOwner       PaymentForm = "41"
+	PaymentFormTEDSameOwner        PaymentForm = "43"
+	PaymentFormTEDInvestment       PaymentForm = "44"
+	PaymentFormPixTransfer         PaymentForm = "45"
+	PaymentFormPixQRCode           PaymentForm = "47"
+	PaymentFormCheckingAccountDebt PaymentForm = "50"
+	PaymentFormBenefitCard         PaymentForm = "70"
+	PaymentFormFGTS                PaymentForm = "71"
+)
+
+var paymentFormDescriptions = map[PaymentForm]string{
+	PaymentFormCheckingAccount:     "Crédito em conta corrente/salário",
+	PaymentFormCheck:               "Cheque pagamento/administrativo",
+	PaymentFormDOCTED:              "DOC/TED",
+	PaymentFormSalaryCard:          "Cartão salário",
+	PaymentFormSavingsAccount:      "Crédito em conta poupança",
+	PaymentFormPaymentOrder:        "OP à disposição",
+	PaymentFormBarcodeBill:         "Pagamento de contas e tributos com código de barras",
+	PaymentFormDARF:                "Tributo - DARF normal",
+	PaymentFormGPS:                 "Tributo - GPS (Guia da Previdência Social)",
+	PaymentFormDARFSimples:         "Tributo - DARF simples",
+	PaymentFormIPTU:                "Tributo - IPTU/prefeituras",
+	PaymentFormAuthentication:      "Pagamento com autenticação",
+	PaymentFormDARJ:                "Tributo - DARJ",
+	PaymentFormGAREICMS:            "Tributo - GARE-SP ICMS",
+	PaymentFormGAREDR:              "Tributo - GARE-SP DR",
+	PaymentFormGAREITCMD:           "Tributo - GARE-SP ITCMD",
+	PaymentFormIPVA:                "Tributo - IPVA",
+	PaymentFormLicensing:           "Tributo - licenciamento",
+	PaymentFormDPVAT:               "Tributo - DPVAT",
+	PaymentFormOwnBankTitle:        "Liquidação de títulos do próprio banco",
+	PaymentFormOtherBankTitle:      "Pagamento de títulos de outros bancos",
+	PaymentFormTEDOtherOwner:       "TED - outra titularidade",
+	PaymentFormTEDSameOwner:        "TED - mesma titularidade",
+	PaymentFormTEDInvestment:       "TED para transferência de conta investimento",
+	PaymentFormPixTransfer:         "PIX transferência",
+	PaymentFormPixQRCode:           "PIX QR-CODE",
+	PaymentFormCheckingAccountDebt: "Débito em conta corrente",
+	PaymentFormBenefitCard:         "Cartão benefício",
+	PaymentFormFGTS:                "Tributo - FGTS (GRF/GRRF/GRDE)",
+}
+
+// Description returns the description of the payment form defined by
+// FEBRABAN, or an empty string for unknown codes.
+func (p PaymentForm) Description() string {
+	return paymentFormDescriptions[p]
+}
+
+// paymentGroup groups the payment forms that share the same segments.
+type paymentGroup int
+
+const (
+	paymentGroupCredit paymentGroup = iota
+	paymentGroupTitle
+	paymentGroupTax
+)
+
+func (p PaymentForm) group() paymentGroup {
+	switch p {
+	case PaymentFormOwnBankTitle, PaymentFormOtherBankTitle, PaymentFormPixQRCode:
+		return paymentGroupTitle
+
+	case PaymentFormBarcodeBill, PaymentFormDARF, PaymentFormGPS, PaymentFormDARFSimples,
+		PaymentFormIPTU, PaymentFormAuthentication, PaymentFormDARJ, PaymentFormGAREICMS,
+		PaymentFormGAREDR, PaymentFormGAREITCMD, PaymentFormIPVA, PaymentFormLicensing,
+		PaymentFormDPVAT, PaymentFormFGTS:
+		return paymentGroupTax
+	}
+
+	return paymentGroupCredit
+}
+
+// MovementType is the type of the movement of a payment (tipo de movimento).
+type MovementType string
+
+// List of movement types defined by FEBRABAN.
+const (
+	MovementEntry    MovementType = "0"
+	MovementQuery    MovementType = "1"
+	MovementReversal MovementType = "3"
+	MovementChange   MovementType = "5"
+	MovementRelease  MovementType = "7"
+	MovementRemoval  MovementType = "9"
+)
+
+// PaymentInstruction is the instruction of a payment (código da instrução
+// para movimento).
+type PaymentInstruction string
+
+// List of payment instructions defined by FEBRABAN. Banks could support only a
+// subset of them.
+const (
+	PaymentInstructionReleasedEntry    PaymentInstruction = "00"
+	PaymentInstructionBlockedEntry     PaymentInstruction = "09"
+	PaymentInstructionBlock            PaymentInstruction = "10"
+	PaymentInstructionRelease          PaymentInstruction = "11"
+	PaymentInstructionChangeAmount     PaymentInstruction = "17"
+	PaymentInstructionChangeDate       PaymentInstruction = "19"
+	PaymentInstructionDirectPayment    PaymentInstruction = "23"
+	PaymentInstructionKeepInWallet     PaymentInstruction = "25"
+	PaymentInstructionRemoveFromWallet PaymentInstruction = "27"
+	PaymentInstructionClearingReversal PaymentInstruction = "33"
+	PaymentInstructionPayerAllegation  PaymentInstruction = "40"
+	PaymentInstructionRemoveEntry      PaymentInstruction = "99"
+)
+
+var paymentInstructionDescriptions = map[PaymentInstruction]string{
+	PaymentInstructionReleasedEntry:    "Inclusão de registro detalhe liberado",
+	PaymentInstructionBlockedEntry:     "Inclusão do registro detalhe bloqueado",
+	PaymentInstructionBlock:            "Alteração do pagamento liberado para bloqueado (bloqueio)",
+	PaymentInstructionRelease:          "Alteração do pagamento bloqueado para liberado (liberação)",
+	PaymentInstructionChangeAmount:     "Alteração do valor do título",
+	PaymentInstructionChangeDate:       "Alteração da data de pagamento",
+	PaymentInstructionDirectPayment:    "Pagamento direto ao fornecedor - baixar",
+	PaymentInstructionKeepInWallet:     "Manutenção em carteira - não pagar",
+	PaymentInstructionRemoveFromWallet: "Retirada de carteira - não pagar",
+	PaymentInstructionClearingReversal: "Estorno por devolução da câmara centralizadora",
+	PaymentInstructionPayerAllegation:  "Alegação do pagador",
+	PaymentInstructionRemoveEntry:      "Exclusão do registro detalhe incluído anteriormente",
+}
+
+// Description returns the description of the payment instruction defined by
+// FEBRABAN, or an empty string for unknown codes.
+func (p PaymentInstruction) Description() string {
+	return paymentInstructionDescriptions[p]
+}
@@ -0,0 +1,320 @@
+package febraban240_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+)
+
+func pagamentoBatchHeader(batch int, service febraban240.ServiceType, form febraban240.PaymentForm) febraban240.PagamentoBatchHeader {
+	return febraban240.PagamentoBatchHeader{
+		Bank:                341,
+		Batch:               batch,
+		Operation:           febraban240.OperationCredit,
+		Service:             service,
+		PaymentForm:         form,
+		LayoutVersion:       "046",
+		CompanyDocumentType: febraban240.DocumentTypeCNPJ,
+		CompanyDocument:     12345678000195,
+		Agreement:           "000000000000123456",
+		Agency:              1234,
+		AgencyDigit:         "5",
+		Account:             67890,
+		AccountDigit:        "1",
+		CompanyName:         "EMPRESA EXEMPLO LTDA",
+		Street:              "AVENIDA PAULISTA",
+		Number:              1000,
+		City:                "SAO PAULO",
+		ZipCode:             1310,
+		ZipCodeSuffix:       100,
+		State:               "SP",
+	}
+}
+
+func pagamentoFile(fileCode febraban240.FileCode, withReturn bool) cnab240.File {
+	var creditZ, titleZ *febraban240.SegmentZ
+	var occurrences string
+	if withReturn {
+		occurrences = "00"
+		creditZ = &febraban240.SegmentZ{
+			Bank:           341,
+			Batch:          1,
+			Sequence:       4,
+			Authentication: "A1B2C3D4E5F6",
+			Protocol:       "0000000000000000000012345",
+			Occurrences:    occurrences,
+		}
+		titleZ = &febraban240.SegmentZ{
+			Bank:           341,
+			Batch:          2,
+			Sequence:       3,
+			Authentication: "F6E5D4C3B2A1",
+			Protocol:       "0000000000000000000054321",
+			Occurrences:    occurrences,
+		}
+	}
+
+	creditRecords, titleRecords := 5, 4
+	if withReturn {
+		creditRecords, titleRecords = 6, 5
+	}
+
+	return cnab240.File{
+		Header: cobrancaFileHeader(fileCode),
+		Batches: []cnab240.Batch{
+			{
+				Header: pagamentoBatchHeader(1, febraban240.ServiceSupplierPayment, febraban240.PaymentFormTEDOtherOwner),
+				Segments: []cnab240.Segment{
+					febraban240.Credit{
+						A: febraban240.SegmentA{
+							Bank:                    341,
+							Batch:                   1,
+							Sequence:                1,
+							MovementType:            febraban240.MovementEntry,
+							Instruction:             febraban240.PaymentInstructionReleasedEntry,
+							ClearingHouse:           18,
+							BeneficiaryBank:         1,
+							BeneficiaryAgency:       4321,
+							BeneficiaryAgencyDigit:  "0",
+							BeneficiaryAccount:      123456,
+							BeneficiaryAccountDigit: "7",
+							BeneficiaryName:         "FORNECEDOR EXEMPLO SA",
+							CompanyNumber:           "NF-2001",
+							PaymentDate:             cnab240.NewDate(2026, 10, 20),
+							CurrencyType:            "BRL",
+							Amount:                  2500.5,
+							TEDPurpose:              "00005",
+							Occurrences:             occurrences,
+						},
+						B: &febraban240.SegmentB{
+							Bank:                    341,
+							Batch:                   1,
+							Sequence:                2,
+							BeneficiaryDocumentType: febraban240.DocumentTypeCNPJ,
+							BeneficiaryDocument:     98765432000198,
+							Street:                  "RUA DO COMERCIO",
+							Number:                  50,
+							District:                "CENTRO",
+							City:                    "CAMPINAS",
+							ZipCode:                 13010,
+							ZipCodeSuffix:           1,
+							State:                   "SP",
+						},
+						C: &febraban240.SegmentC{
+							Bank:     341,
+							Batch:    1,
+							Sequence: 3,
+							IR:       37.51,
+							ISS:      125.03,
+						},
+						Z: creditZ,
+					},
+				},
+				Trailer: febraban240.PagamentoBatchTrailer{
+					Bank:        341,
+					Batch:       1,
+					Records:     creditRecords,
+					TotalAmount: 2500.5,
+				},
+			},
+			{
+				Header: pagamentoBatchHeader(2, febraban240.ServiceSupplierPayment, febraban240.PaymentFormOtherBankTitle),
+				Segments: []cnab240.Segment{
+					febraban240.TitlePayment{
+						J: febraban240.SegmentJ{
+							Bank:          341,
+							Batch:         2,
+							Sequence:      1,
+							MovementType:  febraban240.MovementEntry,
+							Instruction:   febraban240.PaymentInstructionReleasedEntry,
+							Barcode:       "00193373700000001000500940144816060680935031",
+							AssignorName:  "BENEFICIARIO EXEMPLO LTDA",
+							DueDate:       cnab240.NewDate(2026, 10, 25),
+							TitleAmount:   100,
+							PaymentDate:   cnab240.NewDate(2026, 10, 25),
+							Amount:        100,
+							CompanyNumber: "BOLETO-1",
+							Currency:      9,
+							Occurrences:   occurrences,
+						},
+						J52: &febraban240.SegmentJ52{
+							Bank:                    341,
+							Batch:                   2,
+							Sequence:                2,
+							Instruction:             febraban240.PaymentInstructionReleasedEntry,
+							PayerDocumentType:       febraban240.DocumentTypeCNPJ,
+							PayerDocument:           12345678000195,
+							PayerName:               "EMPRESA EXEMPLO LTDA",
+							BeneficiaryDocumentType: febraban240.DocumentTypeCNPJ,
+							BeneficiaryDocument:     11222333000181,
+							BeneficiaryName:         "BENEFICIARIO EXEMPLO LTDA",
+						},
+						Z: titleZ,
+					},
+				},
+				Trailer: febraban240.PagamentoBatchTrailer{
+					Bank:        341,
+					Batch:       2,
+					Records:     titleRecords,
+					TotalAmount: 100,
+				},
+			},
+			{
+				Header: pagamentoBatchHeader(3, febraban240.ServiceBillAndTaxPayment, febraban240.PaymentFormBarcodeBill),
+				Segments: []cnab240.Segment{
+					febraban240.SegmentO{
+						Bank:          341,
+						Batch:         3,
+						Sequence:      1,
+						MovementType:  febraban240.MovementEntry,
+						Instruction:   febraban240.PaymentInstructionReleasedEntry,
+						Barcode:       "83640000001234500481003612345678901234567890",
+						CompanyName:   "CONCESSIONARIA DE ENERGIA",
+						DueDate:       cnab240.NewDate(2026, 10, 30),
+						PaymentDate:   cnab240.NewDate(2026, 10, 30),
+						Amount:        123.45,
+						CompanyNumber: "CONTA-LUZ-10",
+						Occurrences:   occurrences,
+					},
+					febraban240.SegmentW{
+						Bank:               341,
+						Batch:              3,
+						Sequence:           2,
+						ComplementSequence: 1,
+						InformationUsage:   "1",
+						Information1:       "REFERENCIA 10/2026",
+					},
+				},
+				Trailer: febraban240.PagamentoBatchTrailer{
+					Bank:        341,
+					Batch:       3,
+					Records:     4,
+					TotalAmount: 123.45,
+				},
+			},
+		},
+		Trailer: febraban240.FileTrailer{
+			Bank:    341,
+			Batches: 3,
+			Records: 2 + creditRecords + titleRecords + 4,
+		},
+	}
+}
+
+func TestPagamento_remittance(t *testing.T) {
+	t.Parallel()
+
+	file := pagamentoFile(febraban240.FileCodeRemittance, false)
+
+	data, err := cnab240.Marshal(layouttest.WithoutFields(file, layouttest.CNAB240ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "pagamento_remessa.golden", data)
+
+	decoded, err := cnab240.Unmarshal(golden, febraban240.NewPagamentoMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+
+	for i, batch := range decoded.Batches {
+		if err := febraban240.CheckPagamentoBatch(batch); err != nil {
+			t.Errorf("unexpected error in batch %d. details: %s", i+1, err)
+		}
+	}
+}
+
+func TestPagamento_return(t *testing.T) {
+	t.Parallel()
+
+	file := pagamentoFile(febraban240.FileCodeReturn, true)
+
+	data, err := cnab240.Marshal(layouttest.WithoutFields(file, layouttest.CNAB240ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "pagamento_retorno.golden", data)
+
+	decoded, err := cnab240.Unmarshal(golden, febraban240.NewPagamentoMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+}
+
+func TestCheckPagamentoBatch(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description   string
+		batch         cnab240.Batch
+		expectedError error
+	}{
+		{
+			description: "it should accept credits in a TED batch",
+			batch: cnab240.Batch{
+				Header:   febraban240.PagamentoBatchHeader{PaymentForm: febraban240.PaymentFormTEDOtherOwner},
+				Segments: []cnab240.Segment{febraban240.Credit{}, febraban240.SegmentZ{}},
+			},
+		},
+		{
+			description: "it should accept boleto payments in a título batch",
+			batch: cnab240.Batch{
+				Header:   febraban240.PagamentoBatchHeader{PaymentForm: febraban240.PaymentFormOwnBankTitle},
+				Segments: []cnab240.Segment{febraban240.TitlePayment{}, febraban240.SegmentJ52{}},
+			},
+		},
+		{
+			description: "it should accept tax payments in a DARF batch",
+			batch: cnab240.Batch{
+				Header:   febraban240.PagamentoBatchHeader{PaymentForm: febraban240.PaymentFormDARF},
+				Segments: []cnab240.Segment{febraban240.SegmentN{}, febraban240.SegmentW{}},
+			},
+		},
+		{
+			description: "it should detect a boleto payment in a TED batch",
+			batch: cnab240.Batch{
+				Header:   febraban240.PagamentoBatchHeader{PaymentForm: febraban240.PaymentFormTEDOtherOwner},
+				Segments: []cnab240.Segment{febraban240.Credit{}, febraban240.TitlePayment{}},
+			},
+			expectedError: febraban240.ErrUnexpectedSegment,
+		},
+		{
+			description: "it should detect a segment that isn't a payment segment",
+			batch: cnab240.Batch{
+				Header:   febraban240.PagamentoBatchHeader{PaymentForm: febraban240.PaymentFormTEDOtherOwner},
+				Segments: []cnab240.Segment{febraban240.Credit{}, febraban240.SegmentP{}},
+			},
+			expectedError: febraban240.ErrUnexpectedSegment,
+		},
+		{
+			description: "it should detect a batch that isn't a payment batch",
+			batch: cnab240.Batch{
+				Header: febraban240.CobrancaBatchHeader{},
+			},
+			expectedError: febraban240.ErrUnexpectedSegment,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			err := febraban240.CheckPagamentoBatch(scenario.batch)
+			if !errors.Is(err, scenario.expectedError) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+		})
+	}
+}
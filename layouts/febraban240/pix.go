@@ -0,0 +1,131 @@
+package febraban240
+
+import (
+	"bytes"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+)
+
+// Clearing houses (câmara centralizadora) of the segment A.
+const (
+	ClearingHouseTED = 18
+	ClearingHouseDOC = 700
+	ClearingHousePix = 9
+)
+
+// PixKeyType is the way a Pix transfer is initiated (forma de iniciação),
+// identifying the type of the key in the segment B Pix. It is a numeric field,
+// so the codes are written with leading zeros (e.g. 002 for e-mail).
+type PixKeyType int
+
+// List of Pix key types defined by FEBRABAN.
+const (
+	PixKeyTypePhone       PixKeyType = 1
+	PixKeyTypeEmail       PixKeyType = 2
+	PixKeyTypeCPFCNPJ     PixKeyType = 3
+	PixKeyTypeEVP         PixKeyType = 4
+	PixKeyTypeBankAccount PixKeyType = 5
+)
+
+var pixKeyTypeDescriptions = map[PixKeyType]string{
+	PixKeyTypePhone:       "Telefone",
+	PixKeyTypeEmail:       "E-mail",
+	PixKeyTypeCPFCNPJ:     "CPF/CNPJ",
+	PixKeyTypeEVP:         "Chave aleatória",
+	PixKeyTypeBankAccount: "Dados bancários",
+}
+
+// Description returns the description of the Pix key type defined by
+// FEBRABAN, or an empty string for unknown codes.
+func (p PixKeyType) Description() string {
+	return pixKeyTypeDescriptions[p]
+}
+
+// SegmentBPix contains the Pix key of a transfer (segmento B Pix), used with
+// the segment A in batches with the payment form PaymentFormPixTransfer. When
+// the key type is PixKeyTypeBankAccount the account of the segment A is used
+// instead of the key. The key and the transaction identifier are case
+// sensitive, so they are written as they are.
+type SegmentBPix struct {
+	Bank                    int          `cnab:"0,3"`
+	Batch                   int          `cnab:"3,7"`
+	_                       string       `cnab:"7,8,const=3"`
+	Sequence                int          `cnab:"8,13"`
+	_                       string       `cnab:"13,14,const=B"`
+	KeyType                 PixKeyType   `cnab:"14,17"`
+	BeneficiaryDocumentType DocumentType `cnab:"17,18"`
+	BeneficiaryDocument     int64        `cnab:"18,32"`
+	TransactionID           string       `cnab:"32,67,keepcase"`
+	Information             string       `cnab:"67,127"`
+	Key                     string       `cnab:"127,226,keepcase"`
+	UGCode                  int          `cnab:"226,232"`
+	ISPB                    int          `cnab:"232,240"`
+}
+
+// SegmentJ52Pix contains the payer, the beneficiary and the QR code of a Pix
+// payment (segmento J-52 Pix), used with the segment J in batches with the
+// payment form PaymentFormPixQRCode. The key (or the QR code URL) and the
+// transaction identifier are written as they are. No field tells it apart from
+// the segment J-52, so they can't be registered in the same mapper.
+type SegmentJ52Pix struct {
+	Bank                    int                `cnab:"0,3"`
+	Batch                   int                `cnab:"3,7"`
+	_                       string             `cnab:"7,8,const=3"`
+	Sequence                int                `cnab:"8,13"`
+	_                       string             `cnab:"13,14,const=J"`
+	_                       string             `cnab:"14,15"`
+	Instruction             PaymentInstruction `cnab:"15,17"`
+	_                       string             `cnab:"17,19,const=52"`
+	PayerDocumentType       DocumentType       `cnab:"19,20"`
+	PayerDocument           int64              `cnab:"20,35"`
+	PayerName               string             `cnab:"35,75"`
+	BeneficiaryDocumentType DocumentType       `cnab:"75,76"`
+	BeneficiaryDocument     int64              `cnab:"76,91"`
+	BeneficiaryName         string             `cnab:"91,131"`
+	Key                     string             `cnab:"131,210,keepcase"`
+	TransactionID           string             `cnab:"210,240,keepcase"`
+}
+
+// PixCredit is a Pix transfer, composed by the consecutive segments A, B Pix
+// and optionally Z.
+type PixCredit struct {
+	A SegmentA    `cnab:"line"`
+	B SegmentBPix `cnab:"line"`
+	Z *SegmentZ   `cnab:"line"`
+}
+
+// PixTitlePayment is a Pix payment with QR code, composed by the consecutive
+// segments J, J-52 Pix and optionally Z.
+type PixTitlePayment struct {
+	J   SegmentJ      `cnab:"line"`
+	J52 SegmentJ52Pix `cnab:"line"`
+	Z   *SegmentZ     `cnab:"line"`
+}
+
+// MatchSegmentAPix detects the segment A of a Pix transfer, by the Pix
+// clearing house.
+var MatchSegmentAPix = gocnab.MatchAll(
+	cnab240.MatchSegment("A"),
+	gocnab.MatchRange(17, 20, "009"),
+)
+
+// MatchSegmentBPix detects the segment B Pix, that shares the segment code
+// with the segment B, by the key type in the positions [14,17) (blank in the
+// segment B).
+var MatchSegmentBPix = gocnab.MatchAll(
+	cnab240.MatchSegment("B"),
+	func(line []byte) bool {
+		return len(line) >= 17 && len(bytes.TrimSpace(line[14:17])) > 0
+	},
+)
+
+// NewPixMapper returns a mapper with the payment record types for files with
+// Pix QR code batches, to be used with cnab240.Unmarshal. It is the same of
+// NewPagamentoMapper, but the segment J is decoded as PixTitlePayment and the
+// segment J-52 as SegmentJ52Pix, as the segments J-52 and J-52 Pix can't be
+// told apart. Pix transfers (segments A and B Pix) are decoded by both
+// mappers.
+func NewPixMapper() *gocnab.Mapper {
+	return newPagamentoMapper((*PixTitlePayment)(nil), (*SegmentJ52Pix)(nil))
+}
@@ -0,0 +1,228 @@
+package febraban240_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+)
+
+func pixFile() cnab240.File {
+	return cnab240.File{
+		Header: cobrancaFileHeader(febraban240.FileCodeRemittance),
+		Batches: []cnab240.Batch{
+			{
+				Header: pagamentoBatchHeader(1, febraban240.ServiceSupplierPayment, febraban240.PaymentFormPixTransfer),
+				Segments: []cnab240.Segment{
+					febraban240.PixCredit{
+						A: febraban240.SegmentA{
+							Bank:            341,
+							Batch:           1,
+							Sequence:        1,
+							MovementType:    febraban240.MovementEntry,
+							Instruction:     febraban240.PaymentInstructionReleasedEntry,
+							ClearingHouse:   febraban240.ClearingHousePix,
+							BeneficiaryName: "FULANO DE TAL",
+							CompanyNumber:   "PIX-1",
+							PaymentDate:     cnab240.NewDate(2026, 10, 20),
+							CurrencyType:    "BRL",
+							Amount:          150,
+						},
+						B: febraban240.SegmentBPix{
+							Bank:                    341,
+							Batch:                   1,
+							Sequence:                2,
+							KeyType:                 febraban240.PixKeyTypeEmail,
+							BeneficiaryDocumentType: febraban240.DocumentTypeCPF,
+							BeneficiaryDocument:     12345678909,
+							Information:             "REEMBOLSO",
+							Key:                     "Fulano.Tal@example.com",
+						},
+					},
+					febraban240.PixCredit{
+						A: febraban240.SegmentA{
+							Bank:            341,
+							Batch:           1,
+							Sequence:        3,
+							MovementType:    febraban240.MovementEntry,
+							Instruction:     febraban240.PaymentInstructionReleasedEntry,
+							ClearingHouse:   febraban240.ClearingHousePix,
+							BeneficiaryName: "FORNECEDOR EXEMPLO SA",
+							CompanyNumber:   "PIX-2",
+							PaymentDate:     cnab240.NewDate(2026, 10, 20),
+							CurrencyType:    "BRL",
+							Amount:          99.9,
+						},
+						B: febraban240.SegmentBPix{
+							Bank:                    341,
+							Batch:                   1,
+							Sequence:                4,
+							KeyType:                 febraban240.PixKeyTypeEVP,
+							BeneficiaryDocumentType: febraban240.DocumentTypeCNPJ,
+							BeneficiaryDocument:     98765432000198,
+							TransactionID:           "pedido2026abc",
+							Key:                     "123e4567-e89b-12d3-a456-426614174000",
+						},
+					},
+				},
+				Trailer: febraban240.PagamentoBatchTrailer{
+					Bank:        341,
+					Batch:       1,
+					Records:     6,
+					TotalAmount: 249.9,
+				},
+			},
+			{
+				Header: pagamentoBatchHeader(2, febraban240.ServiceSupplierPayment, febraban240.PaymentFormPixQRCode),
+				Segments: []cnab240.Segment{
+					febraban240.PixTitlePayment{
+						J: febraban240.SegmentJ{
+							Bank:          341,
+							Batch:         2,
+							Sequence:      1,
+							MovementType:  febraban240.MovementEntry,
+							Instruction:   febraban240.PaymentInstructionReleasedEntry,
+							AssignorName:  "LOJA EXEMPLO",
+							PaymentDate:   cnab240.NewDate(2026, 10, 20),
+							Amount:        45.5,
+							CompanyNumber: "QRCODE-1",
+						},
+						J52: febraban240.SegmentJ52Pix{
+							Bank:                    341,
+							Batch:                   2,
+							Sequence:                2,
+							Instruction:             febraban240.PaymentInstructionReleasedEntry,
+							PayerDocumentType:       febraban240.DocumentTypeCNPJ,
+							PayerDocument:           12345678000195,
+							PayerName:               "EMPRESA EXEMPLO LTDA",
+							BeneficiaryDocumentType: febraban240.DocumentTypeCNPJ,
+							BeneficiaryDocument:     11222333000181,
+							BeneficiaryName:         "LOJA EXEMPLO",
+							Key:                     "pix.example.com/qr/v2/9d36b84fc70b478fb95c12729b90ca25",
+							TransactionID:           "Loja2026Pedido45",
+						},
+					},
+				},
+				Trailer: febraban240.PagamentoBatchTrailer{
+					Bank:        341,
+					Batch:       2,
+					Records:     4,
+					TotalAmount: 45.5,
+				},
+			},
+		},
+		Trailer: febraban240.FileTrailer{
+			Bank:    341,
+			Batches: 2,
+			Records: 12,
+		},
+	}
+}
+
+func TestPix(t *testing.T) {
+	t.Parallel()
+
+	file := pixFile()
+
+	data, err := cnab240.Marshal(layouttest.WithoutFields(file, layouttest.CNAB240ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "pix_remessa.golden", data)
+
+	for _, key := range []string{"Fulano.Tal@example.com", "123e4567-e89b-12d3-a456-426614174000", "Loja2026Pedido45"} {
+		if !bytes.Contains(golden, []byte(key)) {
+			t.Errorf("key “%s” not written as it is", key)
+		}
+	}
+
+	decoded, err := cnab240.Unmarshal(golden, febraban240.NewPixMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+
+	for i, batch := range decoded.Batches {
+		if err := febraban240.CheckPagamentoBatch(batch); err != nil {
+			t.Errorf("unexpected error in batch %d. details: %s", i+1, err)
+		}
+	}
+}
+
+func TestPix_pagamentoMapper(t *testing.T) {
+	t.Parallel()
+
+	// only the Pix transfers, as the segment J-52 Pix needs the Pix mapper
+	file := pixFile()
+	file.Batches = file.Batches[:1]
+
+	data, err := cnab240.Marshal(layouttest.WithoutFields(file, layouttest.CNAB240ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	decoded, err := cnab240.Unmarshal(data, febraban240.NewPagamentoMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file.Batches, decoded.Batches) {
+		t.Errorf("expected batches “%#v” and got “%#v”", file.Batches, decoded.Batches)
+	}
+}
+
+func TestSegmentBPix_spec(t *testing.T) {
+	t.Parallel()
+
+	// line assembled by hand from the FEBRABAN table of the segment B Pix
+	line := "341" + "0001" + "3" + "00002" + "B" +
+		"002" + // forma de iniciação [14,17), numeric
+		"1" + "00012345678909" +
+		fmt.Sprintf("%-35s", "pedido2026abc") +
+		fmt.Sprintf("%-60s", "REEMBOLSO") +
+		fmt.Sprintf("%-99s", "Fulano.Tal@example.com") +
+		"000000" + "00000000"
+
+	expected := febraban240.SegmentBPix{
+		Bank:                    341,
+		Batch:                   1,
+		Sequence:                2,
+		KeyType:                 febraban240.PixKeyTypeEmail,
+		BeneficiaryDocumentType: febraban240.DocumentTypeCPF,
+		BeneficiaryDocument:     12345678909,
+		TransactionID:           "pedido2026abc",
+		Information:             "REEMBOLSO",
+		Key:                     "Fulano.Tal@example.com",
+	}
+
+	var segment febraban240.SegmentBPix
+	if err := gocnab.Unmarshal([]byte(line), &segment); err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(expected, segment) {
+		t.Errorf("expected segment “%#v” and got “%#v”", expected, segment)
+	}
+
+	data, err := gocnab.Marshal240(expected)
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	if string(data) != line {
+		t.Errorf("expected line “%s” and got “%s”", line, data)
+	}
+
+	if !febraban240.MatchSegmentBPix([]byte(line)) {
+		t.Error("segment B Pix not detected")
+	}
+}
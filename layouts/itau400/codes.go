@@ -0,0 +1,257 @@
+package itau400
+
+// InstructionCode is the occurrence code of a título in a remittance (código
+// de ocorrência), defining the instruction sent to the bank.
+type InstructionCode string
+
+// List of instruction codes accepted by Itaú.
+const (
+	InstructionEntry                     InstructionCode = "01"
+	InstructionWriteOff                  InstructionCode = "02"
+	InstructionGrantRebate               InstructionCode = "04"
+	InstructionCancelRebate              InstructionCode = "05"
+	InstructionChangeDueDate             InstructionCode = "06"
+	InstructionChangeCompanyUse          InstructionCode = "07"
+	InstructionChangeDocumentNumber      InstructionCode = "08"
+	InstructionProtest                   InstructionCode = "09"
+	InstructionDontProtest               InstructionCode = "10"
+	InstructionBankruptcyProtest         InstructionCode = "11"
+	InstructionStopProtest               InstructionCode = "18"
+	InstructionRemoveGuarantor           InstructionCode = "30"
+	InstructionChangeOtherData           InstructionCode = "31"
+	InstructionWriteOffPaidToBeneficiary InstructionCode = "34"
+	InstructionCancelInstruction         InstructionCode = "35"
+	InstructionChangeDueDateAndStop      InstructionCode = "37"
+	InstructionRefusePayerAllegation     InstructionCode = "38"
+	InstructionWaiveInterest             InstructionCode = "47"
+	InstructionChangeExtraData           InstructionCode = "49"
+	InstructionNegative                  InstructionCode = "66"
+	InstructionDontNegative              InstructionCode = "67"
+	InstructionRemoveNegative            InstructionCode = "68"
+	InstructionCancelNegative            InstructionCode = "69"
+)
+
+var instructionDescriptions = map[InstructionCode]string{
+	InstructionEntry:                     "Remessa",
+	InstructionWriteOff:                  "Pedido de baixa",
+	InstructionGrantRebate:               "Concessão de abatimento",
+	InstructionCancelRebate:              "Cancelamento de abatimento",
+	InstructionChangeDueDate:             "Alteração do vencimento",
+	InstructionChangeCompanyUse:          "Alteração do uso da empresa",
+	InstructionChangeDocumentNumber:      "Alteração do seu número",
+	InstructionProtest:                   "Protestar",
+	InstructionDontProtest:               "Não protestar",
+	InstructionBankruptcyProtest:         "Protesto para fins falimentares",
+	InstructionStopProtest:               "Sustar o protesto",
+	InstructionRemoveGuarantor:           "Exclusão de sacador avalista",
+	InstructionChangeOtherData:           "Alteração de outros dados",
+	InstructionWriteOffPaidToBeneficiary: "Baixa por ter sido pago diretamente ao beneficiário",
+	InstructionCancelInstruction:         "Cancelamento de instrução",
+	InstructionChangeDueDateAndStop:      "Alteração do vencimento e sustar protesto",
+	InstructionRefusePayerAllegation:     "Beneficiário não concorda com alegação do pagador",
+	InstructionWaiveInterest:             "Beneficiário solicita dispensa de juros",
+	InstructionChangeExtraData:           "Alteração de dados extras (registro de multa)",
+	InstructionNegative:                  "Entrada em negativação expressa",
+	InstructionDontNegative:              "Não negativar",
+	InstructionRemoveNegative:            "Excluir negativação expressa",
+	InstructionCancelNegative:            "Cancelar negativação expressa",
+}
+
+// Description returns the description of the instruction code, or an empty
+// string for unknown codes.
+func (i InstructionCode) Description() string {
+	return instructionDescriptions[i]
+}
+
+// OccurrenceCode is the occurrence code of a título in a return file (código
+// de ocorrência), informing what happened to the título.
+type OccurrenceCode string
+
+// List of occurrence codes returned by Itaú. The fee occurrences (tarifas) are
+// not listed.
+const (
+	OccurrenceEntryConfirmed           OccurrenceCode = "02"
+	OccurrenceEntryRejected            OccurrenceCode = "03"
+	OccurrenceDataChanged              OccurrenceCode = "04"
+	OccurrenceDataChangedWriteOff      OccurrenceCode = "05"
+	OccurrenceSettlement               OccurrenceCode = "06"
+	OccurrencePartialSettlement        OccurrenceCode = "07"
+	OccurrenceNotarySettlement         OccurrenceCode = "08"
+	OccurrenceWriteOff                 OccurrenceCode = "09"
+	OccurrenceWriteOffSettled          OccurrenceCode = "10"
+	OccurrenceTitleInWallet            OccurrenceCode = "11"
+	OccurrenceRebateGranted            OccurrenceCode = "12"
+	OccurrenceRebateCancelled          OccurrenceCode = "13"
+	OccurrenceDueDateChanged           OccurrenceCode = "14"
+	OccurrenceWriteOffRejected         OccurrenceCode = "15"
+	OccurrenceInstructionRejected      OccurrenceCode = "16"
+	OccurrenceDataChangeRejected       OccurrenceCode = "17"
+	OccurrenceContractRejected         OccurrenceCode = "18"
+	OccurrenceProtestConfirmed         OccurrenceCode = "19"
+	OccurrenceStopProtestConfirmed     OccurrenceCode = "20"
+	OccurrenceDontProtestConfirmed     OccurrenceCode = "21"
+	OccurrenceSentToNotary             OccurrenceCode = "23"
+	OccurrenceProtestRejected          OccurrenceCode = "24"
+	OccurrencePayerAllegation          OccurrenceCode = "25"
+	OccurrenceWriteOffProtested        OccurrenceCode = "32"
+	OccurrenceWriteOffToDiscount       OccurrenceCode = "47"
+	OccurrenceInstructionCancelled     OccurrenceCode = "57"
+	OccurrenceWriteOffSispag           OccurrenceCode = "59"
+	OccurrenceCarneEntryRejected       OccurrenceCode = "60"
+	OccurrenceJudicialStop             OccurrenceCode = "63"
+	OccurrenceEntryWithApportionment   OccurrenceCode = "64"
+	OccurrenceCheckClearing            OccurrenceCode = "65"
+	OccurrenceCheckReturned            OccurrenceCode = "69"
+	OccurrenceEntryUnderReview         OccurrenceCode = "71"
+	OccurrenceWriteOffSispagNoTitle    OccurrenceCode = "72"
+	OccurrenceSimpleEntryConfirmed     OccurrenceCode = "73"
+	OccurrenceNegativeRejected         OccurrenceCode = "74"
+	OccurrenceNegativeConfirmed        OccurrenceCode = "75"
+	OccurrenceCheckCleared             OccurrenceCode = "76"
+	OccurrenceNegativeRemovalConfirmed OccurrenceCode = "77"
+	OccurrenceNegativeCancelConfirmed  OccurrenceCode = "78"
+	OccurrenceNegativeInformation      OccurrenceCode = "79"
+)
+
+var occurrenceDescriptions = map[OccurrenceCode]string{
+	OccurrenceEntryConfirmed:           "Entrada confirmada",
+	OccurrenceEntryRejected:            "Entrada rejeitada",
+	OccurrenceDataChanged:              "Alteração de dados - nova entrada ou alteração/exclusão de dados acatada",
+	OccurrenceDataChangedWriteOff:      "Alteração de dados - baixa",
+	OccurrenceSettlement:               "Liquidação normal",
+	OccurrencePartialSettlement:        "Liquidação parcial - cobrança inteligente",
+	OccurrenceNotarySettlement:         "Liquidação em cartório",
+	OccurrenceWriteOff:                 "Baixa simples",
+	OccurrenceWriteOffSettled:          "Baixa por ter sido liquidado",
+	OccurrenceTitleInWallet:            "Em ser",
+	OccurrenceRebateGranted:            "Abatimento concedido",
+	OccurrenceRebateCancelled:          "Abatimento cancelado",
+	OccurrenceDueDateChanged:           "Vencimento alterado",
+	OccurrenceWriteOffRejected:         "Baixas rejeitadas",
+	OccurrenceInstructionRejected:      "Instruções rejeitadas",
+	OccurrenceDataChangeRejected:       "Alteração/exclusão de dados rejeitados",
+	OccurrenceContractRejected:         "Cobrança contratual - instruções/alterações rejeitadas/pendentes",
+	OccurrenceProtestConfirmed:         "Confirma recebimento de instrução de protesto",
+	OccurrenceStopProtestConfirmed:     "Confirma recebimento de instrução de sustação de protesto",
+	OccurrenceDontProtestConfirmed:     "Confirma recebimento de instrução de não protestar",
+	OccurrenceSentToNotary:             "Título enviado a cartório",
+	OccurrenceProtestRejected:          "Instrução de protesto rejeitada/sustada/pendente",
+	OccurrencePayerAllegation:          "Alegações do pagador",
+	OccurrenceWriteOffProtested:        "Baixa por ter sido protestado",
+	OccurrenceWriteOffToDiscount:       "Baixa com transferência para desconto",
+	OccurrenceInstructionCancelled:     "Instrução cancelada",
+	OccurrenceWriteOffSispag:           "Baixa por crédito em c/c através do SISPAG",
+	OccurrenceCarneEntryRejected:       "Entrada rejeitada carnê",
+	OccurrenceJudicialStop:             "Título sustado judicialmente",
+	OccurrenceEntryWithApportionment:   "Entrada confirmada com rateio de crédito",
+	OccurrenceCheckClearing:            "Pagamento com cheque - aguardando compensação",
+	OccurrenceCheckReturned:            "Cheque devolvido",
+	OccurrenceEntryUnderReview:         "Entrada registrada, aguardando avaliação",
+	OccurrenceWriteOffSispagNoTitle:    "Baixa por crédito em c/c através do SISPAG sem título correspondente",
+	OccurrenceSimpleEntryConfirmed:     "Confirmação de entrada na cobrança simples - entrada não aceita na cobrança contratual",
+	OccurrenceNegativeRejected:         "Instrução de negativação expressa rejeitada",
+	OccurrenceNegativeConfirmed:        "Confirmação de recebimento de instrução de entrada em negativação expressa",
+	OccurrenceCheckCleared:             "Cheque compensado",
+	OccurrenceNegativeRemovalConfirmed: "Confirmação de recebimento de instrução de exclusão de entrada em negativação expressa",
+	OccurrenceNegativeCancelConfirmed:  "Confirmação de recebimento de instrução de cancelamento de negativação expressa",
+	OccurrenceNegativeInformation:      "Negativação expressa informacional",
+}
+
+// Description returns the description of the occurrence code, or an empty
+// string for unknown codes.
+func (o OccurrenceCode) Description() string {
+	return occurrenceDescriptions[o]
+}
+
+// ErrorCode is the reason of a rejected entry (erro), returned with the
+// occurrence 03.
+type ErrorCode string
+
+// List of error codes returned by Itaú for rejected entries.
+const (
+	ErrorInvalidZipCodeAgency       ErrorCode = "03"
+	ErrorInvalidState               ErrorCode = "04"
+	ErrorInvalidDueDateTerm         ErrorCode = "05"
+	ErrorAmountTooHigh              ErrorCode = "07"
+	ErrorMissingPayerName           ErrorCode = "08"
+	ErrorClosedAgency               ErrorCode = "09"
+	ErrorMissingStreet              ErrorCode = "10"
+	ErrorInvalidZipCode             ErrorCode = "11"
+	ErrorMissingGuarantorName       ErrorCode = "12"
+	ErrorZipCodeStateMismatch       ErrorCode = "13"
+	ErrorOurNumberRegistered        ErrorCode = "14"
+	ErrorDuplicatedOurNumber        ErrorCode = "15"
+	ErrorInvalidEntryDate           ErrorCode = "18"
+	ErrorInvalidOccurrence          ErrorCode = "19"
+	ErrorInvalidCollectingAgency    ErrorCode = "21"
+	ErrorWalletNotAllowed           ErrorCode = "22"
+	ErrorAccountNotAllowed          ErrorCode = "26"
+	ErrorUnfitCNPJ                  ErrorCode = "27"
+	ErrorIOFTooHigh                 ErrorCode = "35"
+	ErrorInvalidCurrencyQuantity    ErrorCode = "36"
+	ErrorInvalidPayerDocument       ErrorCode = "37"
+	ErrorOurNumberOutOfRange        ErrorCode = "42"
+	ErrorCorrespondentNotAccepted   ErrorCode = "52"
+	ErrorDueDateTooSoon             ErrorCode = "54"
+	ErrorDueDateTooLate             ErrorCode = "56"
+	ErrorInvalidRebate              ErrorCode = "60"
+	ErrorInterestTooHigh            ErrorCode = "61"
+	ErrorDiscountTooHigh            ErrorCode = "62"
+	ErrorDailyDiscountNotAllowed    ErrorCode = "63"
+	ErrorInvalidIssueDate           ErrorCode = "64"
+	ErrorInvalidDueDate             ErrorCode = "66"
+	ErrorInvalidAmount              ErrorCode = "67"
+	ErrorInvalidWallet              ErrorCode = "68"
+	ErrorInvalidApportionmentWallet ErrorCode = "69"
+	ErrorApportionmentNotAllowed    ErrorCode = "70"
+	ErrorInvalidMessageLine         ErrorCode = "90"
+	ErrorMissingMessage             ErrorCode = "97"
+	ErrorInvalidFlash               ErrorCode = "98"
+)
+
+var errorDescriptions = map[ErrorCode]string{
+	ErrorInvalidZipCodeAgency:       "Não foi possível atribuir a agência pelo CEP ou CEP inválido",
+	ErrorInvalidState:               "Sigla do estado inválida",
+	ErrorInvalidDueDateTerm:         "Prazo da operação menor que o prazo mínimo ou maior que o máximo",
+	ErrorAmountTooHigh:              "Valor do título maior que 10.000.000,00",
+	ErrorMissingPayerName:           "Nome do pagador não informado ou deslocado",
+	ErrorClosedAgency:               "Agência encerrada",
+	ErrorMissingStreet:              "Logradouro não informado ou deslocado",
+	ErrorInvalidZipCode:             "CEP não numérico ou inválido",
+	ErrorMissingGuarantorName:       "Nome do sacador/avalista não informado ou deslocado",
+	ErrorZipCodeStateMismatch:       "CEP incompatível com a sigla do estado",
+	ErrorOurNumberRegistered:        "Nosso número já registrado no cadastro do banco ou fora da faixa",
+	ErrorDuplicatedOurNumber:        "Nosso número em duplicidade no mesmo movimento",
+	ErrorInvalidEntryDate:           "Data de entrada inválida para operar com esta carteira",
+	ErrorInvalidOccurrence:          "Ocorrência inválida",
+	ErrorInvalidCollectingAgency:    "Agência cobradora não consta no cadastro ou encerrando",
+	ErrorWalletNotAllowed:           "Carteira não permitida",
+	ErrorAccountNotAllowed:          "Agência/conta não liberada para operar com cobrança",
+	ErrorUnfitCNPJ:                  "CNPJ do beneficiário inapto",
+	ErrorIOFTooHigh:                 "IOF maior que 5%",
+	ErrorInvalidCurrencyQuantity:    "Quantidade de moeda incompatível com o valor do título",
+	ErrorInvalidPayerDocument:       "CNPJ/CPF do pagador não numérico ou igual a zeros",
+	ErrorOurNumberOutOfRange:        "Nosso número fora de faixa",
+	ErrorCorrespondentNotAccepted:   "Empresa não aceita banco correspondente",
+	ErrorDueDateTooSoon:             "Banco correspondente - título com vencimento inferior a 15 dias",
+	ErrorDueDateTooLate:             "Vencimento superior a 180 dias da data de entrada",
+	ErrorInvalidRebate:              "Valor do abatimento inválido",
+	ErrorInterestTooHigh:            "Juros de mora maior que o permitido",
+	ErrorDiscountTooHigh:            "Valor do desconto maior que o valor do título",
+	ErrorDailyDiscountNotAllowed:    "Valor da importância por dia de desconto não permitido",
+	ErrorInvalidIssueDate:           "Data de emissão do título inválida",
+	ErrorInvalidDueDate:             "Data de vencimento inválida ou fora do prazo de operação",
+	ErrorInvalidAmount:              "Valor do título ou quantidade de moeda inválido",
+	ErrorInvalidWallet:              "Carteira inválida ou não cadastrada no intercâmbio da cobrança",
+	ErrorInvalidApportionmentWallet: "Carteira inválida para títulos com rateio de crédito",
+	ErrorApportionmentNotAllowed:    "Beneficiário não cadastrado para fazer rateio de crédito",
+	ErrorInvalidMessageLine:         "Número da linha da mensagem inválido ou quantidade de linhas excedida",
+	ErrorMissingMessage:             "Cobrança mensagem sem mensagem",
+	ErrorInvalidFlash:               "Registro de mensagem sem flash cadastrado ou diferente do cadastrado",
+}
+
+// Description returns the description of the error code, or an empty string
+// for unknown codes.
+func (e ErrorCode) Description() string {
+	return errorDescriptions[e]
+}
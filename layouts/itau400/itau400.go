@@ -0,0 +1,336 @@
+// Package itau400 contains the record types of the Itaú CNAB 400 cobrança
+// layout, ready to be used with the cnab400 package.
+//
+// Differently from other banks, Itaú identifies the carteira by its number
+// and by a code (see WalletCode), and the nosso número has 8 digits with a
+// check digit (DAC) that is only informed in the return file (see
+// OurNumberDigit).
+//
+//	file := cnab400.File{
+//	  Header: itau400.Header{...},
+//	  Details: []cnab400.Detail{
+//	    itau400.Title{Detail: itau400.Detail{...}, Fine: &itau400.Fine{...}},
+//	  },
+//	  Trailer: itau400.Trailer{},
+//	}
+//
+//	data, err := cnab400.Marshal(file)
+package itau400
+
+import (
+	"fmt"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/cnab400"
+)
+
+// BankCode is the code of Itaú in the clearing system.
+const BankCode = "341"
+
+// Record types of the details.
+const (
+	RecordTypeDetail = "1"
+	RecordTypeFine   = "2"
+)
+
+// DocumentType identifies the type of the document (inscrição) of the payer or
+// of the company.
+type DocumentType int
+
+// List of document types.
+const (
+	DocumentTypeCPF  DocumentType = 1
+	DocumentTypeCNPJ DocumentType = 2
+)
+
+// walletCodes stores the carteiras that don't use the default code "I".
+var walletCodes = map[int]string{
+	147: "E",
+	150: "U",
+	191: "1",
+}
+
+// WalletCode returns the code of the carteira (código da carteira) that is
+// informed together with its number. Carteiras not listed by Itaú use the
+// code "I".
+func WalletCode(wallet int) string {
+	if code, ok := walletCodes[wallet]; ok {
+		return code
+	}
+	return "I"
+}
+
+// ourNumberWalletOnly stores the carteiras that calculate the DAC of the nosso
+// número without the agency and account.
+var ourNumberWalletOnly = map[int]bool{
+	126: true,
+	131: true,
+	146: true,
+	150: true,
+	168: true,
+}
+
+// OurNumberDigit returns the check digit (DAC) of the nosso número, using the
+// module 10 of the agency, account, carteira and nosso número. For the
+// carteiras 126, 131, 146, 150 and 168 only the carteira and the nosso número
+// are used.
+func OurNumberDigit(agency, account, wallet int, ourNumber int64) int {
+	digits := fmt.Sprintf("%03d%08d", wallet, ourNumber)
+	if !ourNumberWalletOnly[wallet] {
+		digits = fmt.Sprintf("%04d%05d", agency, account) + digits
+	}
+
+	var sum int
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if (len(digits)-1-i)%2 == 0 {
+			digit *= 2
+		}
+		sum += digit/10 + digit%10
+	}
+
+	return (10 - sum%10) % 10
+}
+
+// Header is the header of a remittance file (registro 0).
+type Header struct {
+	_             string       `cnab:"0,1,const=0"`
+	_             string       `cnab:"1,2,const=1"`
+	_             string       `cnab:"2,9,const=REMESSA"`
+	_             string       `cnab:"9,11,const=01"`
+	_             string       `cnab:"11,26,const=COBRANCA"`
+	Agency        int          `cnab:"26,30"`
+	_             string       `cnab:"30,32,const=00"`
+	Account       int          `cnab:"32,37"`
+	AccountDigit  string       `cnab:"37,38"`
+	_             string       `cnab:"38,46"`
+	CompanyName   string       `cnab:"46,76"`
+	_             string       `cnab:"76,79,const=341"`
+	_             string       `cnab:"79,94,const=BANCO ITAU SA"`
+	RecordingDate cnab400.Date `cnab:"94,100"`
+	_             string       `cnab:"100,394"`
+	Sequence      int          `cnab:"394,400"`
+}
+
+// Detail contains the data of a título in a remittance file (registro 1). When
+// the code of the carteira is empty it is filled with WalletCode.
+type Detail struct {
+	_                    string          `cnab:"0,1,const=1"`
+	CompanyDocumentType  DocumentType    `cnab:"1,3"`
+	CompanyDocument      int64           `cnab:"3,17"`
+	Agency               int             `cnab:"17,21"`
+	_                    string          `cnab:"21,23,const=00"`
+	Account              int             `cnab:"23,28"`
+	AccountDigit         string          `cnab:"28,29"`
+	_                    string          `cnab:"29,33"`
+	CancelledInstruction string          `cnab:"33,37"`
+	CompanyUse           string          `cnab:"37,62"`
+	OurNumber            int64           `cnab:"62,70"`
+	CurrencyQuantity     int64           `cnab:"70,83"`
+	Wallet               int             `cnab:"83,86"`
+	BankUse              string          `cnab:"86,107"`
+	WalletCode           string          `cnab:"107,108"`
+	Occurrence           InstructionCode `cnab:"108,110"`
+	DocumentNumber       string          `cnab:"110,120"`
+	DueDate              cnab400.Date    `cnab:"120,126"`
+	Amount               float64         `cnab:"126,139"`
+	_                    string          `cnab:"139,142,const=341"`
+	_                    string          `cnab:"142,147,const=00000"`
+	TitleKind            string          `cnab:"147,149"`
+	Acceptance           string          `cnab:"149,150"`
+	IssueDate            cnab400.Date    `cnab:"150,156"`
+	Instruction1         string          `cnab:"156,158"`
+	Instruction2         string          `cnab:"158,160"`
+	DailyInterest        float64         `cnab:"160,173"`
+	DiscountDate         cnab400.Date    `cnab:"173,179"`
+	Discount             float64         `cnab:"179,192"`
+	IOF                  float64         `cnab:"192,205"`
+	Rebate               float64         `cnab:"205,218"`
+	PayerDocumentType    DocumentType    `cnab:"218,220"`
+	PayerDocument        int64           `cnab:"220,234"`
+	PayerName            string          `cnab:"234,264"`
+	_                    string          `cnab:"264,274"`
+	PayerStreet          string          `cnab:"274,314"`
+	PayerDistrict        string          `cnab:"314,326"`
+	PayerZipCode         int             `cnab:"326,334"`
+	PayerCity            string          `cnab:"334,349"`
+	PayerState           string          `cnab:"349,351"`
+	Guarantor            string          `cnab:"351,381"`
+	_                    string          `cnab:"381,385"`
+	InterestDate         cnab400.Date    `cnab:"385,391"`
+	Days                 int             `cnab:"391,393"`
+	_                    string          `cnab:"393,394"`
+	Sequence             int             `cnab:"394,400"`
+}
+
+// BeforeMarshalCNAB fills the code of the carteira from its number.
+func (d *Detail) BeforeMarshalCNAB() error {
+	if d.WalletCode == "" {
+		d.WalletCode = WalletCode(d.Wallet)
+	}
+	return nil
+}
+
+// Fine contains the fine of a título (registro 2, multa), an optional record
+// that follows the detail. The date of the fine is in the format DDMMAAAA.
+type Fine struct {
+	_        string       `cnab:"0,1,const=2"`
+	Code     int          `cnab:"1,2"`
+	Date     cnab240.Date `cnab:"2,10"`
+	Value    float64      `cnab:"10,23"`
+	_        string       `cnab:"23,394"`
+	Sequence int          `cnab:"394,400"`
+}
+
+// Trailer is the trailer of a remittance file (registro 9).
+type Trailer struct {
+	_        string `cnab:"0,1,const=9"`
+	_        string `cnab:"1,394"`
+	Sequence int    `cnab:"394,400"`
+}
+
+// Title is a título of a remittance, composed by the consecutive records 1
+// and optionally 2.
+type Title struct {
+	Detail Detail `cnab:"line"`
+	Fine   *Fine  `cnab:"line"`
+}
+
+// ReturnHeader is the header of a return file (registro 0).
+type ReturnHeader struct {
+	_             string       `cnab:"0,1,const=0"`
+	_             string       `cnab:"1,2,const=2"`
+	_             string       `cnab:"2,9,const=RETORNO"`
+	_             string       `cnab:"9,11,const=01"`
+	_             string       `cnab:"11,26,const=COBRANCA"`
+	Agency        int          `cnab:"26,30"`
+	_             string       `cnab:"30,32,const=00"`
+	Account       int          `cnab:"32,37"`
+	AccountDigit  string       `cnab:"37,38"`
+	_             string       `cnab:"38,46"`
+	CompanyName   string       `cnab:"46,76"`
+	_             string       `cnab:"76,79,const=341"`
+	_             string       `cnab:"79,94,const=BANCO ITAU SA"`
+	RecordingDate cnab400.Date `cnab:"94,100"`
+	Density       int          `cnab:"100,105"`
+	DensityUnit   string       `cnab:"105,108"`
+	FileSequence  int          `cnab:"108,113"`
+	CreditDate    cnab400.Date `cnab:"113,119"`
+	_             string       `cnab:"119,394"`
+	Sequence      int          `cnab:"394,400"`
+}
+
+// ReturnDetail contains the occurrence of a título in a return file (registro
+// 1).
+type ReturnDetail struct {
+	_                     string         `cnab:"0,1,const=1"`
+	CompanyDocumentType   DocumentType   `cnab:"1,3"`
+	CompanyDocument       int64          `cnab:"3,17"`
+	Agency                int            `cnab:"17,21"`
+	_                     string         `cnab:"21,23,const=00"`
+	Account               int            `cnab:"23,28"`
+	AccountDigit          string         `cnab:"28,29"`
+	_                     string         `cnab:"29,37"`
+	CompanyUse            string         `cnab:"37,62"`
+	OurNumber             int64          `cnab:"62,70"`
+	_                     string         `cnab:"70,82"`
+	Wallet                int            `cnab:"82,85"`
+	BankOurNumber         int64          `cnab:"85,93"`
+	OurNumberDigit        int            `cnab:"93,94"`
+	_                     string         `cnab:"94,107"`
+	WalletCode            string         `cnab:"107,108"`
+	Occurrence            OccurrenceCode `cnab:"108,110"`
+	OccurrenceDate        cnab400.Date   `cnab:"110,116"`
+	DocumentNumber        string         `cnab:"116,126"`
+	ConfirmedOurNumber    int64          `cnab:"126,134"`
+	_                     string         `cnab:"134,146"`
+	DueDate               cnab400.Date   `cnab:"146,152"`
+	Amount                float64        `cnab:"152,165"`
+	CollectingBank        int            `cnab:"165,168"`
+	CollectingAgency      int            `cnab:"168,172"`
+	CollectingAgencyDigit string         `cnab:"172,173"`
+	TitleKind             string         `cnab:"173,175"`
+	Fee                   float64        `cnab:"175,188"`
+	_                     string         `cnab:"188,214"`
+	IOF                   float64        `cnab:"214,227"`
+	Rebate                float64        `cnab:"227,240"`
+	Discount              float64        `cnab:"240,253"`
+	PaidAmount            float64        `cnab:"253,266"`
+	Interest              float64        `cnab:"266,279"`
+	OtherCredits          float64        `cnab:"279,292"`
+	DDA                   string         `cnab:"292,293"`
+	_                     string         `cnab:"293,295"`
+	CreditDate            cnab400.Date   `cnab:"295,301"`
+	CancelledInstruction  string         `cnab:"301,305"`
+	_                     string         `cnab:"305,311"`
+	_                     string         `cnab:"311,324"`
+	PayerName             string         `cnab:"324,354"`
+	_                     string         `cnab:"354,377"`
+	ErrorCodes            string         `cnab:"377,385"`
+	_                     string         `cnab:"385,392"`
+	SettlementCode        string         `cnab:"392,394"`
+	Sequence              int            `cnab:"394,400"`
+}
+
+// Errors returns the codes of the errors of a rejected entry or instruction
+// (erros/mensagem informativa), that are stored in groups of 2 characters.
+// Empty and zeroed codes are ignored.
+func (r ReturnDetail) Errors() []ErrorCode {
+	var codes []ErrorCode
+	for i := 0; i+2 <= len(r.ErrorCodes); i += 2 {
+		code := r.ErrorCodes[i : i+2]
+		if code != "00" && code != "  " {
+			codes = append(codes, ErrorCode(code))
+		}
+	}
+	return codes
+}
+
+// ReturnTrailer is the trailer of a return file (registro 9), with the
+// summary of each type of cobrança.
+type ReturnTrailer struct {
+	_            string  `cnab:"0,1,const=9"`
+	_            string  `cnab:"1,2,const=2"`
+	_            string  `cnab:"2,4,const=01"`
+	_            string  `cnab:"4,7,const=341"`
+	_            string  `cnab:"7,17"`
+	SimpleCount  int     `cnab:"17,25"`
+	SimpleAmount float64 `cnab:"25,39"`
+	SimpleNotice string  `cnab:"39,47"`
+	_            string  `cnab:"47,57"`
+	LinkedCount  int     `cnab:"57,65"`
+	LinkedAmount float64 `cnab:"65,79"`
+	LinkedNotice string  `cnab:"79,87"`
+	_            string  `cnab:"87,177"`
+	DirectCount  int     `cnab:"177,185"`
+	DirectAmount float64 `cnab:"185,199"`
+	DirectNotice string  `cnab:"199,207"`
+	FileSequence int     `cnab:"207,212"`
+	Details      int     `cnab:"212,220"`
+	TotalAmount  float64 `cnab:"220,234"`
+	_            string  `cnab:"234,394"`
+	Sequence     int     `cnab:"394,400"`
+}
+
+// NewRemittanceMapper returns a mapper with the remittance record types, to
+// be used with cnab400.Unmarshal. The títulos are decoded as Title, and fine
+// records that aren't part of one are decoded individually.
+func NewRemittanceMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*Header)(nil), cnab400.MatchRecordType(cnab400.RecordTypeHeader))
+	mapper.Register((*Title)(nil), cnab400.MatchRecordType(RecordTypeDetail))
+	mapper.Register((*Fine)(nil), cnab400.MatchRecordType(RecordTypeFine))
+	mapper.Register((*Trailer)(nil), cnab400.MatchRecordType(cnab400.RecordTypeTrailer))
+	return mapper
+}
+
+// NewReturnMapper returns a mapper with the return record types, to be used
+// with cnab400.Unmarshal.
+func NewReturnMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*ReturnHeader)(nil), cnab400.MatchRecordType(cnab400.RecordTypeHeader))
+	mapper.Register((*ReturnDetail)(nil), cnab400.MatchRecordType(RecordTypeDetail))
+	mapper.Register((*ReturnTrailer)(nil), cnab400.MatchRecordType(cnab400.RecordTypeTrailer))
+	return mapper
+}
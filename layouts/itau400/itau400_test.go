@@ -0,0 +1,502 @@
+package itau400_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/cnab400"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/itau400"
+)
+
+func TestLayouts(t *testing.T) {
+	t.Parallel()
+
+	layouttest.CheckCoverage(t, cnab400.LineSize,
+		itau400.Header{},
+		itau400.Detail{},
+		itau400.Fine{},
+		itau400.Trailer{},
+		itau400.ReturnHeader{},
+		itau400.ReturnDetail{},
+		itau400.ReturnTrailer{},
+	)
+}
+
+func TestOurNumberDigit(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		agency      int
+		account     int
+		wallet      int
+		ourNumber   int64
+		expected    int
+	}{
+		{
+			description: "it should use the agency and account",
+			agency:      57,
+			account:     12345,
+			wallet:      110,
+			ourNumber:   12345678,
+			expected:    8,
+		},
+		{
+			description: "it should use only the carteira for the special carteiras",
+			agency:      57,
+			account:     12345,
+			wallet:      126,
+			ourNumber:   12345678,
+			expected:    5,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			digit := itau400.OurNumberDigit(scenario.agency, scenario.account, scenario.wallet, scenario.ourNumber)
+			if digit != scenario.expected {
+				t.Errorf("expected digit “%d” and got “%d”", scenario.expected, digit)
+			}
+		})
+	}
+}
+
+func TestRemittance(t *testing.T) {
+	t.Parallel()
+
+	file := cnab400.File{
+		Header: itau400.Header{
+			Agency:        57,
+			Account:       12345,
+			AccountDigit:  "7",
+			CompanyName:   "EMPRESA EXEMPLO LTDA",
+			RecordingDate: cnab400.NewDate(2026, 10, 18),
+			Sequence:      1,
+		},
+		Details: []cnab400.Detail{
+			itau400.Title{
+				Detail: itau400.Detail{
+					CompanyDocumentType: itau400.DocumentTypeCNPJ,
+					CompanyDocument:     12345678000195,
+					Agency:              57,
+					Account:             12345,
+					AccountDigit:        "7",
+					CompanyUse:          "PEDIDO 1001",
+					OurNumber:           12345678,
+					Wallet:              109,
+					WalletCode:          "I",
+					Occurrence:          itau400.InstructionEntry,
+					DocumentNumber:      "NF-1001",
+					DueDate:             cnab400.NewDate(2026, 11, 10),
+					Amount:              1500.75,
+					TitleKind:           "01",
+					Acceptance:          "N",
+					IssueDate:           cnab400.NewDate(2026, 10, 18),
+					DailyInterest:       0.5,
+					PayerDocumentType:   itau400.DocumentTypeCPF,
+					PayerDocument:       12345678909,
+					PayerName:           "FULANO DE TAL",
+					PayerStreet:         "RUA DAS FLORES 100",
+					PayerDistrict:       "CENTRO",
+					PayerZipCode:        1001000,
+					PayerCity:           "SAO PAULO",
+					PayerState:          "SP",
+					Sequence:            2,
+				},
+				Fine: &itau400.Fine{
+					Code:     2,
+					Date:     cnab240.NewDate(2026, 11, 11),
+					Value:    2,
+					Sequence: 3,
+				},
+			},
+			itau400.Title{
+				Detail: itau400.Detail{
+					CompanyDocumentType: itau400.DocumentTypeCNPJ,
+					CompanyDocument:     12345678000195,
+					Agency:              57,
+					Account:             12345,
+					AccountDigit:        "7",
+					CompanyUse:          "PEDIDO 1002",
+					OurNumber:           12345679,
+					Wallet:              147,
+					WalletCode:          "E",
+					Occurrence:          itau400.InstructionEntry,
+					DocumentNumber:      "NF-1002",
+					DueDate:             cnab400.NewDate(2026, 11, 20),
+					Amount:              99.9,
+					TitleKind:           "01",
+					Acceptance:          "N",
+					IssueDate:           cnab400.NewDate(2026, 10, 18),
+					PayerDocumentType:   itau400.DocumentTypeCNPJ,
+					PayerDocument:       98765432000198,
+					PayerName:           "CLIENTE EXEMPLO SA",
+					PayerStreet:         "AVENIDA CENTRAL 200",
+					PayerZipCode:        20040020,
+					PayerCity:           "RIO DE JANEIRO",
+					PayerState:          "RJ",
+					Sequence:            4,
+				},
+			},
+		},
+		Trailer: itau400.Trailer{
+			Sequence: 5,
+		},
+	}
+
+	data, err := cnab400.Marshal(layouttest.WithoutFields(file, layouttest.CNAB400ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "remessa.golden", data)
+
+	decoded, err := cnab400.Unmarshal(golden, itau400.NewRemittanceMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+}
+
+func TestDetail_walletCode(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		wallet      int
+		expected    string
+	}{
+		{description: "it should use the default code", wallet: 109, expected: "I"},
+		{description: "it should use the code of the escritural carteira", wallet: 147, expected: "E"},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			data, err := gocnab.Marshal400(itau400.Detail{Wallet: scenario.wallet})
+			if err != nil {
+				t.Fatalf("unexpected error. details: %s", err)
+			}
+
+			if code := string(data[107:108]); code != scenario.expected {
+				t.Errorf("expected carteira code “%s” and got “%s”", scenario.expected, code)
+			}
+		})
+	}
+}
+
+func TestReturn(t *testing.T) {
+	t.Parallel()
+
+	file := cnab400.File{
+		Header: itau400.ReturnHeader{
+			Agency:        57,
+			Account:       12345,
+			AccountDigit:  "7",
+			CompanyName:   "EMPRESA EXEMPLO LTDA",
+			RecordingDate: cnab400.NewDate(2026, 11, 12),
+			Density:       1600,
+			DensityUnit:   "BPI",
+			FileSequence:  15,
+			CreditDate:    cnab400.NewDate(2026, 11, 13),
+			Sequence:      1,
+		},
+		Details: []cnab400.Detail{
+			itau400.ReturnDetail{
+				CompanyDocumentType:   itau400.DocumentTypeCNPJ,
+				CompanyDocument:       12345678000195,
+				Agency:                57,
+				Account:               12345,
+				AccountDigit:          "7",
+				CompanyUse:            "PEDIDO 1001",
+				OurNumber:             12345678,
+				Wallet:                109,
+				BankOurNumber:         12345678,
+				OurNumberDigit:        itau400.OurNumberDigit(57, 12345, 109, 12345678),
+				WalletCode:            "I",
+				Occurrence:            itau400.OccurrenceSettlement,
+				OccurrenceDate:        cnab400.NewDate(2026, 11, 12),
+				DocumentNumber:        "NF-1001",
+				ConfirmedOurNumber:    12345678,
+				DueDate:               cnab400.NewDate(2026, 11, 10),
+				Amount:                1500.75,
+				CollectingBank:        341,
+				CollectingAgency:      4321,
+				CollectingAgencyDigit: "0",
+				TitleKind:             "01",
+				Fee:                   2.5,
+				PaidAmount:            1500.75,
+				Interest:              3,
+				CreditDate:            cnab400.NewDate(2026, 11, 13),
+				PayerName:             "FULANO DE TAL",
+				SettlementCode:        "AA",
+				Sequence:              2,
+			},
+			itau400.ReturnDetail{
+				CompanyDocumentType: itau400.DocumentTypeCNPJ,
+				CompanyDocument:     12345678000195,
+				Agency:              57,
+				Account:             12345,
+				AccountDigit:        "7",
+				CompanyUse:          "PEDIDO 1002",
+				OurNumber:           12345679,
+				Wallet:              147,
+				WalletCode:          "E",
+				Occurrence:          itau400.OccurrenceEntryRejected,
+				OccurrenceDate:      cnab400.NewDate(2026, 10, 19),
+				DocumentNumber:      "NF-1002",
+				DueDate:             cnab400.NewDate(2026, 11, 20),
+				Amount:              99.9,
+				PayerName:           "CLIENTE EXEMPLO SA",
+				ErrorCodes:          "1113",
+				Sequence:            3,
+			},
+		},
+		Trailer: itau400.ReturnTrailer{
+			SimpleCount:  2,
+			SimpleAmount: 1600.65,
+			FileSequence: 15,
+			Details:      2,
+			TotalAmount:  1600.65,
+			Sequence:     4,
+		},
+	}
+
+	data, err := cnab400.Marshal(layouttest.WithoutFields(file, layouttest.CNAB400ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "retorno.golden", data)
+
+	decoded, err := cnab400.Unmarshal(golden, itau400.NewReturnMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+
+	rejected := decoded.Details[1].(itau400.ReturnDetail)
+	expectedErrors := []itau400.ErrorCode{itau400.ErrorInvalidZipCode, itau400.ErrorZipCodeStateMismatch}
+	if codes := rejected.Errors(); !reflect.DeepEqual(expectedErrors, codes) {
+		t.Errorf("unexpected errors “%v”", codes)
+	}
+
+	if description := rejected.Errors()[0].Description(); description != "CEP não numérico ou inválido" {
+		t.Errorf("unexpected error description “%s”", description)
+	}
+}
+
+func TestSpec(t *testing.T) {
+	t.Parallel()
+
+	// lines assembled from the tables of the Itaú CNAB 400 cobrança
+	// specification, independently of the encoder
+	scenarios := []struct {
+		description string
+		line        func(t *testing.T) []byte
+		expected    interface{}
+	}{
+		{
+			description: "it should decode the remittance detail",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab400.LineSize,
+					layouttest.Text(1, 1, "1"),                      // tipo de registro
+					layouttest.Number(2, 3, 2),                      // código de inscrição
+					layouttest.Number(4, 17, 12345678000195),        // número de inscrição
+					layouttest.Number(18, 21, 1234),                 // agência mantenedora da conta
+					layouttest.Number(22, 23, 0),                    // complemento de registro
+					layouttest.Number(24, 28, 67890),                // número da conta corrente
+					layouttest.Text(29, 29, "1"),                    // dígito de auto conferência ag/conta
+					layouttest.Text(30, 33, ""),                     // complemento de registro
+					layouttest.Text(34, 37, "0000"),                 // código da instrução/alegação a ser cancelada
+					layouttest.Text(38, 62, "PEDIDO 1001"),          // identificação do título na empresa
+					layouttest.Number(63, 70, 12345678),             // identificação do título no banco
+					layouttest.Number(71, 83, 0),                    // quantidade de moeda variável
+					layouttest.Number(84, 86, 109),                  // número da carteira no banco
+					layouttest.Text(87, 107, ""),                    // identificação da operação no banco
+					layouttest.Text(108, 108, "I"),                  // código da carteira
+					layouttest.Text(109, 110, "01"),                 // identificação da ocorrência
+					layouttest.Text(111, 120, "NF-1001"),            // número do documento de cobrança
+					layouttest.Text(121, 126, "101126"),             // data de vencimento do título
+					layouttest.Number(127, 139, 150075),             // valor nominal do título
+					layouttest.Number(140, 142, 341),                // número do banco na câmara de compensação
+					layouttest.Number(143, 147, 0),                  // agência onde o título será cobrado
+					layouttest.Text(148, 149, "01"),                 // espécie do título
+					layouttest.Text(150, 150, "N"),                  // identificação de título aceito ou não aceito
+					layouttest.Text(151, 156, "181026"),             // data da emissão do título
+					layouttest.Text(157, 158, "09"),                 // 1ª instrução de cobrança
+					layouttest.Text(159, 160, "00"),                 // 2ª instrução de cobrança
+					layouttest.Number(161, 173, 50),                 // valor de mora por dia de atraso
+					layouttest.Text(174, 179, "051126"),             // data limite para concessão de desconto
+					layouttest.Number(180, 192, 1000),               // valor do desconto a ser concedido
+					layouttest.Number(193, 205, 0),                  // valor do IOF recolhido
+					layouttest.Number(206, 218, 2000),               // valor do abatimento a ser concedido
+					layouttest.Number(219, 220, 1),                  // identificação do tipo de inscrição do pagador
+					layouttest.Number(221, 234, 12345678909),        // número de inscrição do pagador
+					layouttest.Text(235, 264, "FULANO DE TAL"),      // nome do pagador
+					layouttest.Text(265, 274, ""),                   // complemento de registro
+					layouttest.Text(275, 314, "RUA DAS FLORES 100"), // rua, número e complemento do pagador
+					layouttest.Text(315, 326, "CENTRO"),             // bairro do pagador
+					layouttest.Number(327, 334, 1001000),            // CEP do pagador
+					layouttest.Text(335, 349, "SAO PAULO"),          // cidade do pagador
+					layouttest.Text(350, 351, "SP"),                 // UF do pagador
+					layouttest.Text(352, 381, "AVALISTA EXEMPLO"),   // nome do sacador ou avalista
+					layouttest.Text(382, 385, ""),                   // complemento de registro
+					layouttest.Text(386, 391, "111126"),             // data de mora
+					layouttest.Number(392, 393, 30),                 // quantidade de dias
+					layouttest.Text(394, 394, ""),                   // complemento de registro
+					layouttest.Number(395, 400, 2),                  // número sequencial do registro no arquivo
+				)
+			},
+			expected: itau400.Detail{
+				CompanyDocumentType:  itau400.DocumentTypeCNPJ,
+				CompanyDocument:      12345678000195,
+				Agency:               1234,
+				Account:              67890,
+				AccountDigit:         "1",
+				CancelledInstruction: "0000",
+				CompanyUse:           "PEDIDO 1001",
+				OurNumber:            12345678,
+				Wallet:               109,
+				WalletCode:           "I",
+				Occurrence:           itau400.InstructionEntry,
+				DocumentNumber:       "NF-1001",
+				DueDate:              cnab400.NewDate(2026, 11, 10),
+				Amount:               1500.75,
+				TitleKind:            "01",
+				Acceptance:           "N",
+				IssueDate:            cnab400.NewDate(2026, 10, 18),
+				Instruction1:         "09",
+				Instruction2:         "00",
+				DailyInterest:        0.5,
+				DiscountDate:         cnab400.NewDate(2026, 11, 5),
+				Discount:             10,
+				Rebate:               20,
+				PayerDocumentType:    itau400.DocumentTypeCPF,
+				PayerDocument:        12345678909,
+				PayerName:            "FULANO DE TAL",
+				PayerStreet:          "RUA DAS FLORES 100",
+				PayerDistrict:        "CENTRO",
+				PayerZipCode:         1001000,
+				PayerCity:            "SAO PAULO",
+				PayerState:           "SP",
+				Guarantor:            "AVALISTA EXEMPLO",
+				InterestDate:         cnab400.NewDate(2026, 11, 11),
+				Days:                 30,
+				Sequence:             2,
+			},
+		},
+		{
+			description: "it should decode the return detail",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab400.LineSize,
+					layouttest.Text(1, 1, "1"),                 // tipo de registro
+					layouttest.Number(2, 3, 2),                 // código de inscrição
+					layouttest.Number(4, 17, 12345678000195),   // número de inscrição
+					layouttest.Number(18, 21, 1234),            // agência mantenedora da conta
+					layouttest.Number(22, 23, 0),               // complemento de registro
+					layouttest.Number(24, 28, 67890),           // número da conta corrente
+					layouttest.Text(29, 29, "1"),               // dígito de auto conferência ag/conta
+					layouttest.Text(30, 37, ""),                // complemento de registro
+					layouttest.Text(38, 62, "PEDIDO 1001"),     // identificação do título na empresa
+					layouttest.Number(63, 70, 12345678),        // identificação do título no banco
+					layouttest.Text(71, 82, ""),                // complemento de registro
+					layouttest.Number(83, 85, 109),             // número da carteira
+					layouttest.Number(86, 93, 12345678),        // identificação do título no banco
+					layouttest.Number(94, 94, 5),               // dac do nosso número
+					layouttest.Text(95, 107, ""),               // complemento de registro
+					layouttest.Text(108, 108, "I"),             // código da carteira
+					layouttest.Text(109, 110, "06"),            // código de ocorrência
+					layouttest.Text(111, 116, "121126"),        // data de ocorrência no banco
+					layouttest.Text(117, 126, "NF-1001"),       // número do documento de cobrança
+					layouttest.Number(127, 134, 12345678),      // confirmação do número do título no banco
+					layouttest.Text(135, 146, ""),              // complemento de registro
+					layouttest.Text(147, 152, "101126"),        // data de vencimento do título
+					layouttest.Number(153, 165, 150075),        // valor nominal do título
+					layouttest.Number(166, 168, 341),           // número do banco na câmara de compensação
+					layouttest.Number(169, 172, 4321),          // agência cobradora
+					layouttest.Text(173, 173, "7"),             // dac da agência cobradora
+					layouttest.Text(174, 175, "01"),            // espécie do título
+					layouttest.Number(176, 188, 250),           // valor da despesa de cobrança
+					layouttest.Text(189, 214, ""),              // complemento de registro
+					layouttest.Number(215, 227, 0),             // valor do IOF
+					layouttest.Number(228, 240, 200),           // valor do abatimento concedido
+					layouttest.Number(241, 253, 300),           // valor do desconto concedido
+					layouttest.Number(254, 266, 150375),        // valor lançado em conta corrente
+					layouttest.Number(267, 279, 600),           // valor de mora e multa
+					layouttest.Number(280, 292, 0),             // valor de outros créditos
+					layouttest.Text(293, 293, "1"),             // indicador de boleto DDA
+					layouttest.Text(294, 295, ""),              // complemento de registro
+					layouttest.Text(296, 301, "131126"),        // data de crédito
+					layouttest.Text(302, 305, "0000"),          // código da instrução cancelada
+					layouttest.Text(306, 311, ""),              // complemento de registro
+					layouttest.Number(312, 324, 0),             // complemento de registro
+					layouttest.Text(325, 354, "FULANO DE TAL"), // nome do pagador
+					layouttest.Text(355, 377, ""),              // complemento de registro
+					layouttest.Text(378, 385, "00000000"),      // registros rejeitados ou alegação do pagador
+					layouttest.Text(386, 392, ""),              // complemento de registro
+					layouttest.Text(393, 394, "AA"),            // meio pelo qual o título foi liquidado
+					layouttest.Number(395, 400, 2),             // número sequencial do registro no arquivo
+				)
+			},
+			expected: itau400.ReturnDetail{
+				CompanyDocumentType:   itau400.DocumentTypeCNPJ,
+				CompanyDocument:       12345678000195,
+				Agency:                1234,
+				Account:               67890,
+				AccountDigit:          "1",
+				CompanyUse:            "PEDIDO 1001",
+				OurNumber:             12345678,
+				Wallet:                109,
+				BankOurNumber:         12345678,
+				OurNumberDigit:        5,
+				WalletCode:            "I",
+				Occurrence:            itau400.OccurrenceSettlement,
+				OccurrenceDate:        cnab400.NewDate(2026, 11, 12),
+				DocumentNumber:        "NF-1001",
+				ConfirmedOurNumber:    12345678,
+				DueDate:               cnab400.NewDate(2026, 11, 10),
+				Amount:                1500.75,
+				CollectingBank:        341,
+				CollectingAgency:      4321,
+				CollectingAgencyDigit: "7",
+				TitleKind:             "01",
+				Fee:                   2.5,
+				Rebate:                2,
+				Discount:              3,
+				PaidAmount:            1503.75,
+				Interest:              6,
+				DDA:                   "1",
+				CreditDate:            cnab400.NewDate(2026, 11, 13),
+				CancelledInstruction:  "0000",
+				PayerName:             "FULANO DE TAL",
+				ErrorCodes:            "00000000",
+				SettlementCode:        "AA",
+				Sequence:              2,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			decoded := reflect.New(reflect.TypeOf(scenario.expected))
+			if err := gocnab.Unmarshal(scenario.line(t), decoded.Interface()); err != nil {
+				t.Fatalf("error unmarshalling. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(scenario.expected, decoded.Elem().Interface()) {
+				t.Errorf("expected record “%#v” and got “%#v”", scenario.expected, decoded.Elem().Interface())
+			}
+		})
+	}
+}
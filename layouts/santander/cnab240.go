@@ -0,0 +1,175 @@
+package santander
+
+import (
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+)
+
+// FileHeader is the header of a CNAB 240 file (registro 0), identifying the
+// company by the transmission code.
+type FileHeader struct {
+	_                   string               `cnab:"0,3,const=033"`
+	_                   string               `cnab:"3,7,const=0000"`
+	_                   string               `cnab:"7,8,const=0"`
+	_                   string               `cnab:"8,16"`
+	CompanyDocumentType DocumentType         `cnab:"16,17"`
+	CompanyDocument     int64                `cnab:"17,32"`
+	TransmissionCode    string               `cnab:"32,47"`
+	_                   string               `cnab:"47,72"`
+	CompanyName         string               `cnab:"72,102"`
+	_                   string               `cnab:"102,132,const=BANCO SANTANDER"`
+	_                   string               `cnab:"132,142"`
+	FileCode            febraban240.FileCode `cnab:"142,143"`
+	GenerationDate      cnab240.Date         `cnab:"143,151"`
+	_                   string               `cnab:"151,157"`
+	FileSequence        int                  `cnab:"157,163"`
+	LayoutVersion       string               `cnab:"163,166"`
+	_                   string               `cnab:"166,240"`
+}
+
+// CobrancaBatchHeader is the header of a cobrança batch (registro 1),
+// identifying the company by the transmission code.
+type CobrancaBatchHeader struct {
+	_                   string       `cnab:"0,3,const=033"`
+	Batch               int          `cnab:"3,7"`
+	_                   string       `cnab:"7,8,const=1"`
+	Operation           string       `cnab:"8,9"`
+	_                   string       `cnab:"9,11,const=01"`
+	_                   string       `cnab:"11,13"`
+	LayoutVersion       string       `cnab:"13,16"`
+	_                   string       `cnab:"16,17"`
+	CompanyDocumentType DocumentType `cnab:"17,18"`
+	CompanyDocument     int64        `cnab:"18,33"`
+	_                   string       `cnab:"33,53"`
+	TransmissionCode    string       `cnab:"53,68"`
+	_                   string       `cnab:"68,73"`
+	CompanyName         string       `cnab:"73,103"`
+	Message1            string       `cnab:"103,143"`
+	Message2            string       `cnab:"143,183"`
+	RemittanceNumber    int          `cnab:"183,191"`
+	RecordingDate       cnab240.Date `cnab:"191,199"`
+	_                   string       `cnab:"199,240"`
+}
+
+// SegmentP contains the main data of a título in a remittance (segmento P).
+// Besides the checking account, Santander informs the cobrança account and a
+// nosso número of 13 digits.
+type SegmentP struct {
+	_                    string                      `cnab:"0,3,const=033"`
+	Batch                int                         `cnab:"3,7"`
+	_                    string                      `cnab:"7,8,const=3"`
+	Sequence             int                         `cnab:"8,13"`
+	_                    string                      `cnab:"13,14,const=P"`
+	_                    string                      `cnab:"14,15"`
+	Movement             febraban240.InstructionCode `cnab:"15,17"`
+	Agency               int                         `cnab:"17,21"`
+	AgencyDigit          string                      `cnab:"21,22"`
+	Account              int64                       `cnab:"22,31"`
+	AccountDigit         string                      `cnab:"31,32"`
+	CobrancaAccount      int64                       `cnab:"32,41"`
+	CobrancaAccountDigit string                      `cnab:"41,42"`
+	_                    string                      `cnab:"42,44"`
+	OurNumber            int64                       `cnab:"44,57"`
+	Wallet               int                         `cnab:"57,58"`
+	RegistrationType     int                         `cnab:"58,59"`
+	DocumentKind         int                         `cnab:"59,60"`
+	_                    string                      `cnab:"60,62"`
+	DocumentNumber       string                      `cnab:"62,77"`
+	DueDate              cnab240.Date                `cnab:"77,85"`
+	Amount               float64                     `cnab:"85,100"`
+	_                    string                      `cnab:"100,105,const=00000"`
+	_                    string                      `cnab:"105,106"`
+	TitleKind            int                         `cnab:"106,108"`
+	Acceptance           string                      `cnab:"108,109"`
+	IssueDate            cnab240.Date                `cnab:"109,117"`
+	InterestCode         int                         `cnab:"117,118"`
+	InterestDate         cnab240.Date                `cnab:"118,126"`
+	Interest             float64                     `cnab:"126,141"`
+	DiscountCode         int                         `cnab:"141,142"`
+	DiscountDate         cnab240.Date                `cnab:"142,150"`
+	Discount             float64                     `cnab:"150,165"`
+	IOF                  float64                     `cnab:"165,180"`
+	Rebate               float64                     `cnab:"180,195"`
+	CompanyTitleID       string                      `cnab:"195,220"`
+	ProtestCode          int                         `cnab:"220,221"`
+	ProtestDays          int                         `cnab:"221,223"`
+	WriteOffCode         int                         `cnab:"223,224"`
+	_                    string                      `cnab:"224,225,const=0"`
+	WriteOffDays         int                         `cnab:"225,227"`
+	Currency             int                         `cnab:"227,229"`
+	_                    string                      `cnab:"229,240"`
+}
+
+// SegmentT contains the main data of a título in a return file (segmento T).
+type SegmentT struct {
+	_                     string                     `cnab:"0,3,const=033"`
+	Batch                 int                        `cnab:"3,7"`
+	_                     string                     `cnab:"7,8,const=3"`
+	Sequence              int                        `cnab:"8,13"`
+	_                     string                     `cnab:"13,14,const=T"`
+	_                     string                     `cnab:"14,15"`
+	Occurrence            febraban240.OccurrenceCode `cnab:"15,17"`
+	Agency                int                        `cnab:"17,21"`
+	AgencyDigit           string                     `cnab:"21,22"`
+	Account               int64                      `cnab:"22,31"`
+	AccountDigit          string                     `cnab:"31,32"`
+	_                     string                     `cnab:"32,40"`
+	OurNumber             int64                      `cnab:"40,53"`
+	Wallet                int                        `cnab:"53,54"`
+	DocumentNumber        string                     `cnab:"54,69"`
+	DueDate               cnab240.Date               `cnab:"69,77"`
+	Amount                float64                    `cnab:"77,92"`
+	CollectingBank        int                        `cnab:"92,95"`
+	CollectingAgency      int                        `cnab:"95,99"`
+	CollectingAgencyDigit string                     `cnab:"99,100"`
+	CompanyTitleID        string                     `cnab:"100,125"`
+	Currency              int                        `cnab:"125,127"`
+	PayerDocumentType     DocumentType               `cnab:"127,128"`
+	PayerDocument         int64                      `cnab:"128,143"`
+	PayerName             string                     `cnab:"143,183"`
+	CobrancaAccount       int64                      `cnab:"183,193"`
+	Fee                   float64                    `cnab:"193,208"`
+	OccurrenceReasons     string                     `cnab:"208,218"`
+	_                     string                     `cnab:"218,240"`
+}
+
+// Reasons returns the codes of the reasons of the occurrence (motivos da
+// ocorrência), that are stored in groups of 2 characters. Empty and zeroed
+// codes are ignored.
+func (s SegmentT) Reasons() []string {
+	return febraban240.SegmentT{OccurrenceReasons: s.OccurrenceReasons}.Reasons()
+}
+
+// Boleto is a título of a remittance, composed by the consecutive segments P,
+// Q and optionally R.
+type Boleto struct {
+	P SegmentP              `cnab:"line"`
+	Q febraban240.SegmentQ  `cnab:"line"`
+	R *febraban240.SegmentR `cnab:"line"`
+}
+
+// BoletoReturn is a título of a return file, composed by the consecutive
+// segments T and U.
+type BoletoReturn struct {
+	T SegmentT             `cnab:"line"`
+	U febraban240.SegmentU `cnab:"line"`
+}
+
+// NewCobrancaMapper returns a mapper with the CNAB 240 cobrança record types,
+// to be used with cnab240.Unmarshal. The títulos are decoded as Boleto
+// (remittance) and BoletoReturn (return file), and the other segments are
+// decoded with the febraban240 types.
+func NewCobrancaMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*FileHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileHeader))
+	mapper.Register((*CobrancaBatchHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchHeader))
+	mapper.Register((*Boleto)(nil), cnab240.MatchSegment("P"))
+	mapper.Register((*febraban240.SegmentQ)(nil), cnab240.MatchSegment("Q"))
+	mapper.Register((*febraban240.SegmentR)(nil), cnab240.MatchSegment("R"))
+	mapper.Register((*BoletoReturn)(nil), cnab240.MatchSegment("T"))
+	mapper.Register((*febraban240.SegmentU)(nil), cnab240.MatchSegment("U"))
+	mapper.Register((*febraban240.CobrancaBatchTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchTrailer))
+	mapper.Register((*febraban240.FileTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileTrailer))
+	return mapper
+}
@@ -0,0 +1,359 @@
+package santander_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+	"github.com/rafaeljusto/gocnab/layouts/santander"
+)
+
+func TestCobranca(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		golden      string
+		fileCode    febraban240.FileCode
+		operation   string
+		segments    []cnab240.Segment
+		trailer     febraban240.CobrancaBatchTrailer
+	}{
+		{
+			description: "it should encode and decode a remittance",
+			golden:      "cnab240_remessa.golden",
+			fileCode:    febraban240.FileCodeRemittance,
+			operation:   febraban240.OperationRemittance,
+			segments: []cnab240.Segment{
+				santander.Boleto{
+					P: santander.SegmentP{
+						Batch:                1,
+						Sequence:             1,
+						Movement:             febraban240.InstructionEntry,
+						Agency:               1234,
+						AgencyDigit:          "5",
+						Account:              13000123,
+						AccountDigit:         "4",
+						CobrancaAccount:      13000123,
+						CobrancaAccountDigit: "4",
+						OurNumber:            1234567890123,
+						Wallet:               5,
+						RegistrationType:     1,
+						DocumentKind:         1,
+						DocumentNumber:       "NF-1001",
+						DueDate:              cnab240.NewDate(2026, 11, 10),
+						Amount:               1500.75,
+						TitleKind:            2,
+						Acceptance:           "N",
+						IssueDate:            cnab240.NewDate(2026, 10, 18),
+						InterestCode:         1,
+						InterestDate:         cnab240.NewDate(2026, 11, 11),
+						Interest:             0.5,
+						CompanyTitleID:       "PEDIDO 1001",
+						ProtestCode:          3,
+						WriteOffCode:         1,
+						WriteOffDays:         60,
+						Currency:             0,
+					},
+					Q: febraban240.SegmentQ{
+						Bank:              33,
+						Batch:             1,
+						Sequence:          2,
+						Movement:          febraban240.InstructionEntry,
+						PayerDocumentType: santander.DocumentTypeCPF,
+						PayerDocument:     12345678909,
+						PayerName:         "FULANO DE TAL",
+						PayerAddress:      "RUA DAS FLORES 100",
+						PayerDistrict:     "CENTRO",
+						PayerZipCode:      1001,
+						PayerCity:         "SAO PAULO",
+						PayerState:        "SP",
+					},
+				},
+			},
+			trailer: febraban240.CobrancaBatchTrailer{
+				Bank:    33,
+				Batch:   1,
+				Records: 4,
+			},
+		},
+		{
+			description: "it should encode and decode a return file",
+			golden:      "cnab240_retorno.golden",
+			fileCode:    febraban240.FileCodeReturn,
+			operation:   febraban240.OperationReturn,
+			segments: []cnab240.Segment{
+				santander.BoletoReturn{
+					T: santander.SegmentT{
+						Batch:             1,
+						Sequence:          1,
+						Occurrence:        febraban240.OccurrenceSettlement,
+						Agency:            1234,
+						AgencyDigit:       "5",
+						Account:           13000123,
+						AccountDigit:      "4",
+						OurNumber:         1234567890123,
+						Wallet:            5,
+						DocumentNumber:    "NF-1001",
+						DueDate:           cnab240.NewDate(2026, 11, 10),
+						Amount:            1500.75,
+						CollectingBank:    33,
+						CollectingAgency:  4321,
+						CompanyTitleID:    "PEDIDO 1001",
+						PayerDocumentType: santander.DocumentTypeCPF,
+						PayerDocument:     12345678909,
+						PayerName:         "FULANO DE TAL",
+						CobrancaAccount:   130001234,
+						Fee:               2.5,
+						OccurrenceReasons: "04",
+					},
+					U: febraban240.SegmentU{
+						Bank:           33,
+						Batch:          1,
+						Sequence:       2,
+						Occurrence:     febraban240.OccurrenceSettlement,
+						PaidAmount:     1500.75,
+						NetAmount:      1498.25,
+						OccurrenceDate: cnab240.NewDate(2026, 11, 12),
+						CreditDate:     cnab240.NewDate(2026, 11, 13),
+					},
+				},
+			},
+			trailer: febraban240.CobrancaBatchTrailer{
+				Bank:    33,
+				Batch:   1,
+				Records: 4,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			file := cnab240.File{
+				Header: santander.FileHeader{
+					CompanyDocumentType: santander.DocumentTypeCNPJ,
+					CompanyDocument:     12345678000195,
+					TransmissionCode:    "123400001300012",
+					CompanyName:         "EMPRESA EXEMPLO LTDA",
+					FileCode:            scenario.fileCode,
+					GenerationDate:      cnab240.NewDate(2026, 10, 18),
+					FileSequence:        42,
+					LayoutVersion:       "040",
+				},
+				Batches: []cnab240.Batch{
+					{
+						Header: santander.CobrancaBatchHeader{
+							Batch:               1,
+							Operation:           scenario.operation,
+							LayoutVersion:       "030",
+							CompanyDocumentType: santander.DocumentTypeCNPJ,
+							CompanyDocument:     12345678000195,
+							TransmissionCode:    "123400001300012",
+							CompanyName:         "EMPRESA EXEMPLO LTDA",
+							RemittanceNumber:    42,
+							RecordingDate:       cnab240.NewDate(2026, 10, 18),
+						},
+						Segments: scenario.segments,
+						Trailer:  scenario.trailer,
+					},
+				},
+				Trailer: febraban240.FileTrailer{
+					Bank:    33,
+					Batches: 1,
+					Records: 6,
+				},
+			}
+
+			data, err := cnab240.Marshal(layouttest.WithoutFields(file, layouttest.CNAB240ControlFields...))
+			if err != nil {
+				t.Fatalf("error marshalling. details: %s", err)
+			}
+
+			golden := layouttest.CheckGolden(t, scenario.golden, data)
+
+			decoded, err := cnab240.Unmarshal(golden, santander.NewCobrancaMapper())
+			if err != nil {
+				t.Fatalf("error unmarshalling. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(file, decoded) {
+				t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+			}
+		})
+	}
+}
+
+func TestCNAB240_spec(t *testing.T) {
+	t.Parallel()
+
+	// lines assembled from the tables of the Santander CNAB 240 cobrança
+	// specification, independently of the encoder
+	scenarios := []struct {
+		description string
+		line        func(t *testing.T) []byte
+		expected    interface{}
+	}{
+		{
+			description: "it should decode the segment P",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab240.LineSize,
+					layouttest.Number(1, 3, 33),              // código do banco na compensação
+					layouttest.Number(4, 7, 1),               // número do lote remessa
+					layouttest.Text(8, 8, "3"),               // tipo de registro
+					layouttest.Number(9, 13, 1),              // nº sequencial do registro no lote
+					layouttest.Text(14, 14, "P"),             // código do segmento do registro detalhe
+					layouttest.Text(15, 15, ""),              // reservado (uso banco)
+					layouttest.Number(16, 17, 1),             // código de movimento remessa
+					layouttest.Number(18, 21, 1234),          // agência do destinatária FIDC
+					layouttest.Text(22, 22, "5"),             // dígito da agência do destinatária FIDC
+					layouttest.Number(23, 31, 13000123),      // número da conta corrente
+					layouttest.Text(32, 32, "4"),             // dígito verificador da conta
+					layouttest.Number(33, 41, 13000456),      // conta cobrança destinatária FIDC
+					layouttest.Text(42, 42, "7"),             // dígito da conta cobrança
+					layouttest.Text(43, 44, ""),              // reservado (uso banco)
+					layouttest.Number(45, 57, 1234567890123), // identificação do boleto no banco
+					layouttest.Number(58, 58, 5),             // tipo de cobrança
+					layouttest.Number(59, 59, 1),             // forma de cadastramento
+					layouttest.Number(60, 60, 1),             // tipo de documento
+					layouttest.Text(61, 62, ""),              // reservado (uso banco)
+					layouttest.Text(63, 77, "NF-1001"),       // nº do documento
+					layouttest.Text(78, 85, "10112026"),      // data de vencimento do boleto
+					layouttest.Number(86, 100, 150075),       // valor nominal do boleto
+					layouttest.Number(101, 104, 0),           // agência encarregada da cobrança FIDC
+					layouttest.Number(105, 105, 0),           // dígito da agência do beneficiário
+					layouttest.Text(106, 106, ""),            // reservado (uso banco)
+					layouttest.Number(107, 108, 2),           // espécie do boleto
+					layouttest.Text(109, 109, "N"),           // identificação de boleto aceito/não aceito
+					layouttest.Text(110, 117, "20102026"),    // data da emissão do boleto
+					layouttest.Number(118, 118, 1),           // código do juros de mora
+					layouttest.Text(119, 126, "11112026"),    // data do juros de mora
+					layouttest.Number(127, 141, 50),          // valor da mora/dia ou taxa mensal
+					layouttest.Number(142, 142, 1),           // código do desconto 1
+					layouttest.Text(143, 150, "05112026"),    // data de desconto 1
+					layouttest.Number(151, 165, 1000),        // valor ou percentual do desconto concedido
+					layouttest.Number(166, 180, 0),           // percentual do IOF a ser recolhido
+					layouttest.Number(181, 195, 2000),        // valor do abatimento
+					layouttest.Text(196, 220, "PEDIDO 1001"), // identificação do boleto na empresa
+					layouttest.Number(221, 221, 1),           // código para protesto
+					layouttest.Number(222, 223, 5),           // número de dias para protesto
+					layouttest.Number(224, 224, 2),           // código para baixa/devolução
+					layouttest.Number(225, 225, 0),           // reservado (uso banco)
+					layouttest.Number(226, 227, 60),          // número de dias para baixa/devolução
+					layouttest.Number(228, 229, 0),           // código da moeda
+					layouttest.Text(230, 240, ""),            // reservado (uso banco)
+				)
+			},
+			expected: santander.SegmentP{
+				Batch:                1,
+				Sequence:             1,
+				Movement:             febraban240.InstructionEntry,
+				Agency:               1234,
+				AgencyDigit:          "5",
+				Account:              13000123,
+				AccountDigit:         "4",
+				CobrancaAccount:      13000456,
+				CobrancaAccountDigit: "7",
+				OurNumber:            1234567890123,
+				Wallet:               5,
+				RegistrationType:     1,
+				DocumentKind:         1,
+				DocumentNumber:       "NF-1001",
+				DueDate:              cnab240.NewDate(2026, 11, 10),
+				Amount:               1500.75,
+				TitleKind:            2,
+				Acceptance:           "N",
+				IssueDate:            cnab240.NewDate(2026, 10, 20),
+				InterestCode:         1,
+				InterestDate:         cnab240.NewDate(2026, 11, 11),
+				Interest:             0.5,
+				DiscountCode:         1,
+				DiscountDate:         cnab240.NewDate(2026, 11, 5),
+				Discount:             10,
+				Rebate:               20,
+				CompanyTitleID:       "PEDIDO 1001",
+				ProtestCode:          1,
+				ProtestDays:          5,
+				WriteOffCode:         2,
+				WriteOffDays:         60,
+			},
+		},
+		{
+			description: "it should decode the segment T",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab240.LineSize,
+					layouttest.Number(1, 3, 33),                // código do banco na compensação
+					layouttest.Number(4, 7, 1),                 // número do lote retorno
+					layouttest.Text(8, 8, "3"),                 // tipo de registro
+					layouttest.Number(9, 13, 1),                // nº sequencial do registro no lote
+					layouttest.Text(14, 14, "T"),               // código do segmento do registro detalhe
+					layouttest.Text(15, 15, ""),                // reservado (uso banco)
+					layouttest.Number(16, 17, 6),               // código de movimento retorno
+					layouttest.Number(18, 21, 1234),            // agência do beneficiário
+					layouttest.Text(22, 22, "5"),               // dígito da agência do beneficiário
+					layouttest.Number(23, 31, 13000123),        // número da conta corrente
+					layouttest.Text(32, 32, "4"),               // dígito verificador da conta
+					layouttest.Text(33, 40, ""),                // reservado (uso banco)
+					layouttest.Number(41, 53, 1234567890123),   // identificação do boleto no banco
+					layouttest.Number(54, 54, 5),               // código da carteira
+					layouttest.Text(55, 69, "NF-1001"),         // nº do documento de cobrança
+					layouttest.Text(70, 77, "10112026"),        // data do vencimento do boleto
+					layouttest.Number(78, 92, 150075),          // valor nominal do boleto
+					layouttest.Number(93, 95, 33),              // nº do banco cobrador/recebedor
+					layouttest.Number(96, 99, 4321),            // agência cobradora/recebedora
+					layouttest.Text(100, 100, "0"),             // dígito da agência do beneficiário
+					layouttest.Text(101, 125, "PEDIDO 1001"),   // identificação do boleto na empresa
+					layouttest.Number(126, 127, 0),             // código da moeda
+					layouttest.Number(128, 128, 1),             // tipo de inscrição do pagador
+					layouttest.Number(129, 143, 12345678909),   // número de inscrição do pagador
+					layouttest.Text(144, 183, "FULANO DE TAL"), // nome do pagador
+					layouttest.Number(184, 193, 130004567),     // conta cobrança
+					layouttest.Number(194, 208, 250),           // valor da tarifa/custas
+					layouttest.Text(209, 218, "A1B2"),          // identificação para rejeições, tarifas, custas, liquidação e baixas
+					layouttest.Text(219, 240, ""),              // reservado (uso banco)
+				)
+			},
+			expected: santander.SegmentT{
+				Batch:                 1,
+				Sequence:              1,
+				Occurrence:            febraban240.OccurrenceSettlement,
+				Agency:                1234,
+				AgencyDigit:           "5",
+				Account:               13000123,
+				AccountDigit:          "4",
+				OurNumber:             1234567890123,
+				Wallet:                5,
+				DocumentNumber:        "NF-1001",
+				DueDate:               cnab240.NewDate(2026, 11, 10),
+				Amount:                1500.75,
+				CollectingBank:        33,
+				CollectingAgency:      4321,
+				CollectingAgencyDigit: "0",
+				CompanyTitleID:        "PEDIDO 1001",
+				PayerDocumentType:     santander.DocumentTypeCPF,
+				PayerDocument:         12345678909,
+				PayerName:             "FULANO DE TAL",
+				CobrancaAccount:       130004567,
+				Fee:                   2.5,
+				OccurrenceReasons:     "A1B2",
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			decoded := reflect.New(reflect.TypeOf(scenario.expected))
+			if err := gocnab.Unmarshal(scenario.line(t), decoded.Interface()); err != nil {
+				t.Fatalf("error unmarshalling. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(scenario.expected, decoded.Elem().Interface()) {
+				t.Errorf("expected record “%#v” and got “%#v”", scenario.expected, decoded.Elem().Interface())
+			}
+		})
+	}
+}
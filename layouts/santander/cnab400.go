@@ -0,0 +1,211 @@
+package santander
+
+import (
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab400"
+)
+
+// RecordTypeDetail is the record type of the CNAB 400 details.
+const RecordTypeDetail = "1"
+
+// Header is the header of a CNAB 400 remittance file (registro 0).
+type Header struct {
+	_                string       `cnab:"0,1,const=0"`
+	_                string       `cnab:"1,2,const=1"`
+	_                string       `cnab:"2,9,const=REMESSA"`
+	_                string       `cnab:"9,11,const=01"`
+	_                string       `cnab:"11,26,const=COBRANCA"`
+	TransmissionCode string       `cnab:"26,46"`
+	CompanyName      string       `cnab:"46,76"`
+	_                string       `cnab:"76,79,const=033"`
+	_                string       `cnab:"79,94,const=SANTANDER"`
+	RecordingDate    cnab400.Date `cnab:"94,100"`
+	_                string       `cnab:"100,116,const=0000000000000000"`
+	_                string       `cnab:"116,391"`
+	Version          string       `cnab:"391,394"`
+	Sequence         int          `cnab:"394,400"`
+}
+
+// Detail contains the data of a título in a CNAB 400 remittance file
+// (registro 1). The fine percentage is informed in the detail itself, together
+// with the date from which it is charged.
+type Detail struct {
+	_                   string          `cnab:"0,1,const=1"`
+	CompanyDocumentType DocumentType    `cnab:"1,3"`
+	CompanyDocument     int64           `cnab:"3,17"`
+	TransmissionCode    string          `cnab:"17,37"`
+	ControlNumber       string          `cnab:"37,62"`
+	OurNumber           int64           `cnab:"62,70"`
+	Discount2Date       cnab400.Date    `cnab:"70,76"`
+	_                   string          `cnab:"76,77"`
+	FineCode            int             `cnab:"77,78"`
+	FinePercentage      float64         `cnab:"78,82"`
+	_                   string          `cnab:"82,84,const=00"`
+	OtherUnitAmount     float64         `cnab:"84,97"`
+	_                   string          `cnab:"97,101"`
+	FineDate            cnab400.Date    `cnab:"101,107"`
+	Wallet              int             `cnab:"107,108"`
+	Occurrence          InstructionCode `cnab:"108,110"`
+	DocumentNumber      string          `cnab:"110,120"`
+	DueDate             cnab400.Date    `cnab:"120,126"`
+	Amount              float64         `cnab:"126,139"`
+	_                   string          `cnab:"139,142,const=033"`
+	CollectingAgency    int             `cnab:"142,147"`
+	TitleKind           string          `cnab:"147,149"`
+	Acceptance          string          `cnab:"149,150"`
+	IssueDate           cnab400.Date    `cnab:"150,156"`
+	Instruction1        string          `cnab:"156,158"`
+	Instruction2        string          `cnab:"158,160"`
+	DailyInterest       float64         `cnab:"160,173"`
+	DiscountDate        cnab400.Date    `cnab:"173,179"`
+	Discount            float64         `cnab:"179,192"`
+	IOF                 float64         `cnab:"192,205"`
+	Rebate              float64         `cnab:"205,218"`
+	PayerDocumentType   DocumentType    `cnab:"218,220"`
+	PayerDocument       int64           `cnab:"220,234"`
+	PayerName           string          `cnab:"234,274"`
+	PayerAddress        string          `cnab:"274,314"`
+	PayerDistrict       string          `cnab:"314,326"`
+	PayerZipCode        int             `cnab:"326,331"`
+	PayerZipCodeSuffix  int             `cnab:"331,334"`
+	PayerCity           string          `cnab:"334,349"`
+	PayerState          string          `cnab:"349,351"`
+	Guarantor           string          `cnab:"351,381"`
+	_                   string          `cnab:"381,382"`
+	_                   string          `cnab:"382,383,const=I"`
+	AccountComplement   string          `cnab:"383,385"`
+	_                   string          `cnab:"385,391"`
+	ProtestDays         int             `cnab:"391,393"`
+	_                   string          `cnab:"393,394"`
+	Sequence            int             `cnab:"394,400"`
+}
+
+// Trailer is the trailer of a CNAB 400 remittance file (registro 9), with the
+// number of lines and the total amount of the títulos.
+type Trailer struct {
+	_           string  `cnab:"0,1,const=9"`
+	Lines       int     `cnab:"1,7"`
+	TotalAmount float64 `cnab:"7,20"`
+	_           string  `cnab:"20,394"`
+	Sequence    int     `cnab:"394,400"`
+}
+
+// ReturnHeader is the header of a CNAB 400 return file (registro 0).
+type ReturnHeader struct {
+	_               string       `cnab:"0,1,const=0"`
+	_               string       `cnab:"1,2,const=2"`
+	_               string       `cnab:"2,9,const=RETORNO"`
+	_               string       `cnab:"9,11,const=01"`
+	_               string       `cnab:"11,26,const=COBRANCA"`
+	Agency          int          `cnab:"26,30"`
+	Account         int64        `cnab:"30,38"`
+	CobrancaAccount int64        `cnab:"38,46"`
+	CompanyName     string       `cnab:"46,76"`
+	_               string       `cnab:"76,79,const=033"`
+	_               string       `cnab:"79,94,const=SANTANDER"`
+	RecordingDate   cnab400.Date `cnab:"94,100"`
+	_               string       `cnab:"100,391"`
+	Version         string       `cnab:"391,394"`
+	Sequence        int          `cnab:"394,400"`
+}
+
+// ReturnDetail contains the occurrence of a título in a CNAB 400 return file
+// (registro 1).
+type ReturnDetail struct {
+	_                   string          `cnab:"0,1,const=1"`
+	CompanyDocumentType DocumentType    `cnab:"1,3"`
+	CompanyDocument     int64           `cnab:"3,17"`
+	Agency              int             `cnab:"17,21"`
+	Account             int64           `cnab:"21,29"`
+	CobrancaAccount     int64           `cnab:"29,37"`
+	ControlNumber       string          `cnab:"37,62"`
+	OurNumber           int64           `cnab:"62,70"`
+	_                   string          `cnab:"70,107"`
+	Wallet              int             `cnab:"107,108"`
+	Occurrence          OccurrenceCode  `cnab:"108,110"`
+	OccurrenceDate      cnab400.Date    `cnab:"110,116"`
+	DocumentNumber      string          `cnab:"116,126"`
+	ConfirmedOurNumber  int64           `cnab:"126,134"`
+	OriginalInstruction InstructionCode `cnab:"134,136"`
+	ErrorCodes          string          `cnab:"136,145"`
+	_                   string          `cnab:"145,146"`
+	DueDate             cnab400.Date    `cnab:"146,152"`
+	Amount              float64         `cnab:"152,165"`
+	CollectingBank      int             `cnab:"165,168"`
+	CollectingAgency    int             `cnab:"168,173"`
+	TitleKind           string          `cnab:"173,175"`
+	Fee                 float64         `cnab:"175,188"`
+	OtherExpenses       float64         `cnab:"188,201"`
+	LateInterest        float64         `cnab:"201,214"`
+	IOF                 float64         `cnab:"214,227"`
+	Rebate              float64         `cnab:"227,240"`
+	Discount            float64         `cnab:"240,253"`
+	PaidAmount          float64         `cnab:"253,266"`
+	Interest            float64         `cnab:"266,279"`
+	OtherCredits        float64         `cnab:"279,292"`
+	_                   string          `cnab:"292,293"`
+	Acceptance          string          `cnab:"293,294"`
+	_                   string          `cnab:"294,295"`
+	CreditDate          cnab400.Date    `cnab:"295,301"`
+	PayerName           string          `cnab:"301,337"`
+	_                   string          `cnab:"337,394"`
+	Sequence            int             `cnab:"394,400"`
+}
+
+// Errors returns the codes of the errors of a rejected entry or instruction,
+// that are stored in groups of 3 characters. Empty and zeroed codes are
+// ignored.
+func (r ReturnDetail) Errors() []ErrorCode {
+	var codes []ErrorCode
+	for i := 0; i+3 <= len(r.ErrorCodes); i += 3 {
+		code := r.ErrorCodes[i : i+3]
+		if code != "000" && code != "   " {
+			codes = append(codes, ErrorCode(code))
+		}
+	}
+	return codes
+}
+
+// ReturnTrailer is the trailer of a CNAB 400 return file (registro 9), with the
+// summary of each type of cobrança.
+type ReturnTrailer struct {
+	_                string  `cnab:"0,1,const=9"`
+	_                string  `cnab:"1,2,const=2"`
+	_                string  `cnab:"2,4,const=01"`
+	_                string  `cnab:"4,7,const=033"`
+	_                string  `cnab:"7,17"`
+	SimpleCount      int     `cnab:"17,25"`
+	SimpleAmount     float64 `cnab:"25,39"`
+	SimpleNotice     string  `cnab:"39,47"`
+	_                string  `cnab:"47,57"`
+	PledgedCount     int     `cnab:"57,65"`
+	PledgedAmount    float64 `cnab:"65,79"`
+	PledgedNotice    string  `cnab:"79,87"`
+	_                string  `cnab:"87,97"`
+	DiscountedCount  int     `cnab:"97,105"`
+	DiscountedAmount float64 `cnab:"105,119"`
+	DiscountedNotice string  `cnab:"119,127"`
+	_                string  `cnab:"127,391"`
+	Version          string  `cnab:"391,394"`
+	Sequence         int     `cnab:"394,400"`
+}
+
+// NewRemittanceMapper returns a mapper with the CNAB 400 remittance record
+// types, to be used with cnab400.Unmarshal.
+func NewRemittanceMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*Header)(nil), cnab400.MatchRecordType(cnab400.RecordTypeHeader))
+	mapper.Register((*Detail)(nil), cnab400.MatchRecordType(RecordTypeDetail))
+	mapper.Register((*Trailer)(nil), cnab400.MatchRecordType(cnab400.RecordTypeTrailer))
+	return mapper
+}
+
+// NewReturnMapper returns a mapper with the CNAB 400 return record types, to be
+// used with cnab400.Unmarshal.
+func NewReturnMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*ReturnHeader)(nil), cnab400.MatchRecordType(cnab400.RecordTypeHeader))
+	mapper.Register((*ReturnDetail)(nil), cnab400.MatchRecordType(RecordTypeDetail))
+	mapper.Register((*ReturnTrailer)(nil), cnab400.MatchRecordType(cnab400.RecordTypeTrailer))
+	return mapper
+}
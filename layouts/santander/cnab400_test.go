@@ -0,0 +1,366 @@
+package santander_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab400"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/santander"
+)
+
+func TestRemittance(t *testing.T) {
+	t.Parallel()
+
+	file := cnab400.File{
+		Header: santander.Header{
+			TransmissionCode: "12340000130001234567",
+			CompanyName:      "EMPRESA EXEMPLO LTDA",
+			RecordingDate:    cnab400.NewDate(2026, 10, 18),
+			Version:          "058",
+			Sequence:         1,
+		},
+		Details: []cnab400.Detail{
+			santander.Detail{
+				CompanyDocumentType: santander.DocumentTypeCNPJ,
+				CompanyDocument:     12345678000195,
+				TransmissionCode:    "12340000130001234567",
+				ControlNumber:       "PEDIDO 1001",
+				OurNumber:           12345678,
+				FineCode:            4,
+				FinePercentage:      2,
+				FineDate:            cnab400.NewDate(2026, 11, 11),
+				Wallet:              5,
+				Occurrence:          santander.InstructionEntry,
+				DocumentNumber:      "NF-1001",
+				DueDate:             cnab400.NewDate(2026, 11, 10),
+				Amount:              1500.75,
+				TitleKind:           "01",
+				Acceptance:          "N",
+				IssueDate:           cnab400.NewDate(2026, 10, 18),
+				DailyInterest:       0.5,
+				PayerDocumentType:   santander.DocumentTypeCPF,
+				PayerDocument:       12345678909,
+				PayerName:           "FULANO DE TAL",
+				PayerAddress:        "RUA DAS FLORES 100",
+				PayerDistrict:       "CENTRO",
+				PayerZipCode:        1001,
+				PayerCity:           "SAO PAULO",
+				PayerState:          "SP",
+				AccountComplement:   "34",
+				Sequence:            2,
+			},
+		},
+		Trailer: santander.Trailer{
+			Lines:       3,
+			TotalAmount: 1500.75,
+			Sequence:    3,
+		},
+	}
+
+	data, err := cnab400.Marshal(layouttest.WithoutFields(file, layouttest.CNAB400ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "cnab400_remessa.golden", data)
+
+	decoded, err := cnab400.Unmarshal(golden, santander.NewRemittanceMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+}
+
+func TestReturn(t *testing.T) {
+	t.Parallel()
+
+	file := cnab400.File{
+		Header: santander.ReturnHeader{
+			Agency:          1234,
+			Account:         13000123,
+			CobrancaAccount: 13000123,
+			CompanyName:     "EMPRESA EXEMPLO LTDA",
+			RecordingDate:   cnab400.NewDate(2026, 11, 12),
+			Version:         "058",
+			Sequence:        1,
+		},
+		Details: []cnab400.Detail{
+			santander.ReturnDetail{
+				CompanyDocumentType: santander.DocumentTypeCNPJ,
+				CompanyDocument:     12345678000195,
+				Agency:              1234,
+				Account:             13000123,
+				CobrancaAccount:     13000123,
+				ControlNumber:       "PEDIDO 1001",
+				OurNumber:           12345678,
+				Wallet:              5,
+				Occurrence:          santander.OccurrenceSettlement,
+				OccurrenceDate:      cnab400.NewDate(2026, 11, 12),
+				DocumentNumber:      "NF-1001",
+				ConfirmedOurNumber:  12345678,
+				OriginalInstruction: santander.InstructionEntry,
+				DueDate:             cnab400.NewDate(2026, 11, 10),
+				Amount:              1500.75,
+				CollectingBank:      33,
+				CollectingAgency:    4321,
+				TitleKind:           "01",
+				Fee:                 2.5,
+				PaidAmount:          1500.75,
+				CreditDate:          cnab400.NewDate(2026, 11, 13),
+				PayerName:           "FULANO DE TAL",
+				Sequence:            2,
+			},
+			santander.ReturnDetail{
+				CompanyDocumentType: santander.DocumentTypeCNPJ,
+				CompanyDocument:     12345678000195,
+				Agency:              1234,
+				Account:             13000123,
+				CobrancaAccount:     13000123,
+				ControlNumber:       "PEDIDO 1002",
+				OurNumber:           12345679,
+				Wallet:              5,
+				Occurrence:          santander.OccurrenceEntryRejected,
+				OccurrenceDate:      cnab400.NewDate(2026, 10, 19),
+				DocumentNumber:      "NF-1002",
+				OriginalInstruction: santander.InstructionEntry,
+				ErrorCodes:          "007022000",
+				DueDate:             cnab400.NewDate(2026, 11, 20),
+				Amount:              99.9,
+				PayerName:           "CLIENTE EXEMPLO SA",
+				Sequence:            3,
+			},
+		},
+		Trailer: santander.ReturnTrailer{
+			SimpleCount:  2,
+			SimpleAmount: 1600.65,
+			Version:      "058",
+			Sequence:     4,
+		},
+	}
+
+	data, err := cnab400.Marshal(layouttest.WithoutFields(file, layouttest.CNAB400ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "cnab400_retorno.golden", data)
+
+	decoded, err := cnab400.Unmarshal(golden, santander.NewReturnMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+
+	rejected := decoded.Details[1].(santander.ReturnDetail)
+	expectedErrors := []santander.ErrorCode{santander.ErrorInvalidDueDate, santander.ErrorInvalidZipCode}
+	if codes := rejected.Errors(); !reflect.DeepEqual(expectedErrors, codes) {
+		t.Errorf("unexpected errors “%v”", codes)
+	}
+
+	if description := rejected.Errors()[1].Description(); description != "CEP inválido" {
+		t.Errorf("unexpected error description “%s”", description)
+	}
+}
+
+func TestCNAB400_spec(t *testing.T) {
+	t.Parallel()
+
+	// lines assembled from the tables of the Santander CNAB 400 cobrança
+	// specification, independently of the encoder
+	scenarios := []struct {
+		description string
+		line        func(t *testing.T) []byte
+		expected    interface{}
+	}{
+		{
+			description: "it should decode the remittance detail",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab400.LineSize,
+					layouttest.Text(1, 1, "1"),                      // código do registro
+					layouttest.Number(2, 3, 2),                      // tipo de inscrição do beneficiário
+					layouttest.Number(4, 17, 12345678000195),        // CNPJ ou CPF do beneficiário
+					layouttest.Text(18, 37, "12340130001230000456"), // código de transmissão
+					layouttest.Text(38, 62, "PEDIDO 1001"),          // número de controle do participante
+					layouttest.Number(63, 70, 1234567),              // nosso número
+					layouttest.Text(71, 76, "011126"),               // data do segundo desconto
+					layouttest.Text(77, 77, ""),                     // branco
+					layouttest.Number(78, 78, 4),                    // informação de multa
+					layouttest.Number(79, 82, 200),                  // percentual da multa
+					layouttest.Number(83, 84, 0),                    // unidade de valor moeda corrente
+					layouttest.Number(85, 97, 0),                    // valor do título em outra unidade
+					layouttest.Text(98, 101, ""),                    // brancos
+					layouttest.Text(102, 107, "111126"),             // data para cobrança de multa
+					layouttest.Number(108, 108, 5),                  // código da carteira
+					layouttest.Text(109, 110, "01"),                 // código da ocorrência
+					layouttest.Text(111, 120, "NF-1001"),            // seu número
+					layouttest.Text(121, 126, "101126"),             // data de vencimento do título
+					layouttest.Number(127, 139, 150075),             // valor do título
+					layouttest.Number(140, 142, 33),                 // número do banco cobrador
+					layouttest.Number(143, 147, 0),                  // código da agência cobradora
+					layouttest.Text(148, 149, "01"),                 // espécie de documento
+					layouttest.Text(150, 150, "N"),                  // tipo de aceite
+					layouttest.Text(151, 156, "181026"),             // data de emissão do título
+					layouttest.Text(157, 158, "06"),                 // primeira instrução cobrança
+					layouttest.Text(159, 160, "00"),                 // segunda instrução cobrança
+					layouttest.Number(161, 173, 50),                 // valor de mora a ser cobrado por dia de atraso
+					layouttest.Text(174, 179, "051126"),             // data limite para concessão de desconto
+					layouttest.Number(180, 192, 1000),               // valor de desconto a ser concedido
+					layouttest.Number(193, 205, 0),                  // valor do IOF a ser recolhido
+					layouttest.Number(206, 218, 2000),               // valor do abatimento ou do segundo desconto
+					layouttest.Number(219, 220, 1),                  // tipo de inscrição do pagador
+					layouttest.Number(221, 234, 12345678909),        // CNPJ ou CPF do pagador
+					layouttest.Text(235, 274, "FULANO DE TAL"),      // nome do pagador
+					layouttest.Text(275, 314, "RUA DAS FLORES 100"), // endereço do pagador
+					layouttest.Text(315, 326, "CENTRO"),             // bairro do pagador
+					layouttest.Number(327, 331, 1001),               // CEP do pagador
+					layouttest.Number(332, 334, 0),                  // complemento do CEP
+					layouttest.Text(335, 349, "SAO PAULO"),          // município do pagador
+					layouttest.Text(350, 351, "SP"),                 // UF do pagador
+					layouttest.Text(352, 381, "AVALISTA EXEMPLO"),   // nome do sacador ou coobrigado
+					layouttest.Text(382, 382, ""),                   // brancos
+					layouttest.Text(383, 383, "I"),                  // identificador do complemento
+					layouttest.Text(384, 385, "56"),                 // complemento
+					layouttest.Text(386, 391, ""),                   // brancos
+					layouttest.Number(392, 393, 10),                 // número de dias para protesto
+					layouttest.Text(394, 394, ""),                   // brancos
+					layouttest.Number(395, 400, 2),                  // número sequencial do registro no arquivo
+				)
+			},
+			expected: santander.Detail{
+				CompanyDocumentType: santander.DocumentTypeCNPJ,
+				CompanyDocument:     12345678000195,
+				TransmissionCode:    "12340130001230000456",
+				ControlNumber:       "PEDIDO 1001",
+				OurNumber:           1234567,
+				Discount2Date:       cnab400.NewDate(2026, 11, 1),
+				FineCode:            4,
+				FinePercentage:      2,
+				FineDate:            cnab400.NewDate(2026, 11, 11),
+				Wallet:              5,
+				Occurrence:          santander.InstructionEntry,
+				DocumentNumber:      "NF-1001",
+				DueDate:             cnab400.NewDate(2026, 11, 10),
+				Amount:              1500.75,
+				TitleKind:           "01",
+				Acceptance:          "N",
+				IssueDate:           cnab400.NewDate(2026, 10, 18),
+				Instruction1:        "06",
+				Instruction2:        "00",
+				DailyInterest:       0.5,
+				DiscountDate:        cnab400.NewDate(2026, 11, 5),
+				Discount:            10,
+				Rebate:              20,
+				PayerDocumentType:   santander.DocumentTypeCPF,
+				PayerDocument:       12345678909,
+				PayerName:           "FULANO DE TAL",
+				PayerAddress:        "RUA DAS FLORES 100",
+				PayerDistrict:       "CENTRO",
+				PayerZipCode:        1001,
+				PayerCity:           "SAO PAULO",
+				PayerState:          "SP",
+				Guarantor:           "AVALISTA EXEMPLO",
+				AccountComplement:   "56",
+				ProtestDays:         10,
+				Sequence:            2,
+			},
+		},
+		{
+			description: "it should decode the return detail",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab400.LineSize,
+					layouttest.Text(1, 1, "1"),                 // código do registro
+					layouttest.Number(2, 3, 2),                 // tipo de inscrição do beneficiário
+					layouttest.Number(4, 17, 12345678000195),   // CNPJ ou CPF do beneficiário
+					layouttest.Number(18, 21, 1234),            // código da agência do beneficiário
+					layouttest.Number(22, 29, 13000123),        // conta movimento do beneficiário
+					layouttest.Number(30, 37, 13000456),        // conta cobrança do beneficiário
+					layouttest.Text(38, 62, "PEDIDO 1001"),     // número de controle do participante
+					layouttest.Number(63, 70, 1234567),         // nosso número
+					layouttest.Text(71, 107, ""),               // brancos
+					layouttest.Number(108, 108, 5),             // código da carteira
+					layouttest.Text(109, 110, "06"),            // código da ocorrência
+					layouttest.Text(111, 116, "121126"),        // data da ocorrência
+					layouttest.Text(117, 126, "NF-1001"),       // seu número
+					layouttest.Number(127, 134, 1234567),       // nosso número
+					layouttest.Text(135, 136, "01"),            // código original da remessa
+					layouttest.Text(137, 145, "000000000"),     // código do erro
+					layouttest.Text(146, 146, ""),              // brancos
+					layouttest.Text(147, 152, "101126"),        // data de vencimento do título
+					layouttest.Number(153, 165, 150075),        // valor do título
+					layouttest.Number(166, 168, 33),            // número do banco cobrador
+					layouttest.Number(169, 173, 4321),          // código da agência recebedora
+					layouttest.Text(174, 175, "01"),            // espécie de documento
+					layouttest.Number(176, 188, 250),           // valor da tarifa cobrada
+					layouttest.Number(189, 201, 100),           // valor de outras despesas
+					layouttest.Number(202, 214, 0),             // valor dos juros de atraso
+					layouttest.Number(215, 227, 0),             // valor do IOF devido
+					layouttest.Number(228, 240, 200),           // valor do abatimento concedido
+					layouttest.Number(241, 253, 300),           // valor do desconto concedido
+					layouttest.Number(254, 266, 150375),        // valor total recebido
+					layouttest.Number(267, 279, 600),           // valor dos juros de mora
+					layouttest.Number(280, 292, 0),             // valor de outros créditos
+					layouttest.Text(293, 293, ""),              // brancos
+					layouttest.Text(294, 294, "N"),             // código de aceite
+					layouttest.Text(295, 295, ""),              // brancos
+					layouttest.Text(296, 301, "131126"),        // data do crédito
+					layouttest.Text(302, 337, "FULANO DE TAL"), // nome do pagador
+					layouttest.Number(395, 400, 2),             // número sequencial do registro no arquivo
+				)
+			},
+			expected: santander.ReturnDetail{
+				CompanyDocumentType: santander.DocumentTypeCNPJ,
+				CompanyDocument:     12345678000195,
+				Agency:              1234,
+				Account:             13000123,
+				CobrancaAccount:     13000456,
+				ControlNumber:       "PEDIDO 1001",
+				OurNumber:           1234567,
+				Wallet:              5,
+				Occurrence:          santander.OccurrenceSettlement,
+				OccurrenceDate:      cnab400.NewDate(2026, 11, 12),
+				DocumentNumber:      "NF-1001",
+				ConfirmedOurNumber:  1234567,
+				OriginalInstruction: santander.InstructionEntry,
+				ErrorCodes:          "000000000",
+				DueDate:             cnab400.NewDate(2026, 11, 10),
+				Amount:              1500.75,
+				CollectingBank:      33,
+				CollectingAgency:    4321,
+				TitleKind:           "01",
+				Fee:                 2.5,
+				OtherExpenses:       1,
+				Rebate:              2,
+				Discount:            3,
+				PaidAmount:          1503.75,
+				Interest:            6,
+				Acceptance:          "N",
+				CreditDate:          cnab400.NewDate(2026, 11, 13),
+				PayerName:           "FULANO DE TAL",
+				Sequence:            2,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			decoded := reflect.New(reflect.TypeOf(scenario.expected))
+			if err := gocnab.Unmarshal(scenario.line(t), decoded.Interface()); err != nil {
+				t.Fatalf("error unmarshalling. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(scenario.expected, decoded.Elem().Interface()) {
+				t.Errorf("expected record “%#v” and got “%#v”", scenario.expected, decoded.Elem().Interface())
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package santander
+
+// InstructionCode is the occurrence code of a título in a CNAB 400 remittance
+// (código de movimento), defining the instruction sent to the bank.
+type InstructionCode string
+
+// List of instruction codes accepted by Santander.
+const (
+	InstructionEntry           InstructionCode = "01"
+	InstructionWriteOff        InstructionCode = "02"
+	InstructionGrantRebate     InstructionCode = "04"
+	InstructionCancelRebate    InstructionCode = "05"
+	InstructionChangeDueDate   InstructionCode = "06"
+	InstructionChangeControl   InstructionCode = "07"
+	InstructionChangeOurNumber InstructionCode = "08"
+	InstructionProtest         InstructionCode = "09"
+	InstructionStopProtest     InstructionCode = "18"
+)
+
+var instructionDescriptions = map[InstructionCode]string{
+	InstructionEntry:           "Entrada de título",
+	InstructionWriteOff:        "Baixa de título",
+	InstructionGrantRebate:     "Concessão de abatimento",
+	InstructionCancelRebate:    "Cancelamento de abatimento",
+	InstructionChangeDueDate:   "Alteração de vencimento",
+	InstructionChangeControl:   "Alteração do número de controle do participante",
+	InstructionChangeOurNumber: "Alteração do seu número",
+	InstructionProtest:         "Protestar",
+	InstructionStopProtest:     "Sustar protesto",
+}
+
+// Description returns the description of the instruction code, or an empty
+// string for unknown codes.
+func (i InstructionCode) Description() string {
+	return instructionDescriptions[i]
+}
+
+// OccurrenceCode is the occurrence code of a título in a CNAB 400 return file
+// (código de ocorrência), informing what happened with it.
+type OccurrenceCode string
+
+// List of occurrence codes informed by Santander.
+const (
+	OccurrenceEntryConfirmed          OccurrenceCode = "02"
+	OccurrenceEntryRejected           OccurrenceCode = "03"
+	OccurrenceSettlement              OccurrenceCode = "06"
+	OccurrenceSettlementByAgency      OccurrenceCode = "07"
+	OccurrenceNotarySettlement        OccurrenceCode = "08"
+	OccurrenceWriteOff                OccurrenceCode = "09"
+	OccurrenceWriteOffRequested       OccurrenceCode = "10"
+	OccurrenceTitleInWallet           OccurrenceCode = "11"
+	OccurrenceRebateGranted           OccurrenceCode = "12"
+	OccurrenceRebateCancelled         OccurrenceCode = "13"
+	OccurrenceDueDateChanged          OccurrenceCode = "14"
+	OccurrenceSentToNotary            OccurrenceCode = "15"
+	OccurrenceSettlementAfterWriteOff OccurrenceCode = "17"
+	OccurrenceProtestConfirmed        OccurrenceCode = "19"
+	OccurrenceStopProtest             OccurrenceCode = "20"
+)
+
+var occurrenceDescriptions = map[OccurrenceCode]string{
+	OccurrenceEntryConfirmed:          "Entrada confirmada",
+	OccurrenceEntryRejected:           "Entrada rejeitada",
+	OccurrenceSettlement:              "Liquidação normal",
+	OccurrenceSettlementByAgency:      "Liquidação por conta",
+	OccurrenceNotarySettlement:        "Liquidação em cartório",
+	OccurrenceWriteOff:                "Baixado automaticamente",
+	OccurrenceWriteOffRequested:       "Baixado conforme instruções",
+	OccurrenceTitleInWallet:           "Títulos em ser",
+	OccurrenceRebateGranted:           "Abatimento concedido",
+	OccurrenceRebateCancelled:         "Abatimento cancelado",
+	OccurrenceDueDateChanged:          "Prorrogação de vencimento",
+	OccurrenceSentToNotary:            "Enviado para cartório",
+	OccurrenceSettlementAfterWriteOff: "Liquidado após baixa",
+	OccurrenceProtestConfirmed:        "Confirmação de recebimento de instrução de protesto",
+	OccurrenceStopProtest:             "Confirmação de recebimento de instrução de sustação de protesto",
+}
+
+// Description returns the description of the occurrence code, or an empty
+// string for unknown codes.
+func (o OccurrenceCode) Description() string {
+	return occurrenceDescriptions[o]
+}
+
+// ErrorCode is the code of an error of a rejected entry or instruction, that
+// Santander informs with 3 digits.
+type ErrorCode string
+
+// List of error codes informed by Santander.
+const (
+	ErrorInvalidAgency        ErrorCode = "001"
+	ErrorInvalidAccount       ErrorCode = "002"
+	ErrorInvalidOurNumber     ErrorCode = "003"
+	ErrorDuplicatedOurNumber  ErrorCode = "004"
+	ErrorInvalidWallet        ErrorCode = "005"
+	ErrorInvalidDueDate       ErrorCode = "007"
+	ErrorInvalidAmount        ErrorCode = "008"
+	ErrorInvalidTitleKind     ErrorCode = "010"
+	ErrorInvalidIssueDate     ErrorCode = "012"
+	ErrorInvalidPayerDocument ErrorCode = "019"
+	ErrorInvalidPayerName     ErrorCode = "020"
+	ErrorInvalidPayerAddress  ErrorCode = "021"
+	ErrorInvalidZipCode       ErrorCode = "022"
+)
+
+var errorDescriptions = map[ErrorCode]string{
+	ErrorInvalidAgency:        "Agência beneficiária não prevista",
+	ErrorInvalidAccount:       "Conta cobrança não prevista",
+	ErrorInvalidOurNumber:     "Nosso número inválido",
+	ErrorDuplicatedOurNumber:  "Nosso número em duplicidade",
+	ErrorInvalidWallet:        "Código da carteira inválido",
+	ErrorInvalidDueDate:       "Data de vencimento inválida",
+	ErrorInvalidAmount:        "Valor do título inválido",
+	ErrorInvalidTitleKind:     "Espécie de documento inválida",
+	ErrorInvalidIssueDate:     "Data de emissão inválida",
+	ErrorInvalidPayerDocument: "CPF/CNPJ do pagador inválido",
+	ErrorInvalidPayerName:     "Nome do pagador não informado",
+	ErrorInvalidPayerAddress:  "Endereço do pagador não informado",
+	ErrorInvalidZipCode:       "CEP inválido",
+}
+
+// Description returns the description of the error code, or an empty string
+// for unknown codes.
+func (e ErrorCode) Description() string {
+	return errorDescriptions[e]
+}
@@ -0,0 +1,38 @@
+// Package santander contains the record types of the Santander cobrança
+// layouts, ready to be used with the cnab240 and cnab400 packages.
+//
+// Santander identifies the company by a transmission code (código de
+// transmissão) instead of the convênio, agency and account used by FEBRABAN,
+// so the CNAB 240 headers and the segments P and T are specific to the bank.
+// The other segments are the ones of the febraban240 package. The CNAB 400
+// records are the Header, Detail and Trailer types (remittance) and the
+// Return prefixed types (return file), where the errors of the rejected
+// entries have 3 digits (see ReturnDetail.Errors).
+//
+//	file := cnab400.File{
+//	  Header: santander.Header{...},
+//	  Details: []cnab400.Detail{
+//	    santander.Detail{...},
+//	  },
+//	  Trailer: santander.Trailer{...},
+//	}
+//
+//	data, err := cnab400.Marshal(file)
+package santander
+
+import (
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+)
+
+// BankCode is the code of Santander in the clearing system.
+const BankCode = 33
+
+// DocumentType identifies the type of the document (inscrição) of a company or
+// person. Santander uses the FEBRABAN codes in both layouts.
+type DocumentType = febraban240.DocumentType
+
+// List of document types.
+const (
+	DocumentTypeCPF  = febraban240.DocumentTypeCPF
+	DocumentTypeCNPJ = febraban240.DocumentTypeCNPJ
+)
@@ -0,0 +1,30 @@
+package santander_test
+
+import (
+	"testing"
+
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/cnab400"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/santander"
+)
+
+func TestLayouts(t *testing.T) {
+	t.Parallel()
+
+	layouttest.CheckCoverage(t, cnab240.LineSize,
+		santander.FileHeader{},
+		santander.CobrancaBatchHeader{},
+		santander.SegmentP{},
+		santander.SegmentT{},
+	)
+
+	layouttest.CheckCoverage(t, cnab400.LineSize,
+		santander.Header{},
+		santander.Detail{},
+		santander.Trailer{},
+		santander.ReturnHeader{},
+		santander.ReturnDetail{},
+		santander.ReturnTrailer{},
+	)
+}
@@ -0,0 +1,376 @@
+package sicoob_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+	"github.com/rafaeljusto/gocnab/layouts/sicoob"
+)
+
+func TestCobranca(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		golden      string
+		fileCode    febraban240.FileCode
+		operation   string
+		segments    []cnab240.Segment
+	}{
+		{
+			description: "it should encode and decode a remittance",
+			golden:      "cnab240_remessa.golden",
+			fileCode:    febraban240.FileCodeRemittance,
+			operation:   febraban240.OperationRemittance,
+			segments: []cnab240.Segment{
+				sicoob.Boleto{
+					P: sicoob.SegmentP{
+						Batch:            1,
+						Sequence:         1,
+						Movement:         febraban240.InstructionEntry,
+						Cooperative:      3069,
+						CooperativeDigit: "0",
+						Account:          123456,
+						AccountDigit:     "7",
+						OurNumber:        10,
+						Installment:      1,
+						Modality:         1,
+						FormType:         4,
+						Wallet:           1,
+						RegistrationType: 0,
+						DocumentKind:     2,
+						IssuanceType:     2,
+						DistributionType: "2",
+						DocumentNumber:   "NF-1001",
+						DueDate:          cnab240.NewDate(2026, 11, 10),
+						Amount:           1500.75,
+						TitleKind:        2,
+						Acceptance:       "N",
+						IssueDate:        cnab240.NewDate(2026, 10, 18),
+						InterestCode:     1,
+						InterestDate:     cnab240.NewDate(2026, 11, 11),
+						Interest:         0.5,
+						CompanyTitleID:   "PEDIDO 1001",
+						ProtestCode:      3,
+						Currency:         9,
+					},
+					Q: febraban240.SegmentQ{
+						Bank:              756,
+						Batch:             1,
+						Sequence:          2,
+						Movement:          febraban240.InstructionEntry,
+						PayerDocumentType: sicoob.DocumentTypeCPF,
+						PayerDocument:     12345678909,
+						PayerName:         "FULANO DE TAL",
+						PayerAddress:      "RUA DAS FLORES 100",
+						PayerDistrict:     "CENTRO",
+						PayerZipCode:      1001,
+						PayerCity:         "SAO PAULO",
+						PayerState:        "SP",
+					},
+				},
+			},
+		},
+		{
+			description: "it should encode and decode a return file",
+			golden:      "cnab240_retorno.golden",
+			fileCode:    febraban240.FileCodeReturn,
+			operation:   febraban240.OperationReturn,
+			segments: []cnab240.Segment{
+				sicoob.BoletoReturn{
+					T: sicoob.SegmentT{
+						Batch:             1,
+						Sequence:          1,
+						Occurrence:        febraban240.OccurrenceSettlement,
+						Cooperative:       3069,
+						CooperativeDigit:  "0",
+						Account:           123456,
+						AccountDigit:      "7",
+						OurNumber:         10,
+						Installment:       1,
+						Modality:          1,
+						FormType:          4,
+						Wallet:            1,
+						DocumentNumber:    "NF-1001",
+						DueDate:           cnab240.NewDate(2026, 11, 10),
+						Amount:            1500.75,
+						CollectingBank:    756,
+						CollectingAgency:  3069,
+						CompanyTitleID:    "PEDIDO 1001",
+						Currency:          9,
+						PayerDocumentType: sicoob.DocumentTypeCPF,
+						PayerDocument:     12345678909,
+						PayerName:         "FULANO DE TAL",
+						Fee:               1.8,
+					},
+					U: febraban240.SegmentU{
+						Bank:           756,
+						Batch:          1,
+						Sequence:       2,
+						Occurrence:     febraban240.OccurrenceSettlement,
+						PaidAmount:     1500.75,
+						NetAmount:      1498.95,
+						OccurrenceDate: cnab240.NewDate(2026, 11, 12),
+						CreditDate:     cnab240.NewDate(2026, 11, 13),
+					},
+				},
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			file := cnab240.File{
+				Header: febraban240.FileHeader{
+					Bank:                756,
+					CompanyDocumentType: sicoob.DocumentTypeCNPJ,
+					CompanyDocument:     12345678000195,
+					Agency:              3069,
+					AgencyDigit:         "0",
+					Account:             123456,
+					AccountDigit:        "7",
+					AgencyAccountDigit:  "0",
+					CompanyName:         "EMPRESA EXEMPLO LTDA",
+					BankName:            "SICOOB",
+					FileCode:            scenario.fileCode,
+					GenerationDate:      cnab240.NewDate(2026, 10, 18),
+					GenerationTime:      cnab240.NewTime(10, 30, 15),
+					FileSequence:        42,
+					LayoutVersion:       "081",
+				},
+				Batches: []cnab240.Batch{
+					{
+						Header: febraban240.CobrancaBatchHeader{
+							Bank:                756,
+							Batch:               1,
+							Operation:           scenario.operation,
+							LayoutVersion:       "040",
+							CompanyDocumentType: sicoob.DocumentTypeCNPJ,
+							CompanyDocument:     12345678000195,
+							Agency:              3069,
+							AgencyDigit:         "0",
+							Account:             123456,
+							AccountDigit:        "7",
+							AgencyAccountDigit:  "0",
+							CompanyName:         "EMPRESA EXEMPLO LTDA",
+							RemittanceNumber:    42,
+							RecordingDate:       cnab240.NewDate(2026, 10, 18),
+						},
+						Segments: scenario.segments,
+						Trailer: febraban240.CobrancaBatchTrailer{
+							Bank:    756,
+							Batch:   1,
+							Records: 4,
+						},
+					},
+				},
+				Trailer: febraban240.FileTrailer{
+					Bank:    756,
+					Batches: 1,
+					Records: 6,
+				},
+			}
+
+			data, err := cnab240.Marshal(layouttest.WithoutFields(file, layouttest.CNAB240ControlFields...))
+			if err != nil {
+				t.Fatalf("error marshalling. details: %s", err)
+			}
+
+			golden := layouttest.CheckGolden(t, scenario.golden, data)
+
+			decoded, err := cnab240.Unmarshal(golden, sicoob.NewCobrancaMapper())
+			if err != nil {
+				t.Fatalf("error unmarshalling. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(file, decoded) {
+				t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+			}
+		})
+	}
+}
+
+func TestCNAB240_spec(t *testing.T) {
+	t.Parallel()
+
+	// lines assembled from the tables of the Sicoob CNAB 240 cobrança
+	// specification, independently of the encoder
+	scenarios := []struct {
+		description string
+		line        func(t *testing.T) []byte
+		expected    interface{}
+	}{
+		{
+			description: "it should decode the segment P",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab240.LineSize,
+					layouttest.Number(1, 3, 756),             // código do Sicoob na compensação
+					layouttest.Number(4, 7, 1),               // lote de serviço
+					layouttest.Text(8, 8, "3"),               // tipo de registro
+					layouttest.Number(9, 13, 1),              // nº sequencial do registro no lote
+					layouttest.Text(14, 14, "P"),             // código do segmento do registro detalhe
+					layouttest.Text(15, 15, ""),              // uso exclusivo FEBRABAN/CNAB
+					layouttest.Number(16, 17, 1),             // código de movimento remessa
+					layouttest.Number(18, 22, 3069),          // prefixo da cooperativa
+					layouttest.Text(23, 23, "8"),             // dígito verificador do prefixo
+					layouttest.Number(24, 35, 123456),        // conta corrente
+					layouttest.Text(36, 36, "7"),             // dígito verificador da conta
+					layouttest.Text(37, 37, ""),              // dígito verificador da cooperativa/conta
+					layouttest.Number(38, 47, 12345),         // nosso número com o dígito verificador
+					layouttest.Number(48, 49, 1),             // número da parcela
+					layouttest.Number(50, 51, 1),             // modalidade
+					layouttest.Number(52, 52, 4),             // tipo de formulário
+					layouttest.Text(53, 57, ""),              // brancos
+					layouttest.Number(58, 58, 1),             // código da carteira
+					layouttest.Number(59, 59, 0),             // forma de cadastramento do título no banco
+					layouttest.Number(60, 60, 0),             // tipo de documento
+					layouttest.Number(61, 61, 2),             // identificação da emissão do boleto
+					layouttest.Text(62, 62, "2"),             // identificação da distribuição do boleto
+					layouttest.Text(63, 77, "NF-1001"),       // número do documento de cobrança
+					layouttest.Text(78, 85, "10112026"),      // data de vencimento do título
+					layouttest.Number(86, 100, 150075),       // valor nominal do título
+					layouttest.Number(101, 105, 0),           // agência encarregada da cobrança
+					layouttest.Text(106, 106, ""),            // dígito verificador da agência
+					layouttest.Number(107, 108, 2),           // espécie do título
+					layouttest.Text(109, 109, "N"),           // identificação de título aceito/não aceito
+					layouttest.Text(110, 117, "20102026"),    // data da emissão do título
+					layouttest.Number(118, 118, 2),           // código do juros de mora
+					layouttest.Text(119, 126, "11112026"),    // data do juros de mora
+					layouttest.Number(127, 141, 100),         // juros de mora por dia/taxa
+					layouttest.Number(142, 142, 1),           // código do desconto 1
+					layouttest.Text(143, 150, "05112026"),    // data do desconto 1
+					layouttest.Number(151, 165, 1000),        // valor/percentual a ser concedido
+					layouttest.Number(166, 180, 0),           // valor do IOF a ser recolhido
+					layouttest.Number(181, 195, 2000),        // valor do abatimento
+					layouttest.Text(196, 220, "PEDIDO 1001"), // identificação do título na empresa
+					layouttest.Number(221, 221, 1),           // código para protesto
+					layouttest.Number(222, 223, 5),           // número de dias para protesto
+					layouttest.Number(224, 224, 0),           // código para baixa/devolução
+					layouttest.Number(225, 227, 0),           // número de dias para baixa/devolução
+					layouttest.Number(228, 229, 9),           // código da moeda
+					layouttest.Number(230, 239, 0),           // nº do contrato da operação de crédito
+					layouttest.Text(240, 240, ""),            // uso exclusivo FEBRABAN/CNAB
+				)
+			},
+			expected: sicoob.SegmentP{
+				Batch:            1,
+				Sequence:         1,
+				Movement:         febraban240.InstructionEntry,
+				Cooperative:      3069,
+				CooperativeDigit: "8",
+				Account:          123456,
+				AccountDigit:     "7",
+				OurNumber:        12345,
+				Installment:      1,
+				Modality:         1,
+				FormType:         4,
+				Wallet:           1,
+				IssuanceType:     2,
+				DistributionType: "2",
+				DocumentNumber:   "NF-1001",
+				DueDate:          cnab240.NewDate(2026, 11, 10),
+				Amount:           1500.75,
+				TitleKind:        2,
+				Acceptance:       "N",
+				IssueDate:        cnab240.NewDate(2026, 10, 20),
+				InterestCode:     2,
+				InterestDate:     cnab240.NewDate(2026, 11, 11),
+				Interest:         1,
+				DiscountCode:     1,
+				DiscountDate:     cnab240.NewDate(2026, 11, 5),
+				Discount:         10,
+				Rebate:           20,
+				CompanyTitleID:   "PEDIDO 1001",
+				ProtestCode:      1,
+				ProtestDays:      5,
+				Currency:         9,
+			},
+		},
+		{
+			description: "it should decode the segment T",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab240.LineSize,
+					layouttest.Number(1, 3, 756),               // código do Sicoob na compensação
+					layouttest.Number(4, 7, 1),                 // lote de serviço
+					layouttest.Text(8, 8, "3"),                 // tipo de registro
+					layouttest.Number(9, 13, 1),                // nº sequencial do registro no lote
+					layouttest.Text(14, 14, "T"),               // código do segmento do registro detalhe
+					layouttest.Text(15, 15, ""),                // uso exclusivo FEBRABAN/CNAB
+					layouttest.Number(16, 17, 6),               // código de movimento retorno
+					layouttest.Number(18, 22, 3069),            // prefixo da cooperativa
+					layouttest.Text(23, 23, "8"),               // dígito verificador do prefixo
+					layouttest.Number(24, 35, 123456),          // conta corrente
+					layouttest.Text(36, 36, "7"),               // dígito verificador da conta
+					layouttest.Text(37, 37, ""),                // dígito verificador da cooperativa/conta
+					layouttest.Number(38, 47, 12345),           // nosso número com o dígito verificador
+					layouttest.Number(48, 49, 1),               // número da parcela
+					layouttest.Number(50, 51, 1),               // modalidade
+					layouttest.Number(52, 52, 4),               // tipo de formulário
+					layouttest.Text(53, 57, ""),                // brancos
+					layouttest.Number(58, 58, 1),               // código da carteira
+					layouttest.Text(59, 73, "NF-1001"),         // número do documento de cobrança
+					layouttest.Text(74, 81, "10112026"),        // data do vencimento do título
+					layouttest.Number(82, 96, 150075),          // valor nominal do título
+					layouttest.Number(97, 99, 756),             // número do banco cobrador/recebedor
+					layouttest.Number(100, 104, 3069),          // cooperativa cobradora/recebedora
+					layouttest.Text(105, 105, "8"),             // dígito verificador da cooperativa
+					layouttest.Text(106, 130, "PEDIDO 1001"),   // identificação do título na empresa
+					layouttest.Number(131, 132, 9),             // código da moeda
+					layouttest.Number(133, 133, 1),             // tipo de inscrição do pagador
+					layouttest.Number(134, 148, 12345678909),   // número de inscrição do pagador
+					layouttest.Text(149, 188, "FULANO DE TAL"), // nome do pagador
+					layouttest.Number(189, 198, 0),             // nº do contrato da operação de crédito
+					layouttest.Number(199, 213, 250),           // valor da tarifa/custas
+					layouttest.Text(214, 223, "A1B2"),          // motivo da ocorrência
+					layouttest.Text(224, 240, ""),              // uso exclusivo FEBRABAN/CNAB
+				)
+			},
+			expected: sicoob.SegmentT{
+				Batch:                 1,
+				Sequence:              1,
+				Occurrence:            febraban240.OccurrenceSettlement,
+				Cooperative:           3069,
+				CooperativeDigit:      "8",
+				Account:               123456,
+				AccountDigit:          "7",
+				OurNumber:             12345,
+				Installment:           1,
+				Modality:              1,
+				FormType:              4,
+				Wallet:                1,
+				DocumentNumber:        "NF-1001",
+				DueDate:               cnab240.NewDate(2026, 11, 10),
+				Amount:                1500.75,
+				CollectingBank:        756,
+				CollectingAgency:      3069,
+				CollectingAgencyDigit: "8",
+				CompanyTitleID:        "PEDIDO 1001",
+				Currency:              9,
+				PayerDocumentType:     sicoob.DocumentTypeCPF,
+				PayerDocument:         12345678909,
+				PayerName:             "FULANO DE TAL",
+				Fee:                   2.5,
+				OccurrenceReasons:     "A1B2",
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			decoded := reflect.New(reflect.TypeOf(scenario.expected))
+			if err := gocnab.Unmarshal(scenario.line(t), decoded.Interface()); err != nil {
+				t.Fatalf("error unmarshalling. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(scenario.expected, decoded.Elem().Interface()) {
+				t.Errorf("expected record “%#v” and got “%#v”", scenario.expected, decoded.Elem().Interface())
+			}
+		})
+	}
+}
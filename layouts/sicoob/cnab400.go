@@ -0,0 +1,194 @@
+package sicoob
+
+import (
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab400"
+)
+
+// RecordTypeDetail is the record type of the CNAB 400 details.
+const RecordTypeDetail = "1"
+
+// Header is the header of a CNAB 400 remittance file (registro 0).
+type Header struct {
+	_                string       `cnab:"0,1,const=0"`
+	_                string       `cnab:"1,2,const=1"`
+	_                string       `cnab:"2,9,const=REMESSA"`
+	_                string       `cnab:"9,11,const=01"`
+	_                string       `cnab:"11,19,const=COBRANCA"`
+	_                string       `cnab:"19,26"`
+	Cooperative      int          `cnab:"26,30"`
+	CooperativeDigit string       `cnab:"30,31"`
+	Client           int          `cnab:"31,39"`
+	ClientDigit      string       `cnab:"39,40"`
+	_                string       `cnab:"40,46"`
+	CompanyName      string       `cnab:"46,76"`
+	_                string       `cnab:"76,94,const=756BANCOOBCED"`
+	RecordingDate    cnab400.Date `cnab:"94,100"`
+	RemittanceNumber int          `cnab:"100,107"`
+	_                string       `cnab:"107,394"`
+	Sequence         int          `cnab:"394,400"`
+}
+
+// Detail contains the data of a título in a CNAB 400 remittance file
+// (registro 1). The nosso número has 12 digits, including the check digit,
+// and the interest and fine rates are percentages with 4 decimal places (for
+// example, 20000 is 2%).
+type Detail struct {
+	_                   string          `cnab:"0,1,const=1"`
+	CompanyDocumentType DocumentType    `cnab:"1,3"`
+	CompanyDocument     int64           `cnab:"3,17"`
+	Cooperative         int             `cnab:"17,21"`
+	CooperativeDigit    string          `cnab:"21,22"`
+	Account             int             `cnab:"22,30"`
+	AccountDigit        string          `cnab:"30,31"`
+	_                   string          `cnab:"31,37,const=000000"`
+	ControlNumber       string          `cnab:"37,62"`
+	OurNumber           int64           `cnab:"62,74"`
+	Installment         int             `cnab:"74,76"`
+	_                   string          `cnab:"76,78,const=00"`
+	_                   string          `cnab:"78,81"`
+	GuarantorIndicator  string          `cnab:"81,82"`
+	_                   string          `cnab:"82,85"`
+	_                   string          `cnab:"85,88,const=000"`
+	_                   string          `cnab:"88,89,const=0"`
+	_                   string          `cnab:"89,95,const=000000"`
+	_                   string          `cnab:"95,101,const=000000"`
+	_                   string          `cnab:"101,105"`
+	IssuanceType        int             `cnab:"105,106"`
+	Modality            int             `cnab:"106,108"`
+	Command             InstructionCode `cnab:"108,110"`
+	DocumentNumber      string          `cnab:"110,120"`
+	DueDate             cnab400.Date    `cnab:"120,126"`
+	Amount              float64         `cnab:"126,139"`
+	_                   string          `cnab:"139,142,const=756"`
+	CollectingAgency    int             `cnab:"142,146"`
+	CollectingDigit     string          `cnab:"146,147"`
+	TitleKind           string          `cnab:"147,149"`
+	Acceptance          string          `cnab:"149,150"`
+	IssueDate           cnab400.Date    `cnab:"150,156"`
+	Instruction1        string          `cnab:"156,158"`
+	Instruction2        string          `cnab:"158,160"`
+	InterestRate        int             `cnab:"160,166"`
+	FineRate            int             `cnab:"166,172"`
+	DistributionType    string          `cnab:"172,173"`
+	DiscountDate        cnab400.Date    `cnab:"173,179"`
+	Discount            float64         `cnab:"179,192"`
+	IOF                 float64         `cnab:"192,205"`
+	Rebate              float64         `cnab:"205,218"`
+	PayerDocumentType   DocumentType    `cnab:"218,220"`
+	PayerDocument       int64           `cnab:"220,234"`
+	PayerName           string          `cnab:"234,274"`
+	PayerAddress        string          `cnab:"274,311"`
+	PayerDistrict       string          `cnab:"311,326"`
+	PayerZipCode        int             `cnab:"326,334"`
+	PayerCity           string          `cnab:"334,349"`
+	PayerState          string          `cnab:"349,351"`
+	Notes               string          `cnab:"351,391"`
+	ProtestDays         int             `cnab:"391,393"`
+	_                   string          `cnab:"393,394"`
+	Sequence            int             `cnab:"394,400"`
+}
+
+// Trailer is the trailer of a CNAB 400 remittance file (registro 9).
+type Trailer struct {
+	_        string `cnab:"0,1,const=9"`
+	_        string `cnab:"1,394"`
+	Sequence int    `cnab:"394,400"`
+}
+
+// ReturnHeader is the header of a CNAB 400 return file (registro 0).
+type ReturnHeader struct {
+	_                string       `cnab:"0,1,const=0"`
+	_                string       `cnab:"1,2,const=2"`
+	_                string       `cnab:"2,9,const=RETORNO"`
+	_                string       `cnab:"9,11,const=01"`
+	_                string       `cnab:"11,19,const=COBRANCA"`
+	_                string       `cnab:"19,26"`
+	Cooperative      int          `cnab:"26,30"`
+	CooperativeDigit string       `cnab:"30,31"`
+	Client           int          `cnab:"31,39"`
+	ClientDigit      string       `cnab:"39,40"`
+	_                string       `cnab:"40,46"`
+	CompanyName      string       `cnab:"46,76"`
+	_                string       `cnab:"76,94,const=756BANCOOBCED"`
+	RecordingDate    cnab400.Date `cnab:"94,100"`
+	ReturnNumber     int          `cnab:"100,107"`
+	_                string       `cnab:"107,394"`
+	Sequence         int          `cnab:"394,400"`
+}
+
+// ReturnDetail contains the occurrence of a título in a CNAB 400 return file
+// (registro 1).
+type ReturnDetail struct {
+	_                     string         `cnab:"0,1,const=1"`
+	CompanyDocumentType   DocumentType   `cnab:"1,3"`
+	CompanyDocument       int64          `cnab:"3,17"`
+	Cooperative           int            `cnab:"17,21"`
+	CooperativeDigit      string         `cnab:"21,22"`
+	Account               int            `cnab:"22,30"`
+	AccountDigit          string         `cnab:"30,31"`
+	_                     string         `cnab:"31,37"`
+	ControlNumber         string         `cnab:"37,62"`
+	OurNumber             int64          `cnab:"62,74"`
+	Installment           int            `cnab:"74,76"`
+	_                     string         `cnab:"76,106"`
+	Modality              int            `cnab:"106,108"`
+	Command               OccurrenceCode `cnab:"108,110"`
+	OccurrenceDate        cnab400.Date   `cnab:"110,116"`
+	DocumentNumber        string         `cnab:"116,126"`
+	_                     string         `cnab:"126,146"`
+	DueDate               cnab400.Date   `cnab:"146,152"`
+	Amount                float64        `cnab:"152,165"`
+	CollectingBank        int            `cnab:"165,168"`
+	CollectingAgency      int            `cnab:"168,172"`
+	CollectingAgencyDigit string         `cnab:"172,173"`
+	TitleKind             string         `cnab:"173,175"`
+	CreditDate            cnab400.Date   `cnab:"175,181"`
+	Fee                   float64        `cnab:"181,188"`
+	OtherExpenses         float64        `cnab:"188,201"`
+	DiscountInterest      float64        `cnab:"201,214"`
+	DiscountIOF           float64        `cnab:"214,227"`
+	Rebate                float64        `cnab:"227,240"`
+	Discount              float64        `cnab:"240,253"`
+	PaidAmount            float64        `cnab:"253,266"`
+	Interest              float64        `cnab:"266,279"`
+	OtherCredits          float64        `cnab:"279,292"`
+	UnusedRebate          float64        `cnab:"292,305"`
+	EntryAmount           float64        `cnab:"305,318"`
+	DebitCredit           string         `cnab:"318,319"`
+	AdjustmentIndicator   string         `cnab:"319,320"`
+	Adjustment            float64        `cnab:"320,332"`
+	_                     string         `cnab:"332,394"`
+	Sequence              int            `cnab:"394,400"`
+}
+
+// ReturnTrailer is the trailer of a CNAB 400 return file (registro 9).
+type ReturnTrailer struct {
+	_           string `cnab:"0,1,const=9"`
+	_           string `cnab:"1,2,const=2"`
+	_           string `cnab:"2,4,const=01"`
+	_           string `cnab:"4,7,const=756"`
+	Cooperative int    `cnab:"7,11"`
+	_           string `cnab:"11,394"`
+	Sequence    int    `cnab:"394,400"`
+}
+
+// NewRemittanceMapper returns a mapper with the CNAB 400 remittance record
+// types, to be used with cnab400.Unmarshal.
+func NewRemittanceMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*Header)(nil), cnab400.MatchRecordType(cnab400.RecordTypeHeader))
+	mapper.Register((*Detail)(nil), cnab400.MatchRecordType(RecordTypeDetail))
+	mapper.Register((*Trailer)(nil), cnab400.MatchRecordType(cnab400.RecordTypeTrailer))
+	return mapper
+}
+
+// NewReturnMapper returns a mapper with the CNAB 400 return record types, to be
+// used with cnab400.Unmarshal.
+func NewReturnMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*ReturnHeader)(nil), cnab400.MatchRecordType(cnab400.RecordTypeHeader))
+	mapper.Register((*ReturnDetail)(nil), cnab400.MatchRecordType(RecordTypeDetail))
+	mapper.Register((*ReturnTrailer)(nil), cnab400.MatchRecordType(cnab400.RecordTypeTrailer))
+	return mapper
+}
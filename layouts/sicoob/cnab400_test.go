@@ -0,0 +1,363 @@
+package sicoob_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab400"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/sicoob"
+)
+
+func TestRemittance(t *testing.T) {
+	t.Parallel()
+
+	file := cnab400.File{
+		Header: sicoob.Header{
+			Cooperative:      3069,
+			CooperativeDigit: "0",
+			Client:           123456,
+			ClientDigit:      "7",
+			CompanyName:      "EMPRESA EXEMPLO LTDA",
+			RecordingDate:    cnab400.NewDate(2026, 10, 18),
+			RemittanceNumber: 42,
+			Sequence:         1,
+		},
+		Details: []cnab400.Detail{
+			sicoob.Detail{
+				CompanyDocumentType: sicoob.DocumentTypeCNPJ,
+				CompanyDocument:     12345678000195,
+				Cooperative:         3069,
+				CooperativeDigit:    "0",
+				Account:             123456,
+				AccountDigit:        "7",
+				ControlNumber:       "PEDIDO 1001",
+				OurNumber:           10,
+				Installment:         1,
+				IssuanceType:        2,
+				Modality:            1,
+				Command:             sicoob.InstructionEntry,
+				DocumentNumber:      "NF-1001",
+				DueDate:             cnab400.NewDate(2026, 11, 10),
+				Amount:              1500.75,
+				CollectingAgency:    3069,
+				CollectingDigit:     "0",
+				TitleKind:           "01",
+				Acceptance:          "N",
+				IssueDate:           cnab400.NewDate(2026, 10, 18),
+				InterestRate:        10000,
+				FineRate:            20000,
+				DistributionType:    "2",
+				PayerDocumentType:   sicoob.DocumentTypeCPF,
+				PayerDocument:       12345678909,
+				PayerName:           "FULANO DE TAL",
+				PayerAddress:        "RUA DAS FLORES 100",
+				PayerDistrict:       "CENTRO",
+				PayerZipCode:        1001000,
+				PayerCity:           "SAO PAULO",
+				PayerState:          "SP",
+				Sequence:            2,
+			},
+		},
+		Trailer: sicoob.Trailer{
+			Sequence: 3,
+		},
+	}
+
+	data, err := cnab400.Marshal(layouttest.WithoutFields(file, layouttest.CNAB400ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "cnab400_remessa.golden", data)
+
+	decoded, err := cnab400.Unmarshal(golden, sicoob.NewRemittanceMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+}
+
+func TestReturn(t *testing.T) {
+	t.Parallel()
+
+	file := cnab400.File{
+		Header: sicoob.ReturnHeader{
+			Cooperative:      3069,
+			CooperativeDigit: "0",
+			Client:           123456,
+			ClientDigit:      "7",
+			CompanyName:      "EMPRESA EXEMPLO LTDA",
+			RecordingDate:    cnab400.NewDate(2026, 11, 12),
+			ReturnNumber:     15,
+			Sequence:         1,
+		},
+		Details: []cnab400.Detail{
+			sicoob.ReturnDetail{
+				CompanyDocumentType: sicoob.DocumentTypeCNPJ,
+				CompanyDocument:     12345678000195,
+				Cooperative:         3069,
+				CooperativeDigit:    "0",
+				Account:             123456,
+				AccountDigit:        "7",
+				ControlNumber:       "PEDIDO 1001",
+				OurNumber:           10,
+				Installment:         1,
+				Modality:            1,
+				Command:             sicoob.OccurrenceSettlement,
+				OccurrenceDate:      cnab400.NewDate(2026, 11, 12),
+				DocumentNumber:      "NF-1001",
+				DueDate:             cnab400.NewDate(2026, 11, 10),
+				Amount:              1500.75,
+				CollectingBank:      756,
+				CollectingAgency:    3069,
+				TitleKind:           "01",
+				CreditDate:          cnab400.NewDate(2026, 11, 13),
+				Fee:                 1.8,
+				PaidAmount:          1500.75,
+				EntryAmount:         1498.95,
+				DebitCredit:         "2",
+				Sequence:            2,
+			},
+		},
+		Trailer: sicoob.ReturnTrailer{
+			Cooperative: 3069,
+			Sequence:    3,
+		},
+	}
+
+	data, err := cnab400.Marshal(layouttest.WithoutFields(file, layouttest.CNAB400ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "cnab400_retorno.golden", data)
+
+	decoded, err := cnab400.Unmarshal(golden, sicoob.NewReturnMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+
+	detail := decoded.Details[0].(sicoob.ReturnDetail)
+	if description := detail.Command.Description(); description != "Liquidação normal" {
+		t.Errorf("unexpected occurrence description “%s”", description)
+	}
+}
+
+func TestCNAB400_spec(t *testing.T) {
+	t.Parallel()
+
+	// lines assembled from the tables of the Sicoob CNAB 400 cobrança
+	// specification, independently of the encoder
+	scenarios := []struct {
+		description string
+		line        func(t *testing.T) []byte
+		expected    interface{}
+	}{
+		{
+			description: "it should decode the remittance detail",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab400.LineSize,
+					layouttest.Text(1, 1, "1"),                      // identificação do registro detalhe
+					layouttest.Number(2, 3, 2),                      // tipo de inscrição do beneficiário
+					layouttest.Number(4, 17, 12345678000195),        // número do CPF/CNPJ do beneficiário
+					layouttest.Number(18, 21, 3069),                 // prefixo da cooperativa
+					layouttest.Text(22, 22, "8"),                    // dígito verificador do prefixo
+					layouttest.Number(23, 30, 123456),               // conta corrente
+					layouttest.Text(31, 31, "7"),                    // dígito verificador da conta
+					layouttest.Number(32, 37, 0),                    // número do convênio de cobrança
+					layouttest.Text(38, 62, "PEDIDO 1001"),          // número de controle do participante
+					layouttest.Number(63, 74, 12345),                // nosso número com o dígito verificador
+					layouttest.Number(75, 76, 1),                    // número da parcela
+					layouttest.Number(77, 78, 0),                    // grupo de valor
+					layouttest.Text(79, 81, ""),                     // complemento do registro
+					layouttest.Text(82, 82, "A"),                    // indicativo de mensagem ou sacador/avalista
+					layouttest.Text(83, 85, ""),                     // prefixo do título
+					layouttest.Number(86, 88, 0),                    // variação da carteira
+					layouttest.Number(89, 89, 0),                    // conta caução
+					layouttest.Number(90, 94, 0),                    // número do contrato garantia
+					layouttest.Number(95, 95, 0),                    // dígito verificador do contrato
+					layouttest.Number(96, 101, 0),                   // número do borderô
+					layouttest.Text(102, 105, ""),                   // complemento do registro
+					layouttest.Number(106, 106, 2),                  // tipo de emissão
+					layouttest.Number(107, 108, 1),                  // carteira/modalidade
+					layouttest.Text(109, 110, "01"),                 // comando/movimento
+					layouttest.Text(111, 120, "NF-1001"),            // seu número
+					layouttest.Text(121, 126, "101126"),             // data de vencimento do título
+					layouttest.Number(127, 139, 150075),             // valor do título
+					layouttest.Number(140, 142, 756),                // número do banco
+					layouttest.Number(143, 146, 3069),               // prefixo da cooperativa
+					layouttest.Text(147, 147, "8"),                  // dígito verificador do prefixo
+					layouttest.Text(148, 149, "01"),                 // espécie do título
+					layouttest.Text(150, 150, "0"),                  // aceite do título
+					layouttest.Text(151, 156, "181026"),             // data de emissão do título
+					layouttest.Text(157, 158, "00"),                 // primeira instrução
+					layouttest.Text(159, 160, "00"),                 // segunda instrução
+					layouttest.Number(161, 166, 10000),              // taxa de mora mês
+					layouttest.Number(167, 172, 20000),              // taxa de multa
+					layouttest.Text(173, 173, "2"),                  // tipo de distribuição
+					layouttest.Text(174, 179, "051126"),             // data do primeiro desconto
+					layouttest.Number(180, 192, 1000),               // valor do primeiro desconto
+					layouttest.Number(193, 205, 0),                  // valor do IOF
+					layouttest.Number(206, 218, 2000),               // valor do abatimento
+					layouttest.Number(219, 220, 1),                  // tipo de inscrição do pagador
+					layouttest.Number(221, 234, 12345678909),        // número do CPF/CNPJ do pagador
+					layouttest.Text(235, 274, "FULANO DE TAL"),      // nome do pagador
+					layouttest.Text(275, 311, "RUA DAS FLORES 100"), // endereço do pagador
+					layouttest.Text(312, 326, "CENTRO"),             // bairro do pagador
+					layouttest.Number(327, 334, 1001000),            // CEP do pagador
+					layouttest.Text(335, 349, "SAO PAULO"),          // cidade do pagador
+					layouttest.Text(350, 351, "SP"),                 // UF do pagador
+					layouttest.Text(352, 391, "AVALISTA EXEMPLO"),   // observações/mensagem ou sacador/avalista
+					layouttest.Number(392, 393, 10),                 // número de dias para protesto
+					layouttest.Text(394, 394, ""),                   // complemento do registro
+					layouttest.Number(395, 400, 2),                  // sequencial do registro
+				)
+			},
+			expected: sicoob.Detail{
+				CompanyDocumentType: sicoob.DocumentTypeCNPJ,
+				CompanyDocument:     12345678000195,
+				Cooperative:         3069,
+				CooperativeDigit:    "8",
+				Account:             123456,
+				AccountDigit:        "7",
+				ControlNumber:       "PEDIDO 1001",
+				OurNumber:           12345,
+				Installment:         1,
+				GuarantorIndicator:  "A",
+				IssuanceType:        2,
+				Modality:            1,
+				Command:             sicoob.InstructionEntry,
+				DocumentNumber:      "NF-1001",
+				DueDate:             cnab400.NewDate(2026, 11, 10),
+				Amount:              1500.75,
+				CollectingAgency:    3069,
+				CollectingDigit:     "8",
+				TitleKind:           "01",
+				Acceptance:          "0",
+				IssueDate:           cnab400.NewDate(2026, 10, 18),
+				Instruction1:        "00",
+				Instruction2:        "00",
+				InterestRate:        10000,
+				FineRate:            20000,
+				DistributionType:    "2",
+				DiscountDate:        cnab400.NewDate(2026, 11, 5),
+				Discount:            10,
+				Rebate:              20,
+				PayerDocumentType:   sicoob.DocumentTypeCPF,
+				PayerDocument:       12345678909,
+				PayerName:           "FULANO DE TAL",
+				PayerAddress:        "RUA DAS FLORES 100",
+				PayerDistrict:       "CENTRO",
+				PayerZipCode:        1001000,
+				PayerCity:           "SAO PAULO",
+				PayerState:          "SP",
+				Notes:               "AVALISTA EXEMPLO",
+				ProtestDays:         10,
+				Sequence:            2,
+			},
+		},
+		{
+			description: "it should decode the return detail",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab400.LineSize,
+					layouttest.Text(1, 1, "1"),               // identificação do registro detalhe
+					layouttest.Number(2, 3, 2),               // tipo de inscrição do beneficiário
+					layouttest.Number(4, 17, 12345678000195), // número do CPF/CNPJ do beneficiário
+					layouttest.Number(18, 21, 3069),          // prefixo da cooperativa
+					layouttest.Text(22, 22, "8"),             // dígito verificador do prefixo
+					layouttest.Number(23, 30, 123456),        // conta corrente
+					layouttest.Text(31, 31, "7"),             // dígito verificador da conta
+					layouttest.Number(32, 37, 0),             // número do convênio de cobrança
+					layouttest.Text(38, 62, "PEDIDO 1001"),   // número de controle do participante
+					layouttest.Number(63, 74, 12345),         // nosso número com o dígito verificador
+					layouttest.Number(75, 76, 1),             // número da parcela
+					layouttest.Number(77, 106, 0),            // grupo de valor, baixa, carteira e taxas
+					layouttest.Number(107, 108, 1),           // carteira/modalidade
+					layouttest.Text(109, 110, "06"),          // comando/movimento
+					layouttest.Text(111, 116, "121126"),      // data da entrada/liquidação
+					layouttest.Text(117, 126, "NF-1001"),     // seu número
+					layouttest.Text(127, 146, ""),            // complemento do registro
+					layouttest.Text(147, 152, "101126"),      // data de vencimento do título
+					layouttest.Number(153, 165, 150075),      // valor do título
+					layouttest.Number(166, 168, 756),         // código do banco recebedor
+					layouttest.Number(169, 172, 3069),        // prefixo da cooperativa recebedora
+					layouttest.Text(173, 173, "8"),           // dígito verificador do prefixo
+					layouttest.Text(174, 175, "01"),          // espécie do título
+					layouttest.Text(176, 181, "131126"),      // data do crédito
+					layouttest.Number(182, 188, 250),         // valor da tarifa
+					layouttest.Number(189, 201, 100),         // outras despesas
+					layouttest.Number(202, 214, 0),           // juros do desconto
+					layouttest.Number(215, 227, 0),           // IOF do desconto
+					layouttest.Number(228, 240, 200),         // valor do abatimento
+					layouttest.Number(241, 253, 300),         // desconto concedido
+					layouttest.Number(254, 266, 150375),      // valor recebido
+					layouttest.Number(267, 279, 600),         // juros de mora
+					layouttest.Number(280, 292, 0),           // outros recebimentos
+					layouttest.Number(293, 305, 0),           // abatimento não aproveitado pelo pagador
+					layouttest.Number(306, 318, 150125),      // valor do lançamento
+					layouttest.Text(319, 319, "2"),           // indicativo de débito/crédito
+					layouttest.Text(320, 320, "0"),           // indicador de valor
+					layouttest.Number(321, 332, 0),           // valor do ajuste
+					layouttest.Text(333, 394, ""),            // complemento do registro
+					layouttest.Number(395, 400, 2),           // sequencial do registro
+				)
+			},
+			expected: sicoob.ReturnDetail{
+				CompanyDocumentType:   sicoob.DocumentTypeCNPJ,
+				CompanyDocument:       12345678000195,
+				Cooperative:           3069,
+				CooperativeDigit:      "8",
+				Account:               123456,
+				AccountDigit:          "7",
+				ControlNumber:         "PEDIDO 1001",
+				OurNumber:             12345,
+				Installment:           1,
+				Modality:              1,
+				Command:               sicoob.OccurrenceSettlement,
+				OccurrenceDate:        cnab400.NewDate(2026, 11, 12),
+				DocumentNumber:        "NF-1001",
+				DueDate:               cnab400.NewDate(2026, 11, 10),
+				Amount:                1500.75,
+				CollectingBank:        756,
+				CollectingAgency:      3069,
+				CollectingAgencyDigit: "8",
+				TitleKind:             "01",
+				CreditDate:            cnab400.NewDate(2026, 11, 13),
+				Fee:                   2.5,
+				OtherExpenses:         1,
+				Rebate:                2,
+				Discount:              3,
+				PaidAmount:            1503.75,
+				Interest:              6,
+				EntryAmount:           1501.25,
+				DebitCredit:           "2",
+				AdjustmentIndicator:   "0",
+				Sequence:              2,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			decoded := reflect.New(reflect.TypeOf(scenario.expected))
+			if err := gocnab.Unmarshal(scenario.line(t), decoded.Interface()); err != nil {
+				t.Fatalf("error unmarshalling. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(scenario.expected, decoded.Elem().Interface()) {
+				t.Errorf("expected record “%#v” and got “%#v”", scenario.expected, decoded.Elem().Interface())
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package sicoob
+
+// InstructionCode is the command of a título in a CNAB 400 remittance
+// (comando/movimento), defining the instruction sent to the bank.
+type InstructionCode string
+
+// List of instruction codes accepted by Sicoob.
+const (
+	InstructionEntry           InstructionCode = "01"
+	InstructionWriteOff        InstructionCode = "02"
+	InstructionGrantRebate     InstructionCode = "04"
+	InstructionCancelRebate    InstructionCode = "05"
+	InstructionChangeDueDate   InstructionCode = "06"
+	InstructionChangeOurNumber InstructionCode = "08"
+	InstructionProtest         InstructionCode = "09"
+	InstructionStopProtest     InstructionCode = "10"
+	InstructionWaiveInterest   InstructionCode = "11"
+	InstructionChangePayer     InstructionCode = "12"
+	InstructionChangeOtherData InstructionCode = "31"
+	InstructionDirectPayment   InstructionCode = "34"
+)
+
+var instructionDescriptions = map[InstructionCode]string{
+	InstructionEntry:           "Registro de títulos",
+	InstructionWriteOff:        "Solicitação de baixa",
+	InstructionGrantRebate:     "Concessão de abatimento",
+	InstructionCancelRebate:    "Cancelamento de abatimento",
+	InstructionChangeDueDate:   "Alteração de vencimento",
+	InstructionChangeOurNumber: "Alteração de seu número",
+	InstructionProtest:         "Instrução para protestar",
+	InstructionStopProtest:     "Instrução para sustar protesto",
+	InstructionWaiveInterest:   "Instrução para dispensar juros",
+	InstructionChangePayer:     "Alteração de pagador",
+	InstructionChangeOtherData: "Alteração de outros dados",
+	InstructionDirectPayment:   "Baixa - pagamento direto ao beneficiário",
+}
+
+// Description returns the description of the instruction code, or an empty
+// string for unknown codes.
+func (i InstructionCode) Description() string {
+	return instructionDescriptions[i]
+}
+
+// OccurrenceCode is the command of a título in a CNAB 400 return file
+// (comando/movimento), informing what happened with it.
+type OccurrenceCode string
+
+// List of occurrence codes informed by Sicoob.
+const (
+	OccurrenceEntryConfirmed      OccurrenceCode = "02"
+	OccurrenceSettlementNoEntry   OccurrenceCode = "05"
+	OccurrenceSettlement          OccurrenceCode = "06"
+	OccurrenceWriteOff            OccurrenceCode = "09"
+	OccurrenceWriteOffRequested   OccurrenceCode = "10"
+	OccurrenceTitleInWallet       OccurrenceCode = "11"
+	OccurrenceRebateGranted       OccurrenceCode = "12"
+	OccurrenceRebateCancelled     OccurrenceCode = "13"
+	OccurrenceDueDateChanged      OccurrenceCode = "14"
+	OccurrenceNotarySettlement    OccurrenceCode = "15"
+	OccurrenceProtestConfirmed    OccurrenceCode = "19"
+	OccurrenceAccountDebit        OccurrenceCode = "20"
+	OccurrencePayerNameChanged    OccurrenceCode = "21"
+	OccurrencePayerAddressChanged OccurrenceCode = "22"
+	OccurrenceSentToNotary        OccurrenceCode = "23"
+	OccurrenceStopProtest         OccurrenceCode = "24"
+	OccurrenceInterestWaived      OccurrenceCode = "25"
+	OccurrenceInstructionRejected OccurrenceCode = "26"
+	OccurrenceDataChangeConfirmed OccurrenceCode = "27"
+	OccurrenceOverdueMaintenance  OccurrenceCode = "28"
+	OccurrenceDataChangeRejected  OccurrenceCode = "30"
+	OccurrenceProtestFees         OccurrenceCode = "96"
+	OccurrenceStopProtestFees     OccurrenceCode = "97"
+	OccurrenceAdvanceFeesDebit    OccurrenceCode = "98"
+)
+
+var occurrenceDescriptions = map[OccurrenceCode]string{
+	OccurrenceEntryConfirmed:      "Confirmação de entrada de título",
+	OccurrenceSettlementNoEntry:   "Liquidação sem registro",
+	OccurrenceSettlement:          "Liquidação normal",
+	OccurrenceWriteOff:            "Baixa de título",
+	OccurrenceWriteOffRequested:   "Baixa solicitada",
+	OccurrenceTitleInWallet:       "Títulos em ser",
+	OccurrenceRebateGranted:       "Abatimento concedido",
+	OccurrenceRebateCancelled:     "Abatimento cancelado",
+	OccurrenceDueDateChanged:      "Alteração de vencimento",
+	OccurrenceNotarySettlement:    "Liquidação em cartório",
+	OccurrenceProtestConfirmed:    "Confirmação de instrução de protesto",
+	OccurrenceAccountDebit:        "Débito em conta",
+	OccurrencePayerNameChanged:    "Alteração de nome do pagador",
+	OccurrencePayerAddressChanged: "Alteração de endereço do pagador",
+	OccurrenceSentToNotary:        "Encaminhado a protesto",
+	OccurrenceStopProtest:         "Sustar protesto",
+	OccurrenceInterestWaived:      "Dispensar juros",
+	OccurrenceInstructionRejected: "Instrução rejeitada",
+	OccurrenceDataChangeConfirmed: "Confirmação de alteração de dados",
+	OccurrenceOverdueMaintenance:  "Manutenção de título vencido",
+	OccurrenceDataChangeRejected:  "Alteração de dados rejeitada",
+	OccurrenceProtestFees:         "Despesas de protesto",
+	OccurrenceStopProtestFees:     "Despesas de sustação de protesto",
+	OccurrenceAdvanceFeesDebit:    "Débito de custas antecipadas",
+}
+
+// Description returns the description of the occurrence code, or an empty
+// string for unknown codes.
+func (o OccurrenceCode) Description() string {
+	return occurrenceDescriptions[o]
+}
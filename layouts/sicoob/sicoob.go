@@ -0,0 +1,61 @@
+// Package sicoob contains the record types of the Sicoob cobrança layouts,
+// ready to be used with the cnab240 and cnab400 packages.
+//
+// The CNAB 240 headers are the FEBRABAN ones (see the febraban240 package),
+// but the nosso número of the segments P and T is composed by the number with
+// its check digit (see OurNumberDigit), the installment, the modalidade and
+// the form type. The CNAB 400 layout is derived from the Banco do Brasil
+// CBR643, with the cooperative (cooperativa) in place of the agency.
+//
+//	file := cnab400.File{
+//	  Header: sicoob.Header{...},
+//	  Details: []cnab400.Detail{
+//	    sicoob.Detail{...},
+//	  },
+//	  Trailer: sicoob.Trailer{},
+//	}
+//
+//	data, err := cnab400.Marshal(file)
+package sicoob
+
+import (
+	"fmt"
+
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+)
+
+// BankCode is the code of Sicoob (Bancoob) in the clearing system.
+const BankCode = 756
+
+// DocumentType identifies the type of the document (inscrição) of a company or
+// person. Sicoob uses the FEBRABAN codes in both layouts.
+type DocumentType = febraban240.DocumentType
+
+// List of document types.
+const (
+	DocumentTypeCPF  = febraban240.DocumentTypeCPF
+	DocumentTypeCNPJ = febraban240.DocumentTypeCNPJ
+)
+
+// ourNumberWeights are the weights applied to the digits of the cooperative,
+// client and nosso número, from left to right.
+const ourNumberWeights = "3197"
+
+// OurNumberDigit returns the check digit of the nosso número, using the module
+// 11 of the cooperative (4 digits), the client code (10 digits) and the nosso
+// número (7 digits) with the weights 3, 1, 9 and 7. The check digit is 0 when
+// the remainder is 0 or 1.
+func OurNumberDigit(cooperative int, client int64, ourNumber int) int {
+	digits := fmt.Sprintf("%04d%010d%07d", cooperative, client, ourNumber)
+
+	var sum int
+	for i := range digits {
+		sum += int(digits[i]-'0') * int(ourNumberWeights[i%len(ourNumberWeights)]-'0')
+	}
+
+	remainder := sum % 11
+	if remainder <= 1 {
+		return 0
+	}
+	return 11 - remainder
+}
@@ -0,0 +1,64 @@
+package sicoob_test
+
+import (
+	"testing"
+
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/cnab400"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/sicoob"
+)
+
+func TestLayouts(t *testing.T) {
+	t.Parallel()
+
+	layouttest.CheckCoverage(t, cnab240.LineSize,
+		sicoob.SegmentP{},
+		sicoob.SegmentT{},
+	)
+
+	layouttest.CheckCoverage(t, cnab400.LineSize,
+		sicoob.Header{},
+		sicoob.Detail{},
+		sicoob.Trailer{},
+		sicoob.ReturnHeader{},
+		sicoob.ReturnDetail{},
+		sicoob.ReturnTrailer{},
+	)
+}
+
+func TestOurNumberDigit(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		cooperative int
+		client      int64
+		ourNumber   int
+		expected    int
+	}{
+		{
+			description: "it should calculate the check digit",
+			cooperative: 1,
+			client:      9,
+			ourNumber:   1,
+			expected:    3,
+		},
+		{
+			description: "it should use zero when the remainder is 0 or 1",
+			cooperative: 3069,
+			client:      123456,
+			ourNumber:   1,
+			expected:    0,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			digit := sicoob.OurNumberDigit(scenario.cooperative, scenario.client, scenario.ourNumber)
+			if digit != scenario.expected {
+				t.Errorf("expected digit “%d” and got “%d”", scenario.expected, digit)
+			}
+		})
+	}
+}
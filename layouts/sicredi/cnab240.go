@@ -0,0 +1,132 @@
+package sicredi
+
+import (
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+)
+
+// SegmentP contains the main data of a título in a remittance (segmento P).
+// The nosso número has 9 digits, including the check digit.
+type SegmentP struct {
+	_                     string                      `cnab:"0,3,const=748"`
+	Batch                 int                         `cnab:"3,7"`
+	_                     string                      `cnab:"7,8,const=3"`
+	Sequence              int                         `cnab:"8,13"`
+	_                     string                      `cnab:"13,14,const=P"`
+	_                     string                      `cnab:"14,15"`
+	Movement              febraban240.InstructionCode `cnab:"15,17"`
+	Agency                int                         `cnab:"17,22"`
+	CooperativeDigit      string                      `cnab:"22,23"`
+	Account               int64                       `cnab:"23,35"`
+	AccountDigit          string                      `cnab:"35,36"`
+	_                     string                      `cnab:"36,37"`
+	OurNumber             int                         `cnab:"37,46"`
+	_                     string                      `cnab:"46,57"`
+	Wallet                int                         `cnab:"57,58"`
+	RegistrationType      int                         `cnab:"58,59"`
+	DocumentKind          int                         `cnab:"59,60"`
+	IssuanceType          int                         `cnab:"60,61"`
+	DistributionType      string                      `cnab:"61,62"`
+	DocumentNumber        string                      `cnab:"62,77"`
+	DueDate               cnab240.Date                `cnab:"77,85"`
+	Amount                float64                     `cnab:"85,100"`
+	CollectingAgency      int                         `cnab:"100,105"`
+	CollectingAgencyDigit string                      `cnab:"105,106"`
+	TitleKind             int                         `cnab:"106,108"`
+	Acceptance            string                      `cnab:"108,109"`
+	IssueDate             cnab240.Date                `cnab:"109,117"`
+	InterestCode          int                         `cnab:"117,118"`
+	InterestDate          cnab240.Date                `cnab:"118,126"`
+	Interest              float64                     `cnab:"126,141"`
+	DiscountCode          int                         `cnab:"141,142"`
+	DiscountDate          cnab240.Date                `cnab:"142,150"`
+	Discount              float64                     `cnab:"150,165"`
+	IOF                   float64                     `cnab:"165,180"`
+	Rebate                float64                     `cnab:"180,195"`
+	CompanyTitleID        string                      `cnab:"195,220"`
+	ProtestCode           int                         `cnab:"220,221"`
+	ProtestDays           int                         `cnab:"221,223"`
+	WriteOffCode          int                         `cnab:"223,224"`
+	WriteOffDays          int                         `cnab:"224,227"`
+	Currency              int                         `cnab:"227,229"`
+	Contract              int64                       `cnab:"229,239"`
+	_                     string                      `cnab:"239,240"`
+}
+
+// SegmentT contains the main data of a título in a return file (segmento T).
+// The nosso número has 9 digits, including the check digit.
+type SegmentT struct {
+	_                     string                     `cnab:"0,3,const=748"`
+	Batch                 int                        `cnab:"3,7"`
+	_                     string                     `cnab:"7,8,const=3"`
+	Sequence              int                        `cnab:"8,13"`
+	_                     string                     `cnab:"13,14,const=T"`
+	_                     string                     `cnab:"14,15"`
+	Occurrence            febraban240.OccurrenceCode `cnab:"15,17"`
+	Agency                int                        `cnab:"17,22"`
+	CooperativeDigit      string                     `cnab:"22,23"`
+	Account               int64                      `cnab:"23,35"`
+	AccountDigit          string                     `cnab:"35,36"`
+	_                     string                     `cnab:"36,37"`
+	OurNumber             int                        `cnab:"37,46"`
+	_                     string                     `cnab:"46,57"`
+	Wallet                int                        `cnab:"57,58"`
+	DocumentNumber        string                     `cnab:"58,73"`
+	DueDate               cnab240.Date               `cnab:"73,81"`
+	Amount                float64                    `cnab:"81,96"`
+	CollectingBank        int                        `cnab:"96,99"`
+	CollectingAgency      int                        `cnab:"99,104"`
+	CollectingAgencyDigit string                     `cnab:"104,105"`
+	CompanyTitleID        string                     `cnab:"105,130"`
+	Currency              int                        `cnab:"130,132"`
+	PayerDocumentType     DocumentType               `cnab:"132,133"`
+	PayerDocument         int64                      `cnab:"133,148"`
+	PayerName             string                     `cnab:"148,188"`
+	Contract              int64                      `cnab:"188,198"`
+	Fee                   float64                    `cnab:"198,213"`
+	OccurrenceReasons     string                     `cnab:"213,223"`
+	_                     string                     `cnab:"223,240"`
+}
+
+// Reasons returns the codes of the reasons of the occurrence (motivos da
+// ocorrência), that are stored in groups of 2 characters. Empty and zeroed
+// codes are ignored.
+func (s SegmentT) Reasons() []string {
+	return febraban240.SegmentT{OccurrenceReasons: s.OccurrenceReasons}.Reasons()
+}
+
+// Boleto is a título of a remittance, composed by the consecutive segments P,
+// Q and optionally R.
+type Boleto struct {
+	P SegmentP              `cnab:"line"`
+	Q febraban240.SegmentQ  `cnab:"line"`
+	R *febraban240.SegmentR `cnab:"line"`
+}
+
+// BoletoReturn is a título of a return file, composed by the consecutive
+// segments T and U.
+type BoletoReturn struct {
+	T SegmentT             `cnab:"line"`
+	U febraban240.SegmentU `cnab:"line"`
+}
+
+// NewCobrancaMapper returns a mapper with the CNAB 240 cobrança record types,
+// to be used with cnab240.Unmarshal. The títulos are decoded as Boleto
+// (remittance) and BoletoReturn (return file), and the headers, trailers and
+// the other segments are decoded with the febraban240 types.
+func NewCobrancaMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*febraban240.FileHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileHeader))
+	mapper.Register((*febraban240.CobrancaBatchHeader)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchHeader))
+	mapper.Register((*Boleto)(nil), cnab240.MatchSegment("P"))
+	mapper.Register((*febraban240.SegmentQ)(nil), cnab240.MatchSegment("Q"))
+	mapper.Register((*febraban240.SegmentR)(nil), cnab240.MatchSegment("R"))
+	mapper.Register((*febraban240.SegmentS)(nil), cnab240.MatchSegment("S"))
+	mapper.RegisterWithPriority((*febraban240.SegmentSMessages)(nil), febraban240.MatchSegmentSMessages, 1)
+	mapper.Register((*BoletoReturn)(nil), cnab240.MatchSegment("T"))
+	mapper.Register((*febraban240.SegmentU)(nil), cnab240.MatchSegment("U"))
+	mapper.Register((*febraban240.CobrancaBatchTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeBatchTrailer))
+	mapper.Register((*febraban240.FileTrailer)(nil), cnab240.MatchRecordType(cnab240.RecordTypeFileTrailer))
+	return mapper
+}
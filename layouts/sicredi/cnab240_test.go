@@ -0,0 +1,357 @@
+package sicredi_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+	"github.com/rafaeljusto/gocnab/layouts/sicredi"
+)
+
+func TestCobranca(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		golden      string
+		fileCode    febraban240.FileCode
+		operation   string
+		segments    []cnab240.Segment
+	}{
+		{
+			description: "it should encode and decode a remittance",
+			golden:      "cnab240_remessa.golden",
+			fileCode:    febraban240.FileCodeRemittance,
+			operation:   febraban240.OperationRemittance,
+			segments: []cnab240.Segment{
+				sicredi.Boleto{
+					P: sicredi.SegmentP{
+						Batch:            1,
+						Sequence:         1,
+						Movement:         febraban240.InstructionEntry,
+						Agency:           100,
+						Account:          123456,
+						AccountDigit:     "7",
+						OurNumber:        262000014,
+						Wallet:           1,
+						RegistrationType: 0,
+						DocumentKind:     2,
+						IssuanceType:     2,
+						DistributionType: "2",
+						DocumentNumber:   "NF-1001",
+						DueDate:          cnab240.NewDate(2026, 11, 10),
+						Amount:           1500.75,
+						TitleKind:        2,
+						Acceptance:       "N",
+						IssueDate:        cnab240.NewDate(2026, 10, 18),
+						InterestCode:     1,
+						InterestDate:     cnab240.NewDate(2026, 11, 11),
+						Interest:         0.5,
+						CompanyTitleID:   "PEDIDO 1001",
+						ProtestCode:      3,
+						Currency:         9,
+					},
+					Q: febraban240.SegmentQ{
+						Bank:              748,
+						Batch:             1,
+						Sequence:          2,
+						Movement:          febraban240.InstructionEntry,
+						PayerDocumentType: sicredi.DocumentTypeCPF,
+						PayerDocument:     12345678909,
+						PayerName:         "FULANO DE TAL",
+						PayerAddress:      "RUA DAS FLORES 100",
+						PayerDistrict:     "CENTRO",
+						PayerZipCode:      1001,
+						PayerCity:         "SAO PAULO",
+						PayerState:        "SP",
+					},
+				},
+			},
+		},
+		{
+			description: "it should encode and decode a return file",
+			golden:      "cnab240_retorno.golden",
+			fileCode:    febraban240.FileCodeReturn,
+			operation:   febraban240.OperationReturn,
+			segments: []cnab240.Segment{
+				sicredi.BoletoReturn{
+					T: sicredi.SegmentT{
+						Batch:             1,
+						Sequence:          1,
+						Occurrence:        febraban240.OccurrenceSettlement,
+						Agency:            100,
+						Account:           123456,
+						AccountDigit:      "7",
+						OurNumber:         262000014,
+						Wallet:            1,
+						DocumentNumber:    "NF-1001",
+						DueDate:           cnab240.NewDate(2026, 11, 10),
+						Amount:            1500.75,
+						CollectingBank:    748,
+						CollectingAgency:  100,
+						CompanyTitleID:    "PEDIDO 1001",
+						Currency:          9,
+						PayerDocumentType: sicredi.DocumentTypeCPF,
+						PayerDocument:     12345678909,
+						PayerName:         "FULANO DE TAL",
+						Fee:               1.8,
+					},
+					U: febraban240.SegmentU{
+						Bank:           748,
+						Batch:          1,
+						Sequence:       2,
+						Occurrence:     febraban240.OccurrenceSettlement,
+						PaidAmount:     1500.75,
+						NetAmount:      1498.95,
+						OccurrenceDate: cnab240.NewDate(2026, 11, 12),
+						CreditDate:     cnab240.NewDate(2026, 11, 13),
+					},
+				},
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			file := cnab240.File{
+				Header: febraban240.FileHeader{
+					Bank:                748,
+					CompanyDocumentType: sicredi.DocumentTypeCNPJ,
+					CompanyDocument:     12345678000195,
+					Agency:              100,
+					AgencyDigit:         "0",
+					Account:             123456,
+					AccountDigit:        "7",
+					AgencyAccountDigit:  "0",
+					CompanyName:         "EMPRESA EXEMPLO LTDA",
+					BankName:            "SICREDI",
+					FileCode:            scenario.fileCode,
+					GenerationDate:      cnab240.NewDate(2026, 10, 18),
+					GenerationTime:      cnab240.NewTime(10, 30, 15),
+					FileSequence:        42,
+					LayoutVersion:       "081",
+				},
+				Batches: []cnab240.Batch{
+					{
+						Header: febraban240.CobrancaBatchHeader{
+							Bank:                748,
+							Batch:               1,
+							Operation:           scenario.operation,
+							LayoutVersion:       "040",
+							CompanyDocumentType: sicredi.DocumentTypeCNPJ,
+							CompanyDocument:     12345678000195,
+							Agency:              100,
+							AgencyDigit:         "0",
+							Account:             123456,
+							AccountDigit:        "7",
+							AgencyAccountDigit:  "0",
+							CompanyName:         "EMPRESA EXEMPLO LTDA",
+							RemittanceNumber:    42,
+							RecordingDate:       cnab240.NewDate(2026, 10, 18),
+						},
+						Segments: scenario.segments,
+						Trailer: febraban240.CobrancaBatchTrailer{
+							Bank:    748,
+							Batch:   1,
+							Records: 4,
+						},
+					},
+				},
+				Trailer: febraban240.FileTrailer{
+					Bank:    748,
+					Batches: 1,
+					Records: 6,
+				},
+			}
+
+			data, err := cnab240.Marshal(layouttest.WithoutFields(file, layouttest.CNAB240ControlFields...))
+			if err != nil {
+				t.Fatalf("error marshalling. details: %s", err)
+			}
+
+			golden := layouttest.CheckGolden(t, scenario.golden, data)
+
+			decoded, err := cnab240.Unmarshal(golden, sicredi.NewCobrancaMapper())
+			if err != nil {
+				t.Fatalf("error unmarshalling. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(file, decoded) {
+				t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+			}
+		})
+	}
+}
+
+func TestCNAB240_spec(t *testing.T) {
+	t.Parallel()
+
+	// lines assembled from the tables of the Sicredi CNAB 240 cobrança
+	// specification, independently of the encoder
+	scenarios := []struct {
+		description string
+		line        func(t *testing.T) []byte
+		expected    interface{}
+	}{
+		{
+			description: "it should decode the segment P",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab240.LineSize,
+					layouttest.Number(1, 3, 748),             // código do banco na compensação
+					layouttest.Number(4, 7, 1),               // lote de serviço
+					layouttest.Text(8, 8, "3"),               // tipo de registro
+					layouttest.Number(9, 13, 1),              // nº sequencial do registro no lote
+					layouttest.Text(14, 14, "P"),             // código do segmento do registro detalhe
+					layouttest.Text(15, 15, ""),              // uso exclusivo FEBRABAN/CNAB
+					layouttest.Number(16, 17, 1),             // código de movimento remessa
+					layouttest.Number(18, 22, 710),           // agência mantenedora da conta
+					layouttest.Text(23, 23, ""),              // dígito verificador da agência
+					layouttest.Number(24, 35, 12345),         // número da conta corrente
+					layouttest.Text(36, 36, "6"),             // dígito verificador da conta
+					layouttest.Text(37, 37, ""),              // dígito verificador da agência/conta
+					layouttest.Number(38, 46, 262000015),     // nosso número com o dígito verificador
+					layouttest.Text(47, 57, ""),              // complemento da identificação do título
+					layouttest.Number(58, 58, 1),             // código da carteira
+					layouttest.Number(59, 59, 1),             // forma de cadastramento do título no banco
+					layouttest.Number(60, 60, 1),             // tipo de documento
+					layouttest.Number(61, 61, 2),             // identificação da emissão do boleto
+					layouttest.Text(62, 62, "2"),             // identificação da distribuição
+					layouttest.Text(63, 77, "NF-1001"),       // número do documento de cobrança
+					layouttest.Text(78, 85, "10112026"),      // data de vencimento do título
+					layouttest.Number(86, 100, 150075),       // valor nominal do título
+					layouttest.Number(101, 105, 0),           // agência encarregada da cobrança
+					layouttest.Text(106, 106, ""),            // dígito verificador da agência
+					layouttest.Number(107, 108, 3),           // espécie do título
+					layouttest.Text(109, 109, "N"),           // identificação de título aceito/não aceito
+					layouttest.Text(110, 117, "20102026"),    // data da emissão do título
+					layouttest.Number(118, 118, 1),           // código do juros de mora
+					layouttest.Text(119, 126, "11112026"),    // data do juros de mora
+					layouttest.Number(127, 141, 50),          // juros de mora por dia/taxa
+					layouttest.Number(142, 142, 1),           // código do desconto 1
+					layouttest.Text(143, 150, "05112026"),    // data do desconto 1
+					layouttest.Number(151, 165, 1000),        // valor/percentual a ser concedido
+					layouttest.Number(166, 180, 0),           // valor do IOF a ser recolhido
+					layouttest.Number(181, 195, 2000),        // valor do abatimento
+					layouttest.Text(196, 220, "PEDIDO 1001"), // identificação do título na empresa
+					layouttest.Number(221, 221, 1),           // código para protesto
+					layouttest.Number(222, 223, 5),           // número de dias para protesto
+					layouttest.Number(224, 224, 1),           // código para baixa/devolução
+					layouttest.Number(225, 227, 60),          // número de dias para baixa/devolução
+					layouttest.Number(228, 229, 9),           // código da moeda
+					layouttest.Number(230, 239, 0),           // nº do contrato da operação de crédito
+					layouttest.Text(240, 240, ""),            // uso exclusivo FEBRABAN/CNAB
+				)
+			},
+			expected: sicredi.SegmentP{
+				Batch:            1,
+				Sequence:         1,
+				Movement:         febraban240.InstructionEntry,
+				Agency:           710,
+				Account:          12345,
+				AccountDigit:     "6",
+				OurNumber:        262000015,
+				Wallet:           1,
+				RegistrationType: 1,
+				DocumentKind:     1,
+				IssuanceType:     2,
+				DistributionType: "2",
+				DocumentNumber:   "NF-1001",
+				DueDate:          cnab240.NewDate(2026, 11, 10),
+				Amount:           1500.75,
+				TitleKind:        3,
+				Acceptance:       "N",
+				IssueDate:        cnab240.NewDate(2026, 10, 20),
+				InterestCode:     1,
+				InterestDate:     cnab240.NewDate(2026, 11, 11),
+				Interest:         0.5,
+				DiscountCode:     1,
+				DiscountDate:     cnab240.NewDate(2026, 11, 5),
+				Discount:         10,
+				Rebate:           20,
+				CompanyTitleID:   "PEDIDO 1001",
+				ProtestCode:      1,
+				ProtestDays:      5,
+				WriteOffCode:     1,
+				WriteOffDays:     60,
+				Currency:         9,
+			},
+		},
+		{
+			description: "it should decode the segment T",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab240.LineSize,
+					layouttest.Number(1, 3, 748),                       // código do banco na compensação
+					layouttest.Number(4, 7, 1),                         // lote de serviço
+					layouttest.Text(8, 8, "3"),                         // tipo de registro
+					layouttest.Number(9, 13, 1),                        // nº sequencial do registro no lote
+					layouttest.Text(14, 14, "T"),                       // código do segmento do registro detalhe
+					layouttest.Text(15, 15, ""),                        // uso exclusivo FEBRABAN/CNAB
+					layouttest.Number(16, 17, 6),                       // código de movimento retorno
+					layouttest.Number(18, 22, 710),                     // agência mantenedora da conta
+					layouttest.Text(23, 23, ""),                        // dígito verificador da agência
+					layouttest.Number(24, 35, 12345),                   // número da conta corrente
+					layouttest.Text(36, 36, "6"),                       // dígito verificador da conta
+					layouttest.Text(37, 37, ""),                        // dígito verificador da agência/conta
+					layouttest.Number(38, 46, 262000015),               // nosso número com o dígito verificador
+					layouttest.Text(47, 57, ""),                        // complemento da identificação do título
+					layouttest.Number(58, 58, 1),                       // código da carteira
+					layouttest.Text(59, 73, "NF-1001"),                 // número do documento de cobrança
+					layouttest.Text(74, 81, "10112026"),                // data do vencimento do título
+					layouttest.Number(82, 96, 150075),                  // valor nominal do título
+					layouttest.Number(97, 99, 748),                     // número do banco cobrador/recebedor
+					layouttest.Number(100, 104, 710),                   // agência cobradora/recebedora
+					layouttest.Text(105, 105, ""),                      // dígito verificador da agência
+					layouttest.Text(106, 130, "PEDIDO 1001"),           // identificação do título na empresa
+					layouttest.Number(131, 132, 9),                     // código da moeda
+					layouttest.Number(133, 133, 2),                     // tipo de inscrição do pagador
+					layouttest.Number(134, 148, 98765432000198),        // número de inscrição do pagador
+					layouttest.Text(149, 188, "EMPRESA PAGADORA LTDA"), // nome do pagador
+					layouttest.Number(189, 198, 0),                     // nº do contrato da operação de crédito
+					layouttest.Number(199, 213, 250),                   // valor da tarifa/custas
+					layouttest.Text(214, 223, "A1B2"),                  // motivo da ocorrência
+					layouttest.Text(224, 240, ""),                      // uso exclusivo FEBRABAN/CNAB
+				)
+			},
+			expected: sicredi.SegmentT{
+				Batch:             1,
+				Sequence:          1,
+				Occurrence:        febraban240.OccurrenceSettlement,
+				Agency:            710,
+				Account:           12345,
+				AccountDigit:      "6",
+				OurNumber:         262000015,
+				Wallet:            1,
+				DocumentNumber:    "NF-1001",
+				DueDate:           cnab240.NewDate(2026, 11, 10),
+				Amount:            1500.75,
+				CollectingBank:    748,
+				CollectingAgency:  710,
+				CompanyTitleID:    "PEDIDO 1001",
+				Currency:          9,
+				PayerDocumentType: sicredi.DocumentTypeCNPJ,
+				PayerDocument:     98765432000198,
+				PayerName:         "EMPRESA PAGADORA LTDA",
+				Fee:               2.5,
+				OccurrenceReasons: "A1B2",
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			decoded := reflect.New(reflect.TypeOf(scenario.expected))
+			if err := gocnab.Unmarshal(scenario.line(t), decoded.Interface()); err != nil {
+				t.Fatalf("error unmarshalling. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(scenario.expected, decoded.Elem().Interface()) {
+				t.Errorf("expected record “%#v” and got “%#v”", scenario.expected, decoded.Elem().Interface())
+			}
+		})
+	}
+}
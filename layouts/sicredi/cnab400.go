@@ -0,0 +1,194 @@
+package sicredi
+
+import (
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab400"
+	"github.com/rafaeljusto/gocnab/cnabdate"
+)
+
+// RecordTypeDetail is the record type of the CNAB 400 details.
+const RecordTypeDetail = "1"
+
+// Header is the header of a CNAB 400 remittance file (registro 0).
+type Header struct {
+	_                string        `cnab:"0,1,const=0"`
+	_                string        `cnab:"1,2,const=1"`
+	_                string        `cnab:"2,9,const=REMESSA"`
+	_                string        `cnab:"9,11,const=01"`
+	_                string        `cnab:"11,26,const=COBRANCA"`
+	BeneficiaryCode  int           `cnab:"26,31"`
+	CompanyDocument  int64         `cnab:"31,45"`
+	_                string        `cnab:"45,76"`
+	_                string        `cnab:"76,79,const=748"`
+	_                string        `cnab:"79,94,const=SICREDI"`
+	RecordingDate    cnabdate.Date `cnab:"94,102"`
+	_                string        `cnab:"102,110"`
+	RemittanceNumber int           `cnab:"110,117"`
+	_                string        `cnab:"117,390"`
+	Version          string        `cnab:"390,394"`
+	Sequence         int           `cnab:"394,400"`
+}
+
+// Detail contains the data of a título in a CNAB 400 remittance file
+// (registro 1). The types of cobrança, carteira, printing, currency, discount
+// and interest are letters defined by Sicredi (usually "A"). The fine
+// percentage has 2 decimal places and the payer person type is "1" for
+// individuals and "2" for companies.
+type Detail struct {
+	_                  string          `cnab:"0,1,const=1"`
+	CobrancaType       string          `cnab:"1,2"`
+	WalletType         string          `cnab:"2,3"`
+	PrintType          string          `cnab:"3,4"`
+	_                  string          `cnab:"4,16"`
+	CurrencyType       string          `cnab:"16,17"`
+	DiscountType       string          `cnab:"17,18"`
+	InterestType       string          `cnab:"18,19"`
+	_                  string          `cnab:"19,47"`
+	OurNumber          int             `cnab:"47,56"`
+	_                  string          `cnab:"56,62"`
+	InstructionDate    cnabdate.Date   `cnab:"62,70"`
+	ChangedField       string          `cnab:"70,71"`
+	Mailing            string          `cnab:"71,72"`
+	_                  string          `cnab:"72,73"`
+	IssuanceType       string          `cnab:"73,74"`
+	Installment        int             `cnab:"74,76"`
+	Installments       int             `cnab:"76,78"`
+	_                  string          `cnab:"78,82"`
+	DailyDiscount      float64         `cnab:"82,92"`
+	FinePercentage     float64         `cnab:"92,96"`
+	_                  string          `cnab:"96,108"`
+	Occurrence         InstructionCode `cnab:"108,110"`
+	DocumentNumber     string          `cnab:"110,120"`
+	DueDate            cnab400.Date    `cnab:"120,126"`
+	Amount             float64         `cnab:"126,139"`
+	_                  string          `cnab:"139,148"`
+	TitleKind          string          `cnab:"148,149"`
+	Acceptance         string          `cnab:"149,150"`
+	IssueDate          cnab400.Date    `cnab:"150,156"`
+	ProtestInstruction string          `cnab:"156,158"`
+	ProtestDays        int             `cnab:"158,160"`
+	DailyInterest      float64         `cnab:"160,173"`
+	DiscountDate       cnab400.Date    `cnab:"173,179"`
+	Discount           float64         `cnab:"179,192"`
+	_                  string          `cnab:"192,205,const=0000000000000"`
+	Rebate             float64         `cnab:"205,218"`
+	PayerPersonType    string          `cnab:"218,219"`
+	_                  string          `cnab:"219,220,const=0"`
+	PayerDocument      int64           `cnab:"220,234"`
+	PayerName          string          `cnab:"234,274"`
+	PayerAddress       string          `cnab:"274,314"`
+	PayerCode          int             `cnab:"314,319"`
+	_                  string          `cnab:"319,325,const=000000"`
+	_                  string          `cnab:"325,326"`
+	PayerZipCode       int             `cnab:"326,334"`
+	PayerClientCode    int             `cnab:"334,339"`
+	GuarantorDocument  int64           `cnab:"339,353"`
+	GuarantorName      string          `cnab:"353,394"`
+	Sequence           int             `cnab:"394,400"`
+}
+
+// Trailer is the trailer of a CNAB 400 remittance file (registro 9).
+type Trailer struct {
+	_               string `cnab:"0,1,const=9"`
+	_               string `cnab:"1,2,const=1"`
+	_               string `cnab:"2,5,const=748"`
+	BeneficiaryCode int    `cnab:"5,10"`
+	_               string `cnab:"10,394"`
+	Sequence        int    `cnab:"394,400"`
+}
+
+// ReturnHeader is the header of a CNAB 400 return file (registro 0).
+type ReturnHeader struct {
+	_               string        `cnab:"0,1,const=0"`
+	_               string        `cnab:"1,2,const=2"`
+	_               string        `cnab:"2,9,const=RETORNO"`
+	_               string        `cnab:"9,11,const=01"`
+	_               string        `cnab:"11,26,const=COBRANCA"`
+	BeneficiaryCode int           `cnab:"26,31"`
+	CompanyDocument int64         `cnab:"31,45"`
+	_               string        `cnab:"45,76"`
+	_               string        `cnab:"76,79,const=748"`
+	_               string        `cnab:"79,94,const=SICREDI"`
+	RecordingDate   cnabdate.Date `cnab:"94,102"`
+	_               string        `cnab:"102,110"`
+	ReturnNumber    int           `cnab:"110,117"`
+	_               string        `cnab:"117,390"`
+	Version         string        `cnab:"390,394"`
+	Sequence        int           `cnab:"394,400"`
+}
+
+// ReturnDetail contains the occurrence of a título in a CNAB 400 return file
+// (registro 1).
+type ReturnDetail struct {
+	_                 string         `cnab:"0,1,const=1"`
+	_                 string         `cnab:"1,47"`
+	OurNumber         int            `cnab:"47,56"`
+	_                 string         `cnab:"56,108"`
+	Occurrence        OccurrenceCode `cnab:"108,110"`
+	OccurrenceDate    cnab400.Date   `cnab:"110,116"`
+	DocumentNumber    string         `cnab:"116,126"`
+	_                 string         `cnab:"126,146"`
+	DueDate           cnab400.Date   `cnab:"146,152"`
+	Amount            float64        `cnab:"152,165"`
+	_                 string         `cnab:"165,174"`
+	TitleKind         string         `cnab:"174,175"`
+	Fee               float64        `cnab:"175,188"`
+	OtherExpenses     float64        `cnab:"188,201"`
+	_                 string         `cnab:"201,227"`
+	Rebate            float64        `cnab:"227,240"`
+	Discount          float64        `cnab:"240,253"`
+	PaidAmount        float64        `cnab:"253,266"`
+	Interest          float64        `cnab:"266,279"`
+	Fine              float64        `cnab:"279,292"`
+	_                 string         `cnab:"292,294"`
+	ConditionalReason string         `cnab:"294,295"`
+	_                 string         `cnab:"295,318"`
+	OccurrenceReasons string         `cnab:"318,328"`
+	CreditDate        cnabdate.Date  `cnab:"328,336"`
+	_                 string         `cnab:"336,394"`
+	Sequence          int            `cnab:"394,400"`
+}
+
+// Reasons returns the codes of the reasons of the occurrence (motivos da
+// ocorrência), that are stored in groups of 2 characters. Empty and zeroed
+// codes are ignored.
+func (r ReturnDetail) Reasons() []string {
+	var reasons []string
+	for i := 0; i+2 <= len(r.OccurrenceReasons); i += 2 {
+		reason := r.OccurrenceReasons[i : i+2]
+		if reason != "00" && reason != "  " {
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons
+}
+
+// ReturnTrailer is the trailer of a CNAB 400 return file (registro 9).
+type ReturnTrailer struct {
+	_               string `cnab:"0,1,const=9"`
+	_               string `cnab:"1,2,const=2"`
+	_               string `cnab:"2,5,const=748"`
+	BeneficiaryCode int    `cnab:"5,10"`
+	_               string `cnab:"10,394"`
+	Sequence        int    `cnab:"394,400"`
+}
+
+// NewRemittanceMapper returns a mapper with the CNAB 400 remittance record
+// types, to be used with cnab400.Unmarshal.
+func NewRemittanceMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*Header)(nil), cnab400.MatchRecordType(cnab400.RecordTypeHeader))
+	mapper.Register((*Detail)(nil), cnab400.MatchRecordType(RecordTypeDetail))
+	mapper.Register((*Trailer)(nil), cnab400.MatchRecordType(cnab400.RecordTypeTrailer))
+	return mapper
+}
+
+// NewReturnMapper returns a mapper with the CNAB 400 return record types, to be
+// used with cnab400.Unmarshal.
+func NewReturnMapper() *gocnab.Mapper {
+	mapper := gocnab.NewMapper()
+	mapper.Register((*ReturnHeader)(nil), cnab400.MatchRecordType(cnab400.RecordTypeHeader))
+	mapper.Register((*ReturnDetail)(nil), cnab400.MatchRecordType(RecordTypeDetail))
+	mapper.Register((*ReturnTrailer)(nil), cnab400.MatchRecordType(cnab400.RecordTypeTrailer))
+	return mapper
+}
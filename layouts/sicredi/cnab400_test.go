@@ -0,0 +1,327 @@
+package sicredi_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+	"github.com/rafaeljusto/gocnab/cnab400"
+	"github.com/rafaeljusto/gocnab/cnabdate"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/sicredi"
+)
+
+func TestRemittance(t *testing.T) {
+	t.Parallel()
+
+	file := cnab400.File{
+		Header: sicredi.Header{
+			BeneficiaryCode:  12345,
+			CompanyDocument:  12345678000195,
+			RecordingDate:    cnabdate.NewDate(2026, 10, 18),
+			RemittanceNumber: 42,
+			Version:          "2.00",
+			Sequence:         1,
+		},
+		Details: []cnab400.Detail{
+			sicredi.Detail{
+				CobrancaType:       "A",
+				WalletType:         "A",
+				PrintType:          "A",
+				CurrencyType:       "A",
+				DiscountType:       "A",
+				InterestType:       "A",
+				OurNumber:          262000014,
+				Mailing:            "N",
+				IssuanceType:       "B",
+				FinePercentage:     2,
+				Occurrence:         sicredi.InstructionEntry,
+				DocumentNumber:     "NF-1001",
+				DueDate:            cnab400.NewDate(2026, 11, 10),
+				Amount:             1500.75,
+				TitleKind:          "A",
+				Acceptance:         "N",
+				IssueDate:          cnab400.NewDate(2026, 10, 18),
+				ProtestInstruction: "00",
+				DailyInterest:      0.5,
+				PayerPersonType:    "1",
+				PayerDocument:      12345678909,
+				PayerName:          "FULANO DE TAL",
+				PayerAddress:       "RUA DAS FLORES 100",
+				PayerZipCode:       1001000,
+				Sequence:           2,
+			},
+		},
+		Trailer: sicredi.Trailer{
+			BeneficiaryCode: 12345,
+			Sequence:        3,
+		},
+	}
+
+	data, err := cnab400.Marshal(layouttest.WithoutFields(file, layouttest.CNAB400ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "cnab400_remessa.golden", data)
+
+	decoded, err := cnab400.Unmarshal(golden, sicredi.NewRemittanceMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+}
+
+func TestReturn(t *testing.T) {
+	t.Parallel()
+
+	file := cnab400.File{
+		Header: sicredi.ReturnHeader{
+			BeneficiaryCode: 12345,
+			CompanyDocument: 12345678000195,
+			RecordingDate:   cnabdate.NewDate(2026, 11, 12),
+			ReturnNumber:    15,
+			Version:         "2.00",
+			Sequence:        1,
+		},
+		Details: []cnab400.Detail{
+			sicredi.ReturnDetail{
+				OurNumber:      262000014,
+				Occurrence:     sicredi.OccurrenceSettlement,
+				OccurrenceDate: cnab400.NewDate(2026, 11, 12),
+				DocumentNumber: "NF-1001",
+				DueDate:        cnab400.NewDate(2026, 11, 10),
+				Amount:         1500.75,
+				TitleKind:      "A",
+				Fee:            1.5,
+				PaidAmount:     1500.75,
+				CreditDate:     cnabdate.NewDate(2026, 11, 13),
+				Sequence:       2,
+			},
+			sicredi.ReturnDetail{
+				OurNumber:         262000022,
+				Occurrence:        sicredi.OccurrenceEntryRejected,
+				OccurrenceDate:    cnab400.NewDate(2026, 10, 19),
+				DocumentNumber:    "NF-1002",
+				DueDate:           cnab400.NewDate(2026, 11, 20),
+				Amount:            99.9,
+				TitleKind:         "A",
+				OccurrenceReasons: "0148",
+				Sequence:          3,
+			},
+		},
+		Trailer: sicredi.ReturnTrailer{
+			BeneficiaryCode: 12345,
+			Sequence:        4,
+		},
+	}
+
+	data, err := cnab400.Marshal(layouttest.WithoutFields(file, layouttest.CNAB400ControlFields...))
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	golden := layouttest.CheckGolden(t, "cnab400_retorno.golden", data)
+
+	decoded, err := cnab400.Unmarshal(golden, sicredi.NewReturnMapper())
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(file, decoded) {
+		t.Errorf("expected file “%#v” and got “%#v”", file, decoded)
+	}
+
+	rejected := decoded.Details[1].(sicredi.ReturnDetail)
+	if reasons := rejected.Reasons(); !reflect.DeepEqual([]string{"01", "48"}, reasons) {
+		t.Errorf("unexpected occurrence reasons “%v”", reasons)
+	}
+
+	if description := rejected.Occurrence.Description(); description != "Entrada rejeitada" {
+		t.Errorf("unexpected occurrence description “%s”", description)
+	}
+}
+
+func TestCNAB400_spec(t *testing.T) {
+	t.Parallel()
+
+	// lines assembled from the tables of the Sicredi CNAB 400 cobrança
+	// specification, independently of the encoder
+	scenarios := []struct {
+		description string
+		line        func(t *testing.T) []byte
+		expected    interface{}
+	}{
+		{
+			description: "it should decode the remittance detail",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab400.LineSize,
+					layouttest.Text(1, 1, "1"),                      // identificação do registro detalhe
+					layouttest.Text(2, 2, "A"),                      // tipo de cobrança
+					layouttest.Text(3, 3, "A"),                      // tipo de carteira
+					layouttest.Text(4, 4, "A"),                      // tipo de impressão
+					layouttest.Text(5, 16, ""),                      // filler
+					layouttest.Text(17, 17, "A"),                    // tipo de moeda
+					layouttest.Text(18, 18, "A"),                    // tipo de desconto
+					layouttest.Text(19, 19, "B"),                    // tipo de juros
+					layouttest.Text(20, 47, ""),                     // filler
+					layouttest.Number(48, 56, 262000015),            // nosso número Sicredi
+					layouttest.Text(57, 62, ""),                     // filler
+					layouttest.Text(63, 70, "20261018"),             // data da instrução
+					layouttest.Text(71, 71, ""),                     // campo alterado
+					layouttest.Text(72, 72, "N"),                    // postagem do título
+					layouttest.Text(73, 73, ""),                     // filler
+					layouttest.Text(74, 74, "B"),                    // emissão do boleto
+					layouttest.Number(75, 76, 1),                    // número da parcela do carnê
+					layouttest.Number(77, 78, 3),                    // número total de parcelas do carnê
+					layouttest.Text(79, 82, ""),                     // filler
+					layouttest.Number(83, 92, 150),                  // valor de desconto por dia de antecipação
+					layouttest.Number(93, 96, 200),                  // percentual de multa por pagamento em atraso
+					layouttest.Text(97, 108, ""),                    // filler
+					layouttest.Text(109, 110, "01"),                 // instrução
+					layouttest.Text(111, 120, "NF-1001"),            // seu número
+					layouttest.Text(121, 126, "101126"),             // data de vencimento
+					layouttest.Number(127, 139, 150075),             // valor do título
+					layouttest.Text(140, 148, ""),                   // filler
+					layouttest.Text(149, 149, "A"),                  // espécie de documento
+					layouttest.Text(150, 150, "N"),                  // aceite do título
+					layouttest.Text(151, 156, "181026"),             // data de emissão
+					layouttest.Text(157, 158, "06"),                 // instrução de protesto automático
+					layouttest.Number(159, 160, 5),                  // número de dias para protesto automático
+					layouttest.Number(161, 173, 50),                 // valor/percentual de juros por dia de atraso
+					layouttest.Text(174, 179, "051126"),             // data limite para concessão de desconto
+					layouttest.Number(180, 192, 1000),               // valor/percentual do desconto
+					layouttest.Number(193, 205, 0),                  // filler
+					layouttest.Number(206, 218, 2000),               // valor do abatimento
+					layouttest.Text(219, 219, "1"),                  // tipo de pessoa do pagador
+					layouttest.Text(220, 220, "0"),                  // filler
+					layouttest.Number(221, 234, 12345678909),        // CPF/CNPJ do pagador
+					layouttest.Text(235, 274, "FULANO DE TAL"),      // nome do pagador
+					layouttest.Text(275, 314, "RUA DAS FLORES 100"), // endereço do pagador
+					layouttest.Number(315, 319, 123),                // código do pagador na cooperativa
+					layouttest.Number(320, 325, 0),                  // filler
+					layouttest.Text(326, 326, ""),                   // filler
+					layouttest.Number(327, 334, 90010000),           // CEP do pagador
+					layouttest.Number(335, 339, 456),                // código do pagador junto ao cliente
+					layouttest.Number(340, 353, 98765432000198),     // CPF/CNPJ do sacador avalista
+					layouttest.Text(354, 394, "AVALISTA EXEMPLO"),   // nome do sacador avalista
+					layouttest.Number(395, 400, 2),                  // número sequencial do registro
+				)
+			},
+			expected: sicredi.Detail{
+				CobrancaType:       "A",
+				WalletType:         "A",
+				PrintType:          "A",
+				CurrencyType:       "A",
+				DiscountType:       "A",
+				InterestType:       "B",
+				OurNumber:          262000015,
+				InstructionDate:    cnabdate.NewDate(2026, 10, 18),
+				Mailing:            "N",
+				IssuanceType:       "B",
+				Installment:        1,
+				Installments:       3,
+				DailyDiscount:      1.5,
+				FinePercentage:     2,
+				Occurrence:         sicredi.InstructionEntry,
+				DocumentNumber:     "NF-1001",
+				DueDate:            cnab400.NewDate(2026, 11, 10),
+				Amount:             1500.75,
+				TitleKind:          "A",
+				Acceptance:         "N",
+				IssueDate:          cnab400.NewDate(2026, 10, 18),
+				ProtestInstruction: "06",
+				ProtestDays:        5,
+				DailyInterest:      0.5,
+				DiscountDate:       cnab400.NewDate(2026, 11, 5),
+				Discount:           10,
+				Rebate:             20,
+				PayerPersonType:    "1",
+				PayerDocument:      12345678909,
+				PayerName:          "FULANO DE TAL",
+				PayerAddress:       "RUA DAS FLORES 100",
+				PayerCode:          123,
+				PayerZipCode:       90010000,
+				PayerClientCode:    456,
+				GuarantorDocument:  98765432000198,
+				GuarantorName:      "AVALISTA EXEMPLO",
+				Sequence:           2,
+			},
+		},
+		{
+			description: "it should decode the return detail",
+			line: func(t *testing.T) []byte {
+				return layouttest.SpecLine(t, cnab400.LineSize,
+					layouttest.Text(1, 1, "1"),              // identificação do registro detalhe
+					layouttest.Text(2, 13, ""),              // filler
+					layouttest.Number(14, 18, 123),          // código do pagador na cooperativa
+					layouttest.Number(19, 23, 456),          // código do pagador junto ao associado
+					layouttest.Text(24, 47, ""),             // filler
+					layouttest.Number(48, 56, 262000015),    // nosso número Sicredi
+					layouttest.Text(57, 108, ""),            // filler
+					layouttest.Text(109, 110, "06"),         // ocorrência
+					layouttest.Text(111, 116, "121126"),     // data da ocorrência
+					layouttest.Text(117, 126, "NF-1001"),    // seu número
+					layouttest.Text(127, 146, ""),           // filler
+					layouttest.Text(147, 152, "101126"),     // data de vencimento
+					layouttest.Number(153, 165, 150075),     // valor do título
+					layouttest.Text(166, 174, ""),           // filler
+					layouttest.Text(175, 175, "A"),          // espécie de documento
+					layouttest.Number(176, 188, 250),        // despesas de cobrança
+					layouttest.Number(189, 201, 100),        // despesas de custas de protesto
+					layouttest.Text(202, 227, ""),           // filler
+					layouttest.Number(228, 240, 200),        // abatimento concedido
+					layouttest.Number(241, 253, 300),        // desconto concedido
+					layouttest.Number(254, 266, 150375),     // valor efetivamente pago
+					layouttest.Number(267, 279, 600),        // juros de mora
+					layouttest.Number(280, 292, 700),        // multa
+					layouttest.Text(293, 294, ""),           // filler
+					layouttest.Text(295, 295, "A"),          // somente para ocorrência "19"
+					layouttest.Text(296, 318, ""),           // filler
+					layouttest.Text(319, 328, "0000000000"), // motivos da ocorrência
+					layouttest.Text(329, 336, "20261113"),   // data prevista para lançamento na conta corrente
+					layouttest.Text(337, 394, ""),           // filler
+					layouttest.Number(395, 400, 2),          // número sequencial do registro
+				)
+			},
+			expected: sicredi.ReturnDetail{
+				OurNumber:         262000015,
+				Occurrence:        sicredi.OccurrenceSettlement,
+				OccurrenceDate:    cnab400.NewDate(2026, 11, 12),
+				DocumentNumber:    "NF-1001",
+				DueDate:           cnab400.NewDate(2026, 11, 10),
+				Amount:            1500.75,
+				TitleKind:         "A",
+				Fee:               2.5,
+				OtherExpenses:     1,
+				Rebate:            2,
+				Discount:          3,
+				PaidAmount:        1503.75,
+				Interest:          6,
+				Fine:              7,
+				ConditionalReason: "A",
+				OccurrenceReasons: "0000000000",
+				CreditDate:        cnabdate.NewDate(2026, 11, 13),
+				Sequence:          2,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			t.Parallel()
+
+			decoded := reflect.New(reflect.TypeOf(scenario.expected))
+			if err := gocnab.Unmarshal(scenario.line(t), decoded.Interface()); err != nil {
+				t.Fatalf("error unmarshalling. details: %s", err)
+			}
+
+			if !reflect.DeepEqual(scenario.expected, decoded.Elem().Interface()) {
+				t.Errorf("expected record “%#v” and got “%#v”", scenario.expected, decoded.Elem().Interface())
+			}
+		})
+	}
+}
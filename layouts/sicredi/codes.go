@@ -0,0 +1,93 @@
+package sicredi
+
+// InstructionCode is the occurrence code of a título in a CNAB 400 remittance,
+// defining the instruction sent to the bank.
+type InstructionCode string
+
+// List of instruction codes accepted by Sicredi.
+const (
+	InstructionEntry                  InstructionCode = "01"
+	InstructionWriteOff               InstructionCode = "02"
+	InstructionGrantRebate            InstructionCode = "04"
+	InstructionCancelRebate           InstructionCode = "05"
+	InstructionChangeDueDate          InstructionCode = "06"
+	InstructionProtest                InstructionCode = "09"
+	InstructionStopProtestAndWriteOff InstructionCode = "18"
+	InstructionStopProtestAndKeep     InstructionCode = "19"
+	InstructionChangeOtherData        InstructionCode = "31"
+)
+
+var instructionDescriptions = map[InstructionCode]string{
+	InstructionEntry:                  "Cadastro de título",
+	InstructionWriteOff:               "Pedido de baixa",
+	InstructionGrantRebate:            "Concessão de abatimento",
+	InstructionCancelRebate:           "Cancelamento de abatimento concedido",
+	InstructionChangeDueDate:          "Alteração de vencimento",
+	InstructionProtest:                "Pedido de protesto",
+	InstructionStopProtestAndWriteOff: "Sustar protesto e baixar título",
+	InstructionStopProtestAndKeep:     "Sustar protesto e manter em carteira",
+	InstructionChangeOtherData:        "Alteração de outros dados",
+}
+
+// Description returns the description of the instruction code, or an empty
+// string for unknown codes.
+func (i InstructionCode) Description() string {
+	return instructionDescriptions[i]
+}
+
+// OccurrenceCode is the occurrence code of a título in a CNAB 400 return file,
+// informing what happened with it.
+type OccurrenceCode string
+
+// List of occurrence codes informed by Sicredi.
+const (
+	OccurrenceEntryConfirmed          OccurrenceCode = "02"
+	OccurrenceEntryRejected           OccurrenceCode = "03"
+	OccurrenceSettlement              OccurrenceCode = "06"
+	OccurrenceAutomaticWriteOff       OccurrenceCode = "09"
+	OccurrenceWriteOffRequested       OccurrenceCode = "10"
+	OccurrenceRebateGranted           OccurrenceCode = "12"
+	OccurrenceRebateCancelled         OccurrenceCode = "13"
+	OccurrenceDueDateChanged          OccurrenceCode = "14"
+	OccurrenceNotarySettlement        OccurrenceCode = "15"
+	OccurrenceSettlementAfterWriteOff OccurrenceCode = "17"
+	OccurrenceProtestConfirmed        OccurrenceCode = "19"
+	OccurrenceStopProtestConfirmed    OccurrenceCode = "20"
+	OccurrenceSentToNotary            OccurrenceCode = "23"
+	OccurrenceRejectedZipCode         OccurrenceCode = "24"
+	OccurrenceWriteOffRejected        OccurrenceCode = "27"
+	OccurrenceFee                     OccurrenceCode = "28"
+	OccurrenceDataChangeRejected      OccurrenceCode = "30"
+	OccurrenceInstructionRejected     OccurrenceCode = "32"
+	OccurrenceDataChangeConfirmed     OccurrenceCode = "33"
+	OccurrenceRemovedFromNotary       OccurrenceCode = "34"
+)
+
+var occurrenceDescriptions = map[OccurrenceCode]string{
+	OccurrenceEntryConfirmed:          "Entrada confirmada",
+	OccurrenceEntryRejected:           "Entrada rejeitada",
+	OccurrenceSettlement:              "Liquidação normal",
+	OccurrenceAutomaticWriteOff:       "Baixado automaticamente via arquivo",
+	OccurrenceWriteOffRequested:       "Baixado conforme instruções da cooperativa",
+	OccurrenceRebateGranted:           "Abatimento concedido",
+	OccurrenceRebateCancelled:         "Abatimento cancelado",
+	OccurrenceDueDateChanged:          "Vencimento alterado",
+	OccurrenceNotarySettlement:        "Liquidação em cartório",
+	OccurrenceSettlementAfterWriteOff: "Liquidação após baixa",
+	OccurrenceProtestConfirmed:        "Confirmação de recebimento de instrução de protesto",
+	OccurrenceStopProtestConfirmed:    "Confirmação de recebimento de instrução de sustação de protesto",
+	OccurrenceSentToNotary:            "Entrada de título em cartório",
+	OccurrenceRejectedZipCode:         "Entrada rejeitada por CEP irregular",
+	OccurrenceWriteOffRejected:        "Baixa rejeitada",
+	OccurrenceFee:                     "Tarifa",
+	OccurrenceDataChangeRejected:      "Alteração rejeitada",
+	OccurrenceInstructionRejected:     "Instrução rejeitada",
+	OccurrenceDataChangeConfirmed:     "Confirmação de pedido de alteração de outros dados",
+	OccurrenceRemovedFromNotary:       "Retirado de cartório e manutenção em carteira",
+}
+
+// Description returns the description of the occurrence code, or an empty
+// string for unknown codes.
+func (o OccurrenceCode) Description() string {
+	return occurrenceDescriptions[o]
+}
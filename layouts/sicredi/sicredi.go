@@ -0,0 +1,63 @@
+// Package sicredi contains the record types of the Sicredi cobrança layouts,
+// ready to be used with the cnab240 and cnab400 packages.
+//
+// The CNAB 240 headers are the FEBRABAN ones (see the febraban240 package),
+// but the nosso número of the segments P and T has only 9 digits, in the
+// format AAXNNNNND (year, byte of generation, sequential number and check
+// digit, see OurNumberDigit). The CNAB 400 layout identifies the company by
+// the beneficiary code (código do beneficiário) and uses dates in the format
+// AAAAMMDD in the headers (see cnabdate.Date).
+//
+//	file := cnab400.File{
+//	  Header: sicredi.Header{...},
+//	  Details: []cnab400.Detail{
+//	    sicredi.Detail{...},
+//	  },
+//	  Trailer: sicredi.Trailer{...},
+//	}
+//
+//	data, err := cnab400.Marshal(file)
+package sicredi
+
+import (
+	"fmt"
+
+	"github.com/rafaeljusto/gocnab/layouts/febraban240"
+)
+
+// BankCode is the code of Sicredi in the clearing system.
+const BankCode = 748
+
+// DocumentType identifies the type of the document (inscrição) of a company or
+// person in the CNAB 240 layout, that uses the FEBRABAN codes.
+type DocumentType = febraban240.DocumentType
+
+// List of document types.
+const (
+	DocumentTypeCPF  = febraban240.DocumentTypeCPF
+	DocumentTypeCNPJ = febraban240.DocumentTypeCNPJ
+)
+
+// OurNumberDigit returns the check digit of the nosso número, using the module
+// 11 of the agency (4 digits), the post (posto, 2 digits), the beneficiary
+// code (5 digits) and the first 8 digits of the nosso número, with the
+// weights 2 to 9 from right to left. Check digits greater than 9 are replaced
+// by 0.
+func OurNumberDigit(agency, post, beneficiary, ourNumber int) int {
+	digits := fmt.Sprintf("%04d%02d%05d%08d", agency, post, beneficiary, ourNumber)
+
+	var sum int
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += int(digits[i]-'0') * weight
+		if weight++; weight > 9 {
+			weight = 2
+		}
+	}
+
+	digit := 11 - sum%11
+	if digit > 9 {
+		return 0
+	}
+	return digit
+}
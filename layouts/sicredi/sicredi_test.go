@@ -0,0 +1,67 @@
+package sicredi_test
+
+import (
+	"testing"
+
+	"github.com/rafaeljusto/gocnab/cnab240"
+	"github.com/rafaeljusto/gocnab/cnab400"
+	"github.com/rafaeljusto/gocnab/internal/layouttest"
+	"github.com/rafaeljusto/gocnab/layouts/sicredi"
+)
+
+func TestLayouts(t *testing.T) {
+	t.Parallel()
+
+	layouttest.CheckCoverage(t, cnab240.LineSize,
+		sicredi.SegmentP{},
+		sicredi.SegmentT{},
+	)
+
+	layouttest.CheckCoverage(t, cnab400.LineSize,
+		sicredi.Header{},
+		sicredi.Detail{},
+		sicredi.Trailer{},
+		sicredi.ReturnHeader{},
+		sicredi.ReturnDetail{},
+		sicredi.ReturnTrailer{},
+	)
+}
+
+func TestOurNumberDigit(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		agency      int
+		post        int
+		beneficiary int
+		ourNumber   int
+		expected    int
+	}{
+		{
+			description: "it should calculate the check digit",
+			agency:      100,
+			post:        2,
+			beneficiary: 12345,
+			ourNumber:   7200001,
+			expected:    4,
+		},
+		{
+			description: "it should replace check digits greater than 9",
+			agency:      100,
+			post:        2,
+			beneficiary: 12345,
+			ourNumber:   7200003,
+			expected:    0,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			digit := sicredi.OurNumberDigit(scenario.agency, scenario.post, scenario.beneficiary, scenario.ourNumber)
+			if digit != scenario.expected {
+				t.Errorf("expected digit “%d” and got “%d”", scenario.expected, digit)
+			}
+		})
+	}
+}
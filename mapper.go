@@ -0,0 +1,333 @@
+package gocnab
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+)
+
+// ErrAmbiguousLine raised when a CNAB line matches more than one record type
+// with the same priority in the mapper.
+var ErrAmbiguousLine = errors.New("line matches more than one record type")
+
+// Discriminator detects if a CNAB line belongs to a record type.
+type Discriminator func(line []byte) bool
+
+// MatchPrefix detects lines starting with prefix.
+func MatchPrefix(prefix string) Discriminator {
+	return func(line []byte) bool {
+		return bytes.HasPrefix(line, []byte(prefix))
+	}
+}
+
+// MatchRange detects lines with value in the positions [begin,end), following
+// the same convention of the CNAB tag. For example, in CNAB 240 the record
+// type is in the range [7,8) and the segment code in the range [13,14).
+func MatchRange(begin, end int, value string) Discriminator {
+	return func(line []byte) bool {
+		if begin < 0 || end < begin || end > len(line) {
+			return false
+		}
+
+		return string(line[begin:end]) == value
+	}
+}
+
+// MatchAll detects lines that match all the discriminators.
+func MatchAll(discriminators ...Discriminator) Discriminator {
+	return func(line []byte) bool {
+		for _, discriminator := range discriminators {
+			if !discriminator(line) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// Mapper maps the lines of a full CNAB file into record types, using a
+// discriminator for each record type. It can be used instead of the
+// map[string]interface{} in gocnab.Unmarshal when the record types can't be
+// detected only by the line prefix.
+//
+// When a line matches more than one record type, the one with the highest
+// priority is used. If there's more than one record type with the highest
+// priority an error is returned, so a line is never decoded twice.
+//
+//	var header fileHeader
+//	var segmentsP []segmentP
+//	var segmentsQ []segmentQ
+//
+//	mapper := gocnab.NewMapper()
+//	mapper.Register(&header, gocnab.MatchRange(7, 8, "0"))
+//	mapper.Register(&segmentsP, gocnab.MatchAll(gocnab.MatchRange(7, 8, "3"), gocnab.MatchRange(13, 14, "P")))
+//	mapper.Register(&segmentsQ, gocnab.MatchAll(gocnab.MatchRange(7, 8, "3"), gocnab.MatchRange(13, 14, "Q")))
+//
+//	err := gocnab.Unmarshal(data, mapper)
+type Mapper struct {
+	entries []mapperEntry
+}
+
+type mapperEntry struct {
+	target        reflect.Value
+	discriminator Discriminator
+	priority      int
+}
+
+// NewMapper returns an empty mapper.
+func NewMapper() *Mapper {
+	return new(Mapper)
+}
+
+// Register adds a record type to the mapper with the default priority (0).
+// The accepted types of v are the same of gocnab.Unmarshal for a single line
+// (*struct or gocnab.LineUnmarshaler) or for many lines (*[]struct or
+// *[]gocnab.LineUnmarshaler).
+func (m *Mapper) Register(v interface{}, discriminator Discriminator) {
+	m.RegisterWithPriority(v, discriminator, 0)
+}
+
+// RegisterWithPriority adds a record type to the mapper with a priority. Record
+// types with higher priorities are preferred when a line matches more than one
+// discriminator.
+func (m *Mapper) RegisterWithPriority(v interface{}, discriminator Discriminator, priority int) {
+	m.entries = append(m.entries, mapperEntry{
+		target:        reflect.ValueOf(v),
+		discriminator: discriminator,
+		priority:      priority,
+	})
+}
+
+// match returns the record type of the line, or nil when the line doesn't
+// match any discriminator.
+func (m *Mapper) match(line []byte) (*mapperEntry, error) {
+	i, err := matchLine(line, len(m.entries), func(i int) (Discriminator, int) {
+		return m.entries[i].discriminator, m.entries[i].priority
+	})
+	if err != nil || i < 0 {
+		return nil, err
+	}
+
+	return &m.entries[i], nil
+}
+
+// matchLine returns the index of the record type with the highest priority
+// matching the line, or -1 when the line doesn't match any of the n record
+// types. The discriminator and the priority of each record type are returned
+// by entry. More than one record type with the highest priority is reported
+// as gocnab.ErrAmbiguousLine.
+func matchLine(line []byte, n int, entry func(i int) (Discriminator, int)) (int, error) {
+	matched, matchedPriority := -1, 0
+	var ambiguous bool
+
+	for i := 0; i < n; i++ {
+		discriminator, priority := entry(i)
+		if !discriminator(line) {
+			continue
+		}
+
+		if matched < 0 || priority > matchedPriority {
+			matched, matchedPriority = i, priority
+			ambiguous = false
+		} else if priority == matchedPriority {
+			ambiguous = true
+		}
+	}
+
+	if ambiguous {
+		return -1, ErrAmbiguousLine
+	}
+
+	return matched, nil
+}
+
+func (m *Mapper) unmarshal(data []byte, options UnmarshalOptions) error {
+	for _, entry := range m.entries {
+		if !entry.supported() {
+			return ErrUnsupportedType
+		}
+	}
+
+	var records []reflect.Value
+	var lines, lineSizes []int
+
+	cnabLines := nonEmptyLines(data)
+	for i := 0; i < len(cnabLines); {
+		cnabLine := cnabLines[i]
+
+		entry, err := m.match(cnabLine.data)
+		if err == nil && entry == nil {
+			if err = options.unknownLine(cnabLine.number, cnabLine.data); err != nil {
+				return err
+			}
+			i++
+			continue
+		}
+
+		var record reflect.Value
+		var consumed int
+		if err == nil {
+			record, consumed, err = entry.unmarshal(lineData(cnabLines[i:]))
+		}
+
+		if err != nil {
+			return LineError{
+				Line: cnabLines[i+consumed].number,
+				Err:  err,
+			}
+		}
+
+		records = append(records, record)
+		lines = append(lines, cnabLine.number)
+		lineSizes = append(lineSizes, len(cnabLine.data))
+		i += consumed
+	}
+
+	if i, err := verifyAggregates(records, lineSizes); err != nil {
+		return LineError{
+			Line: lines[i],
+			Err:  err,
+		}
+	}
+
+	return nil
+}
+
+// recordType returns the type of the records decoded by the entry, that could
+// be registered as a pointer to a record or to a slice of records.
+func (e mapperEntry) recordType() (reflect.Type, bool) {
+	if e.target.Kind() != reflect.Ptr {
+		return nil, false
+	}
+
+	recordType := e.target.Type().Elem()
+	if recordType.Kind() == reflect.Slice && !e.target.Type().Implements(lineUnmarshalerType) {
+		recordType = recordType.Elem()
+	}
+
+	if recordType.Kind() != reflect.Struct && !reflect.PtrTo(recordType).Implements(lineUnmarshalerType) {
+		return nil, false
+	}
+
+	return recordType, true
+}
+
+// supported checks if the target is a valid pointer to a record or to a slice
+// of records.
+func (e mapperEntry) supported() bool {
+	_, ok := e.recordType()
+	return ok && !e.target.IsNil()
+}
+
+// unmarshal decodes the first lines into the target, returning the decoded
+// record and the number of lines used by it, or the index of the line with
+// problem when there's an error. Only composite records use more than one
+// line.
+func (e mapperEntry) unmarshal(cnabLines [][]byte) (reflect.Value, int, error) {
+	target := e.target.Elem()
+	if target.Kind() == reflect.Slice && !e.target.Type().Implements(lineUnmarshalerType) {
+		itemValue := reflect.New(target.Type().Elem())
+		consumed, err := unmarshalRecordLines(cnabLines, itemValue.Elem())
+		if err != nil {
+			return reflect.Value{}, consumed, err
+		}
+
+		target.Set(reflect.Append(target, itemValue.Elem()))
+		return target.Index(target.Len() - 1), consumed, nil
+	}
+
+	consumed, err := unmarshalRecordLines(cnabLines, target)
+	return target, consumed, err
+}
+
+// Record is a CNAB line decoded by the mapper, with the number of the line in
+// the file, starting at 1.
+type Record struct {
+	Line  int
+	Value interface{}
+}
+
+// Decode parses the CNAB-encoded data keeping the order of the lines, which is
+// useful to reassemble entries that use more than one line (like the segments
+// P, Q and R in CNAB 240). Each matching line generates a new record of the
+// registered type, so the registered pointers are only used to detect the
+// record type and could be nil (e.g. (*segmentP)(nil)). The Value of each
+// record contains the struct, and not a pointer to it.
+//
+// Lines that don't match any record type are ignored, unless an option like
+// gocnab.WithUnknownLineError is given.
+func (m *Mapper) Decode(data []byte, optionFuncs ...UnmarshalOptionFunc) ([]Record, error) {
+	var options UnmarshalOptions
+	for _, optionFunc := range optionFuncs {
+		optionFunc(&options)
+	}
+
+	for _, entry := range m.entries {
+		if _, ok := entry.recordType(); !ok {
+			return nil, ErrUnsupportedType
+		}
+	}
+
+	var records []Record
+	var lineSizes []int
+
+	cnabLines := nonEmptyLines(data)
+	for i := 0; i < len(cnabLines); {
+		cnabLine := cnabLines[i]
+
+		entry, err := m.match(cnabLine.data)
+		if err != nil {
+			return nil, LineError{
+				Line: cnabLine.number,
+				Err:  err,
+			}
+		}
+
+		if entry == nil {
+			if err = options.unknownLine(cnabLine.number, cnabLine.data); err != nil {
+				return nil, err
+			}
+			i++
+			continue
+		}
+
+		recordType, _ := entry.recordType()
+		record := reflect.New(recordType).Elem()
+		consumed, err := unmarshalRecordLines(lineData(cnabLines[i:]), record)
+		if err != nil {
+			return nil, LineError{
+				Line: cnabLines[i+consumed].number,
+				Err:  err,
+			}
+		}
+
+		records = append(records, Record{
+			Line:  cnabLine.number,
+			Value: record.Interface(),
+		})
+		lineSizes = append(lineSizes, len(cnabLine.data))
+		i += consumed
+	}
+
+	values := make([]reflect.Value, len(records))
+	for i, record := range records {
+		values[i] = reflect.ValueOf(record.Value)
+	}
+
+	if i, err := verifyAggregates(values, lineSizes); err != nil {
+		return nil, LineError{
+			Line: records[i].Line,
+			Err:  err,
+		}
+	}
+
+	return records, nil
+}
+
+// splitLines breaks the CNAB data into lines, removing the final control
+// character of the last line.
+func splitLines(data []byte) [][]byte {
+	data = bytes.TrimSuffix(data, []byte(FinalControlCharacter))
+	return bytes.Split(data, []byte(LineBreak))
+}
@@ -0,0 +1,469 @@
+package gocnab_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+)
+
+type mapperHeader struct {
+	Bank       int    `cnab:"0,3"`
+	Batch      int    `cnab:"3,7"`
+	RecordType string `cnab:"7,8"`
+	Name       string `cnab:"20,40"`
+}
+
+type mapperSegmentP struct {
+	Bank       int     `cnab:"0,3"`
+	Batch      int     `cnab:"3,7"`
+	RecordType string  `cnab:"7,8"`
+	Sequence   int     `cnab:"8,13"`
+	Segment    string  `cnab:"13,14"`
+	Amount     float64 `cnab:"20,35"`
+}
+
+type mapperSegmentQ struct {
+	Bank       int    `cnab:"0,3"`
+	Batch      int    `cnab:"3,7"`
+	RecordType string `cnab:"7,8"`
+	Sequence   int    `cnab:"8,13"`
+	Segment    string `cnab:"13,14"`
+	Name       string `cnab:"20,40"`
+}
+
+func TestUnmarshal_mapper(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(fmt.Sprintf("%-240s\r\n%-240s\r\n%-240s\r\n\r\n%-240s\r\n%-240s\x1a",
+		"23700000            HEADER NAME",
+		"2370001300001P      000000000001050",
+		"2370001300002Q      PAYER 1",
+		"2370001300003P      000000000002050",
+		"2370001300004Q      PAYER 2",
+	))
+
+	scenarios := []struct {
+		description   string
+		mapper        func(header *mapperHeader, segmentsP *[]mapperSegmentP, segmentsQ *[]mapperSegmentQ) *gocnab.Mapper
+		expectedError error
+	}{
+		{
+			description: "it should unmarshal lines by position",
+			mapper: func(header *mapperHeader, segmentsP *[]mapperSegmentP, segmentsQ *[]mapperSegmentQ) *gocnab.Mapper {
+				mapper := gocnab.NewMapper()
+				mapper.Register(header, gocnab.MatchRange(7, 8, "0"))
+				mapper.Register(segmentsP, gocnab.MatchAll(gocnab.MatchRange(7, 8, "3"), gocnab.MatchRange(13, 14, "P")))
+				mapper.Register(segmentsQ, gocnab.MatchAll(gocnab.MatchRange(7, 8, "3"), gocnab.MatchRange(13, 14, "Q")))
+				return mapper
+			},
+		},
+		{
+			description: "it should use the record type with highest priority",
+			mapper: func(header *mapperHeader, segmentsP *[]mapperSegmentP, segmentsQ *[]mapperSegmentQ) *gocnab.Mapper {
+				mapper := gocnab.NewMapper()
+				mapper.Register(header, gocnab.MatchPrefix("237"))
+				mapper.RegisterWithPriority(header, gocnab.MatchRange(7, 8, "0"), 2)
+				mapper.RegisterWithPriority(segmentsP, gocnab.MatchRange(13, 14, "P"), 1)
+				mapper.RegisterWithPriority(segmentsQ, gocnab.MatchRange(13, 14, "Q"), 1)
+				return mapper
+			},
+		},
+		{
+			description: "it should detect an ambiguous line",
+			mapper: func(header *mapperHeader, segmentsP *[]mapperSegmentP, segmentsQ *[]mapperSegmentQ) *gocnab.Mapper {
+				mapper := gocnab.NewMapper()
+				mapper.Register(header, gocnab.MatchRange(7, 8, "0"))
+				mapper.Register(segmentsP, gocnab.MatchRange(7, 8, "3"))
+				mapper.Register(segmentsQ, gocnab.MatchRange(7, 8, "3"))
+				return mapper
+			},
+			expectedError: gocnab.LineError{
+				Line: 2,
+				Err:  gocnab.ErrAmbiguousLine,
+			},
+		},
+		{
+			description: "it should detect an unsupported type",
+			mapper: func(header *mapperHeader, segmentsP *[]mapperSegmentP, segmentsQ *[]mapperSegmentQ) *gocnab.Mapper {
+				mapper := gocnab.NewMapper()
+				mapper.Register(*header, gocnab.MatchRange(7, 8, "0"))
+				return mapper
+			},
+			expectedError: gocnab.ErrUnsupportedType,
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			var header mapperHeader
+			var segmentsP []mapperSegmentP
+			var segmentsQ []mapperSegmentQ
+
+			err := gocnab.Unmarshal(data, scenario.mapper(&header, &segmentsP, &segmentsQ))
+
+			if !reflect.DeepEqual(scenario.expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			expectedHeader := mapperHeader{Bank: 237, RecordType: "0", Name: "HEADER NAME"}
+			if !reflect.DeepEqual(expectedHeader, header) {
+				t.Errorf("expected header “%#v” and got “%#v”", expectedHeader, header)
+			}
+
+			expectedSegmentsP := []mapperSegmentP{
+				{Bank: 237, Batch: 1, RecordType: "3", Sequence: 1, Segment: "P", Amount: 10.50},
+				{Bank: 237, Batch: 1, RecordType: "3", Sequence: 3, Segment: "P", Amount: 20.50},
+			}
+			if !reflect.DeepEqual(expectedSegmentsP, segmentsP) {
+				t.Errorf("expected segments P “%#v” and got “%#v”", expectedSegmentsP, segmentsP)
+			}
+
+			expectedSegmentsQ := []mapperSegmentQ{
+				{Bank: 237, Batch: 1, RecordType: "3", Sequence: 2, Segment: "Q", Name: "PAYER 1"},
+				{Bank: 237, Batch: 1, RecordType: "3", Sequence: 4, Segment: "Q", Name: "PAYER 2"},
+			}
+			if !reflect.DeepEqual(expectedSegmentsQ, segmentsQ) {
+				t.Errorf("expected segments Q “%#v” and got “%#v”", expectedSegmentsQ, segmentsQ)
+			}
+		})
+	}
+}
+
+func TestDiscriminators(t *testing.T) {
+	t.Parallel()
+
+	line := []byte("2370001300001P")
+
+	scenarios := []struct {
+		description   string
+		discriminator gocnab.Discriminator
+		expected      bool
+	}{
+		{
+			description:   "it should match a prefix",
+			discriminator: gocnab.MatchPrefix("237"),
+			expected:      true,
+		},
+		{
+			description:   "it should not match a different prefix",
+			discriminator: gocnab.MatchPrefix("001"),
+		},
+		{
+			description:   "it should match a range",
+			discriminator: gocnab.MatchRange(13, 14, "P"),
+			expected:      true,
+		},
+		{
+			description:   "it should not match a range beyond the line",
+			discriminator: gocnab.MatchRange(13, 15, "P "),
+		},
+		{
+			description:   "it should not match an invalid range",
+			discriminator: gocnab.MatchRange(14, 13, ""),
+		},
+		{
+			description:   "it should match all discriminators",
+			discriminator: gocnab.MatchAll(gocnab.MatchRange(7, 8, "3"), gocnab.MatchRange(13, 14, "P")),
+			expected:      true,
+		},
+		{
+			description:   "it should not match when one discriminator fails",
+			discriminator: gocnab.MatchAll(gocnab.MatchRange(7, 8, "3"), gocnab.MatchRange(13, 14, "Q")),
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			if matched := scenario.discriminator(line); scenario.expected != matched {
+				t.Errorf("expected match %t and got %t", scenario.expected, matched)
+			}
+		})
+	}
+}
+
+func TestMapper_Decode(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(fmt.Sprintf("%-240s\r\n%-240s\r\n%-240s\r\n\r\n%-240s\r\n%-240s\x1a",
+		"23700000            HEADER NAME",
+		"2370001300001P      000000000001050",
+		"2370001300002Q      PAYER 1",
+		"2370001300003P      000000000002050",
+		"2370001300004Q      PAYER 2",
+	))
+
+	mapper := gocnab.NewMapper()
+	mapper.Register((*mapperHeader)(nil), gocnab.MatchRange(7, 8, "0"))
+	mapper.Register((*[]mapperSegmentP)(nil), gocnab.MatchRange(13, 14, "P"))
+	mapper.Register((*mapperSegmentQ)(nil), gocnab.MatchRange(13, 14, "Q"))
+
+	records, err := mapper.Decode(data)
+	if err != nil {
+		t.Fatalf("unexpected error. details: %s", err)
+	}
+
+	expected := []gocnab.Record{
+		{Line: 1, Value: mapperHeader{Bank: 237, RecordType: "0", Name: "HEADER NAME"}},
+		{Line: 2, Value: mapperSegmentP{Bank: 237, Batch: 1, RecordType: "3", Sequence: 1, Segment: "P", Amount: 10.50}},
+		{Line: 3, Value: mapperSegmentQ{Bank: 237, Batch: 1, RecordType: "3", Sequence: 2, Segment: "Q", Name: "PAYER 1"}},
+		{Line: 5, Value: mapperSegmentP{Bank: 237, Batch: 1, RecordType: "3", Sequence: 3, Segment: "P", Amount: 20.50}},
+		{Line: 6, Value: mapperSegmentQ{Bank: 237, Batch: 1, RecordType: "3", Sequence: 4, Segment: "Q", Name: "PAYER 2"}},
+	}
+
+	if !reflect.DeepEqual(expected, records) {
+		t.Errorf("expected records “%#v” and got “%#v”", expected, records)
+	}
+
+	mapper.Register((*[]int)(nil), gocnab.MatchRange(13, 14, "R"))
+	if _, err = mapper.Decode(data); !reflect.DeepEqual(gocnab.ErrUnsupportedType, err) {
+		t.Errorf("expected error “%v” and got “%v”", gocnab.ErrUnsupportedType, err)
+	}
+}
+
+func ExampleMapper_Decode() {
+	type header struct {
+		Identifier string `cnab:"0,1"`
+		Name       string `cnab:"1,11"`
+	}
+
+	type detail struct {
+		Identifier string `cnab:"0,1"`
+		Amount     int    `cnab:"1,11"`
+	}
+
+	mapper := gocnab.NewMapper()
+	mapper.Register((*header)(nil), gocnab.MatchPrefix("0"))
+	mapper.Register((*detail)(nil), gocnab.MatchPrefix("1"))
+
+	data := []byte("0COMPANY   " + gocnab.LineBreak +
+		"10000000010" + gocnab.LineBreak +
+		"10000000020")
+
+	records, err := mapper.Decode(data)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for _, record := range records {
+		switch value := record.Value.(type) {
+		case header:
+			fmt.Printf("line %d: header %s\n", record.Line, value.Name)
+		case detail:
+			fmt.Printf("line %d: detail %d\n", record.Line, value.Amount)
+		}
+	}
+
+	// Output: line 1: header COMPANY
+	// line 2: detail 10
+	// line 3: detail 20
+}
+
+func TestUnmarshal_unknownLines(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("0HEADER    " + gocnab.LineBreak +
+		"10000000010" + gocnab.LineBreak +
+		"5NEW TYPE  " + gocnab.LineBreak +
+		"10000000020" + gocnab.LineBreak +
+		"9TRAILER   ")
+
+	type header struct {
+		Identifier string `cnab:"0,1"`
+		Name       string `cnab:"1,11"`
+	}
+
+	type detail struct {
+		Identifier string `cnab:"0,1"`
+		Amount     int    `cnab:"1,11"`
+	}
+
+	unmarshalers := map[string]func(options ...gocnab.UnmarshalOptionFunc) error{
+		"map": func(options ...gocnab.UnmarshalOptionFunc) error {
+			var h header
+			var d []detail
+			return gocnab.Unmarshal(data, map[string]interface{}{
+				"0": &h,
+				"1": &d,
+			}, options...)
+		},
+		"mapper": func(options ...gocnab.UnmarshalOptionFunc) error {
+			var h header
+			var d []detail
+			mapper := gocnab.NewMapper()
+			mapper.Register(&h, gocnab.MatchPrefix("0"))
+			mapper.Register(&d, gocnab.MatchPrefix("1"))
+			return gocnab.Unmarshal(data, mapper, options...)
+		},
+		"decode": func(options ...gocnab.UnmarshalOptionFunc) error {
+			mapper := gocnab.NewMapper()
+			mapper.Register((*header)(nil), gocnab.MatchPrefix("0"))
+			mapper.Register((*detail)(nil), gocnab.MatchPrefix("1"))
+			_, err := mapper.Decode(data, options...)
+			return err
+		},
+	}
+
+	for name, unmarshal := range unmarshalers {
+		t.Run(name, func(t *testing.T) {
+			if err := unmarshal(); err != nil {
+				t.Errorf("unexpected error ignoring unknown lines. details: %s", err)
+			}
+
+			expectedError := gocnab.LineError{
+				Line: 3,
+				Err:  gocnab.ErrUnknownLine,
+			}
+
+			if err := unmarshal(gocnab.WithUnknownLineError()); !reflect.DeepEqual(expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", expectedError, err)
+			}
+
+			var lines []gocnab.RawLine
+			if err := unmarshal(gocnab.WithUnknownLines(&lines)); err != nil {
+				t.Errorf("unexpected error collecting unknown lines. details: %s", err)
+			}
+
+			expectedLines := []gocnab.RawLine{
+				{Line: 3, Data: []byte("5NEW TYPE  ")},
+				{Line: 5, Data: []byte("9TRAILER   ")},
+			}
+
+			if !reflect.DeepEqual(expectedLines, lines) {
+				t.Errorf("expected lines “%#v” and got “%#v”", expectedLines, lines)
+			}
+
+			var handled []int
+			err := unmarshal(gocnab.WithUnknownLineHandler(func(line int, data []byte) error {
+				handled = append(handled, line)
+				if data[0] == '9' {
+					return errors.New("trailer not expected")
+				}
+				return nil
+			}))
+
+			expectedError = gocnab.LineError{
+				Line: 5,
+				Err:  errors.New("trailer not expected"),
+			}
+
+			if !reflect.DeepEqual(expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", expectedError, err)
+			}
+
+			if !reflect.DeepEqual([]int{3, 5}, handled) {
+				t.Errorf("expected handled lines “%v” and got “%v”", []int{3, 5}, handled)
+			}
+		})
+	}
+}
+
+func TestUnmarshal_finalControlCharacter(t *testing.T) {
+	t.Parallel()
+
+	// the final control character is in its own line, after the last line break
+	data := []byte("0HEADER    " + gocnab.LineBreak +
+		"10000000010" + gocnab.LineBreak +
+		"10000000020" + gocnab.LineBreak +
+		gocnab.FinalControlCharacter)
+
+	type header struct {
+		Identifier string `cnab:"0,1"`
+		Name       string `cnab:"1,11"`
+	}
+
+	type detail struct {
+		Identifier string `cnab:"0,1"`
+		Amount     int    `cnab:"1,11"`
+	}
+
+	unmarshalers := map[string]func(options ...gocnab.UnmarshalOptionFunc) error{
+		"map": func(options ...gocnab.UnmarshalOptionFunc) error {
+			var h header
+			var d []detail
+			return gocnab.Unmarshal(data, map[string]interface{}{
+				"0": &h,
+				"1": &d,
+			}, options...)
+		},
+		"mapper": func(options ...gocnab.UnmarshalOptionFunc) error {
+			var h header
+			var d []detail
+			mapper := gocnab.NewMapper()
+			mapper.Register(&h, gocnab.MatchPrefix("0"))
+			mapper.Register(&d, gocnab.MatchPrefix("1"))
+			return gocnab.Unmarshal(data, mapper, options...)
+		},
+		"decode": func(options ...gocnab.UnmarshalOptionFunc) error {
+			mapper := gocnab.NewMapper()
+			mapper.Register((*header)(nil), gocnab.MatchPrefix("0"))
+			mapper.Register((*detail)(nil), gocnab.MatchPrefix("1"))
+			_, err := mapper.Decode(data, options...)
+			return err
+		},
+	}
+
+	for name, unmarshal := range unmarshalers {
+		t.Run(name, func(t *testing.T) {
+			if err := unmarshal(gocnab.WithUnknownLineError()); err != nil {
+				t.Errorf("unexpected error. details: %s", err)
+			}
+		})
+	}
+
+	var details []detail
+	if err := gocnab.Unmarshal(data[len("0HEADER    "+gocnab.LineBreak):], &details); err != nil {
+		t.Errorf("unexpected error decoding a slice. details: %s", err)
+	}
+
+	expected := []detail{
+		{Identifier: "1", Amount: 10},
+		{Identifier: "1", Amount: 20},
+	}
+
+	if !reflect.DeepEqual(expected, details) {
+		t.Errorf("expected details “%#v” and got “%#v”", expected, details)
+	}
+}
+
+func TestUnmarshal_unsupportedOption(t *testing.T) {
+	t.Parallel()
+
+	type detail struct {
+		Identifier string `cnab:"0,1"`
+		Amount     int    `cnab:"1,11"`
+	}
+
+	data := []byte("10000000010")
+
+	scenarios := []struct {
+		description string
+		v           interface{}
+	}{
+		{
+			description: "it should reject the options when unmarshaling a struct",
+			v:           &detail{},
+		},
+		{
+			description: "it should reject the options when unmarshaling a slice",
+			v:           &[]detail{},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			err := gocnab.Unmarshal(data, scenario.v, gocnab.WithUnknownLineError())
+
+			if !reflect.DeepEqual(gocnab.ErrUnsupportedOption, err) {
+				t.Errorf("expected error “%v” and got “%v”", gocnab.ErrUnsupportedOption, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,691 @@
+package gocnab
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rafaeljusto/gocnab/cnabdate"
+)
+
+var (
+	// ErrUnknownRecordType record type isn't defined in the layout spec.
+	ErrUnknownRecordType = errors.New("gocnab: record type not defined in the layout spec")
+
+	// ErrUnknownField field of a dynamic record isn't defined in the layout spec
+	// of the record type.
+	ErrUnknownField = errors.New("field not defined in the layout spec")
+
+	// ErrInvalidSpecName record type without name, or record type or field with
+	// a name already used in the layout spec.
+	ErrInvalidSpecName = errors.New("empty or duplicated name in the layout spec")
+)
+
+// LayoutSpec is the declarative definition of the record types of a CNAB
+// file, so layouts can be loaded at runtime instead of being transcribed into
+// structs. It can be decoded from JSON with gocnab.ParseLayoutSpec, or from
+// YAML with any YAML library, as the spec types also have YAML tags:
+//
+//	var spec gocnab.LayoutSpec
+//	if err := yaml.Unmarshal(content, &spec); err != nil {
+//	  return err
+//	}
+//
+//	layout, err := gocnab.NewDynamicLayout(spec)
+type LayoutSpec struct {
+	LineSize int          `json:"lineSize" yaml:"lineSize"`
+	Records  []RecordSpec `json:"records" yaml:"records"`
+}
+
+// RecordSpec defines a record type of the layout. A line belongs to the
+// record type when it matches all the discriminators (a record type without
+// discriminators matches any line), and when a line matches more than one
+// record type the one with the highest priority is used, as in gocnab.Mapper.
+type RecordSpec struct {
+	Name     string      `json:"name" yaml:"name"`
+	Match    []MatchSpec `json:"match,omitempty" yaml:"match,omitempty"`
+	Priority int         `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Fields   []FieldSpec `json:"fields" yaml:"fields"`
+}
+
+// MatchSpec is a discriminator of a record type: the content of the range
+// [Begin,End) must be Value, like gocnab.MatchRange.
+type MatchSpec struct {
+	Begin int    `json:"begin" yaml:"begin"`
+	End   int    `json:"end" yaml:"end"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// FieldSpec defines a field of a record type, in the range [Begin,End). The
+// options have the same format of the options of the CNAB tag (e.g.
+// "required,enum=01|02"), except for count and sum, that aren't supported in
+// dynamic layouts. Fields without name only reserve their range, being useful
+// with the const and default options.
+//
+// The following types are supported, where the format is optional:
+//
+//	string  (default) decoded as string.
+//	int     decoded as int64.
+//	uint    decoded as uint64.
+//	float   decoded as float64. The format is the number of decimal places
+//	        (2 by default).
+//	bool    decoded as bool, represented by 1 or 0.
+//	date    decoded as time.Time, with the format DDMMAAAA (default), DDMMAA
+//	        or AAAAMMDD. Absent dates are encoded as zeros.
+//	time    decoded as time.Time, with the format HHMMSS (default) or HHMM.
+type FieldSpec struct {
+	Name    string `json:"name,omitempty" yaml:"name,omitempty"`
+	Begin   int    `json:"begin" yaml:"begin"`
+	End     int    `json:"end" yaml:"end"`
+	Type    string `json:"type,omitempty" yaml:"type,omitempty"`
+	Format  string `json:"format,omitempty" yaml:"format,omitempty"`
+	Options string `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+// ParseLayoutSpec decodes a layout spec in JSON. Unknown properties are
+// reported as errors, so typos in the spec are detected.
+func ParseLayoutSpec(data []byte) (LayoutSpec, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var spec LayoutSpec
+	if err := decoder.Decode(&spec); err != nil {
+		return LayoutSpec{}, err
+	}
+
+	return spec, nil
+}
+
+// DynamicRecord is a record of a dynamic layout, with the name of its record
+// type and the values of the fields by name. When decoded, the line number
+// (starting at 1) is also informed. Integers above 2^53 can't be represented
+// by float64, so they must be given as int64, uint64 or json.Number (e.g.
+// decoded with json.Decoder.UseNumber).
+type DynamicRecord struct {
+	Type   string
+	Line   int
+	Fields map[string]any
+}
+
+// DynamicLayout marshals and unmarshals CNAB files using a layout spec loaded
+// at runtime. The fields use the same encoding and validations of the struct
+// fields with CNAB tags.
+type DynamicLayout struct {
+	lineSize int
+	records  []dynamicRecordLayout
+}
+
+type dynamicRecordLayout struct {
+	name          string
+	discriminator Discriminator
+	priority      int
+	fields        []dynamicFieldLayout
+}
+
+type dynamicFieldLayout struct {
+	fieldLayout
+
+	fieldType reflect.Type
+	decimals  int
+	date      bool
+}
+
+// dateTypes maps the CNAB date and time formats to the field types.
+var dateTypes = map[string]map[string]reflect.Type{
+	"date": {
+		"":         reflect.TypeOf(dynamicDate[layoutDDMMAAAA]{}),
+		"DDMMAAAA": reflect.TypeOf(dynamicDate[layoutDDMMAAAA]{}),
+		"DDMMAA":   reflect.TypeOf(dynamicDate[layoutDDMMAA]{}),
+		"AAAAMMDD": reflect.TypeOf(dynamicDate[layoutAAAAMMDD]{}),
+	},
+	"time": {
+		"":       reflect.TypeOf(dynamicDate[layoutHHMMSS]{}),
+		"HHMMSS": reflect.TypeOf(dynamicDate[layoutHHMMSS]{}),
+		"HHMM":   reflect.TypeOf(dynamicDate[layoutHHMM]{}),
+	},
+}
+
+// NewDynamicLayout checks the layout spec and returns the dynamic layout to
+// marshal and unmarshal the records. Problems in the fields are reported as
+// gocnab.FieldError or gocnab.LayoutError, wrapped in a gocnab.RecordError
+// with the name of the record type.
+func NewDynamicLayout(spec LayoutSpec) (*DynamicLayout, error) {
+	if spec.LineSize <= 0 {
+		return nil, ErrInvalidLineSize
+	}
+
+	layout := DynamicLayout{
+		lineSize: spec.LineSize,
+	}
+
+	names := make(map[string]bool)
+	for _, recordSpec := range spec.Records {
+		if recordSpec.Name == "" || names[recordSpec.Name] {
+			return nil, RecordError{
+				Record: recordSpec.Name,
+				Err:    ErrInvalidSpecName,
+			}
+		}
+		names[recordSpec.Name] = true
+
+		record, err := newDynamicRecordLayout(recordSpec, spec.LineSize)
+		if err != nil {
+			return nil, RecordError{
+				Record: recordSpec.Name,
+				Err:    err,
+			}
+		}
+
+		layout.records = append(layout.records, record)
+	}
+
+	return &layout, nil
+}
+
+func newDynamicRecordLayout(spec RecordSpec, lineSize int) (dynamicRecordLayout, error) {
+	record := dynamicRecordLayout{
+		name:     spec.Name,
+		priority: spec.Priority,
+	}
+
+	var discriminators []Discriminator
+	for _, match := range spec.Match {
+		if match.Begin < 0 || match.End <= match.Begin || match.End > lineSize {
+			return record, ErrInvalidFieldTagRange
+		}
+		discriminators = append(discriminators, MatchRange(match.Begin, match.End, match.Value))
+	}
+	record.discriminator = MatchAll(discriminators...)
+
+	fieldNames := make(map[string]bool)
+	fields := make([]fieldLayout, 0, len(spec.Fields))
+
+	for i, fieldSpec := range spec.Fields {
+		name := fieldSpec.Name
+		if name == "" {
+			name = "_"
+		} else if fieldNames[name] {
+			return record, FieldError{
+				Field: name,
+				Err:   ErrInvalidSpecName,
+			}
+		}
+		fieldNames[name] = true
+
+		field, err := newDynamicFieldLayout(fieldSpec, i, lineSize)
+		if err != nil {
+			return record, FieldError{
+				Field: name,
+				Err:   err,
+			}
+		}
+
+		record.fields = append(record.fields, field)
+		fields = append(fields, field.fieldLayout)
+	}
+
+	if err := checkOverlaps(fields); err != nil {
+		return record, err
+	}
+
+	return record, nil
+}
+
+func newDynamicFieldLayout(spec FieldSpec, index, lineSize int) (dynamicFieldLayout, error) {
+	field := dynamicFieldLayout{
+		fieldLayout: fieldLayout{
+			name:     spec.Name,
+			index:    index,
+			exported: spec.Name != "",
+			begin:    spec.Begin,
+			end:      spec.End,
+		},
+		decimals: 2,
+	}
+
+	if field.name == "" {
+		field.name = "_"
+	}
+
+	if spec.Begin < 0 || spec.End < spec.Begin || spec.End > lineSize {
+		return field, ErrInvalidFieldTagRange
+	}
+
+	var hasFormat bool
+	switch spec.Type {
+	case "", "string":
+		field.fieldType = reflect.TypeOf("")
+	case "int":
+		field.fieldType = reflect.TypeOf(int64(0))
+	case "uint":
+		field.fieldType = reflect.TypeOf(uint64(0))
+	case "bool":
+		field.fieldType = reflect.TypeOf(false)
+	case "float":
+		field.fieldType = reflect.TypeOf(float64(0))
+		if hasFormat = spec.Format != ""; hasFormat {
+			decimals, err := strconv.Atoi(spec.Format)
+			if err != nil || decimals < 0 {
+				return field, ErrInvalidFieldTagOption
+			}
+			field.decimals = decimals
+		}
+	case "date", "time":
+		field.fieldType, hasFormat = dateTypes[spec.Type][spec.Format]
+		if !hasFormat {
+			return field, ErrInvalidFieldTagOption
+		}
+		field.date = true
+	default:
+		return field, ErrUnsupportedType
+	}
+
+	if spec.Format != "" && !hasFormat {
+		return field, ErrInvalidFieldTagOption
+	}
+
+	var options []string
+	if spec.Options != "" {
+		options = strings.Split(spec.Options, ",")
+	}
+
+	structField := reflect.StructField{
+		Name: field.name,
+		Type: field.fieldType,
+	}
+
+	var err error
+	if field.options, err = parseCNABFieldOptions(structField, options, spec.End-spec.Begin); err != nil {
+		return field, err
+	}
+
+	if field.options.aggregate != nil {
+		return field, ErrInvalidFieldTagOption
+	}
+
+	if field.options.hasConstant {
+		field.constant = make([]byte, spec.End-spec.Begin)
+		setFieldContent(field.constant, field.options.constant, 0, spec.End-spec.Begin)
+	}
+
+	return field, nil
+}
+
+// value converts the value of a dynamic record to the field type. Numbers of
+// any type, including json.Number, are accepted in numeric fields, as long as
+// they don't lose precision (e.g. a float64 decoded from JSON in an int
+// field).
+func (d dynamicFieldLayout) value(v any) (reflect.Value, error) {
+	fieldValue := reflect.New(d.fieldType).Elem()
+	if v == nil {
+		return fieldValue, nil
+	}
+
+	if d.date {
+		t, ok := v.(time.Time)
+		if !ok {
+			return fieldValue, ErrUnsupportedType
+		}
+		fieldValue.Field(0).Set(reflect.ValueOf(t))
+		return fieldValue, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if number, ok := v.(json.Number); ok {
+		parsed, err := parseNumber(string(number), d.fieldType.Kind())
+		if err != nil {
+			return fieldValue, ErrUnsupportedType
+		}
+		rv = reflect.ValueOf(parsed)
+	}
+
+	switch d.fieldType.Kind() {
+	case reflect.String, reflect.Bool:
+		if rv.Kind() != d.fieldType.Kind() {
+			return fieldValue, ErrUnsupportedType
+		}
+		fieldValue.Set(rv.Convert(d.fieldType))
+
+	default:
+		var number float64
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			number = float64(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			number = float64(rv.Uint())
+		case reflect.Float32, reflect.Float64:
+			number = rv.Float()
+		default:
+			return fieldValue, ErrUnsupportedType
+		}
+
+		switch d.fieldType.Kind() {
+		case reflect.Int64:
+			switch rv.Kind() {
+			case reflect.Float32, reflect.Float64:
+				if number != math.Trunc(number) || number < math.MinInt64 || number >= math.MaxInt64 {
+					return fieldValue, ErrUnsupportedType
+				}
+				fieldValue.SetInt(int64(number))
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+				// the conversion would wrap to a negative number
+				if rv.Uint() > math.MaxInt64 {
+					return fieldValue, ErrUnsupportedType
+				}
+				fieldValue.SetInt(int64(rv.Uint()))
+			default:
+				fieldValue.Set(rv.Convert(d.fieldType))
+			}
+		case reflect.Uint64:
+			if number < 0 || ((rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64) && (number != math.Trunc(number) || number >= math.MaxUint64)) {
+				return fieldValue, ErrUnsupportedType
+			}
+			fieldValue.Set(rv.Convert(d.fieldType))
+		default:
+			fieldValue.SetFloat(number)
+		}
+	}
+
+	return fieldValue, nil
+}
+
+// parseNumber parses a JSON number for a field of the kind. Numbers of integer
+// fields are parsed as integers, so they don't lose precision above 2^53.
+func parseNumber(number string, kind reflect.Kind) (any, error) {
+	var parsed any
+	var err error
+
+	switch kind {
+	case reflect.Int64:
+		parsed, err = strconv.ParseInt(number, 10, 64)
+	case reflect.Uint64:
+		parsed, err = strconv.ParseUint(number, 10, 64)
+	default:
+		return strconv.ParseFloat(number, 64)
+	}
+
+	// numbers like 1e3 or 10.0 are still accepted in integer fields, but not
+	// integers that don't fit in the field
+	if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrSyntax {
+		return strconv.ParseFloat(number, 64)
+	}
+
+	return parsed, err
+}
+
+// marshal writes the field value in the CNAB line, using the same encoding of
+// the struct fields. Floats with a number of decimal places different from 2
+// are written as integers, after being scaled.
+func (d dynamicFieldLayout) marshal(data []byte, v reflect.Value) error {
+	if d.fieldType.Kind() == reflect.Float64 && d.decimals != 2 {
+		scaled := math.Round(v.Float() * math.Pow10(d.decimals))
+		return marshalField(data, reflect.ValueOf(int64(scaled)), d.begin, d.end)
+	}
+
+	if d.options.keepCase {
+		setFieldContentKeepCase(data, v.String(), d.begin, d.end)
+		return nil
+	}
+
+	return marshalField(data, v, d.begin, d.end)
+}
+
+// unmarshal reads the field value from the CNAB line, using the same decoding
+// of the struct fields.
+func (d dynamicFieldLayout) unmarshal(data []byte) (reflect.Value, error) {
+	v := reflect.New(d.fieldType).Elem()
+
+	if d.fieldType.Kind() == reflect.Float64 && d.decimals != 2 {
+		scaled := reflect.New(reflect.TypeOf(int64(0))).Elem()
+		if err := unmarshalField(data, scaled, d.begin, d.end); err != nil {
+			return v, err
+		}
+		v.SetFloat(float64(scaled.Int()) / math.Pow10(d.decimals))
+		return v, nil
+	}
+
+	err := unmarshalField(data, v, d.begin, d.end)
+	return v, err
+}
+
+// interfaceValue returns the field value as it is stored in the dynamic
+// record.
+func (d dynamicFieldLayout) interfaceValue(v reflect.Value) any {
+	if d.date {
+		return v.Field(0).Interface()
+	}
+	return v.Interface()
+}
+
+// dateLayout is the Go layout of a date or time format of the dynamic
+// layouts.
+type dateLayout interface {
+	layout() string
+}
+
+type (
+	layoutDDMMAAAA struct{}
+	layoutDDMMAA   struct{}
+	layoutAAAAMMDD struct{}
+	layoutHHMMSS   struct{}
+	layoutHHMM     struct{}
+)
+
+func (layoutDDMMAAAA) layout() string { return "02012006" }
+func (layoutDDMMAA) layout() string   { return "020106" }
+func (layoutAAAAMMDD) layout() string { return "20060102" }
+func (layoutHHMMSS) layout() string   { return "150405" }
+func (layoutHHMM) layout() string     { return "1504" }
+
+// dynamicDate is a date or time field of a dynamic layout, encoded with the
+// Go layout of the field format. The format is part of the type, so the zero
+// value is still the zero date (important for the required and default
+// options).
+type dynamicDate[L dateLayout] struct {
+	time.Time
+}
+
+// MarshalCNAB encodes the date with its format, or zeros when it is absent.
+func (d dynamicDate[L]) MarshalCNAB() ([]byte, error) {
+	var layout L
+	return cnabdate.Format(d.Time, layout.layout()), nil
+}
+
+// UnmarshalCNAB decodes the date with its format. Blank dates or dates with
+// only zeros are decoded as the zero value.
+func (d *dynamicDate[L]) UnmarshalCNAB(data []byte) (err error) {
+	var layout L
+	d.Time, err = cnabdate.Parse(data, layout.layout())
+	return err
+}
+
+// match returns the record type of the line, or nil when the line doesn't
+// match any record type, following the same rules of gocnab.Mapper.
+func (l *DynamicLayout) match(line []byte) (*dynamicRecordLayout, error) {
+	i, err := matchLine(line, len(l.records), func(i int) (Discriminator, int) {
+		return l.records[i].discriminator, l.records[i].priority
+	})
+	if err != nil || i < 0 {
+		return nil, err
+	}
+
+	return &l.records[i], nil
+}
+
+// recordLayout returns the record type with the name.
+func (l *DynamicLayout) recordLayout(name string) (*dynamicRecordLayout, bool) {
+	for i := range l.records {
+		if l.records[i].name == name {
+			return &l.records[i], true
+		}
+	}
+	return nil, false
+}
+
+// MarshalRecord returns the CNAB line of a dynamic record, without line break
+// symbols. Fields absent in the record are written with their zero value.
+func (l *DynamicLayout) MarshalRecord(record DynamicRecord) ([]byte, error) {
+	recordLayout, ok := l.recordLayout(record.Type)
+	if !ok {
+		return nil, ErrUnknownRecordType
+	}
+
+	for name := range record.Fields {
+		if !recordLayout.hasField(name) {
+			return nil, FieldError{
+				Field: name,
+				Err:   ErrUnknownField,
+			}
+		}
+	}
+
+	fields := make([]fieldLayout, len(recordLayout.fields))
+	for i, field := range recordLayout.fields {
+		fields[i] = field.fieldLayout
+	}
+
+	data := []byte(strings.Repeat(" ", l.lineSize))
+	err := marshalFields(data, fields, func(field fieldLayout) (reflect.Value, error) {
+		// not exported fields are absent, so they get the zero value
+		return recordLayout.fields[field.index].value(record.Fields[field.name])
+	}, func(data []byte, v reflect.Value, field fieldLayout) error {
+		return recordLayout.fields[field.index].marshal(data, v)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// hasField checks if the record type has an exported field with the name.
+func (r dynamicRecordLayout) hasField(name string) bool {
+	for _, field := range r.fields {
+		if field.exported && field.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Marshal returns the CNAB encoding of the records, as a full CNAB file: the
+// line break symbols are added between the lines and the final control
+// character is added when there's more than one record, the same behavior of
+// gocnab.Marshal240 with many parameters.
+func (l *DynamicLayout) Marshal(records []DynamicRecord, optionFuncs ...MarshalOptionFunc) ([]byte, error) {
+	options := MarshalOptions{
+		addFinalControlCharacter: true,
+	}
+	for _, optionFunc := range optionFuncs {
+		optionFunc(&options)
+	}
+
+	var cnab []byte
+	for i, record := range records {
+		cnabLine, err := l.MarshalRecord(record)
+		if err != nil {
+			return nil, err
+		}
+
+		cnab = append(cnab, cnabLine...)
+		if i < len(records)-1 {
+			cnab = append(cnab, []byte(LineBreak)...)
+		}
+	}
+
+	if options.addFinalControlCharacter && len(records) > 1 {
+		cnab = append(cnab, []byte(FinalControlCharacter)...)
+	}
+
+	return cnab, nil
+}
+
+// Unmarshal parses all the CNAB lines of data into dynamic records, in the
+// order of the file. Each line is decoded with the record type matching it,
+// and lines that don't match any record type are ignored, unless an option
+// like gocnab.WithUnknownLineError is given. Decoding errors are returned as
+// gocnab.LineError.
+func (l *DynamicLayout) Unmarshal(data []byte, optionFuncs ...UnmarshalOptionFunc) ([]DynamicRecord, error) {
+	var options UnmarshalOptions
+	for _, optionFunc := range optionFuncs {
+		optionFunc(&options)
+	}
+
+	var records []DynamicRecord
+	for _, cnabLine := range nonEmptyLines(data) {
+		recordLayout, err := l.match(cnabLine.data)
+		if err != nil {
+			return nil, LineError{
+				Line: cnabLine.number,
+				Err:  err,
+			}
+		}
+
+		if recordLayout == nil {
+			if err = options.unknownLine(cnabLine.number, cnabLine.data); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		record, err := recordLayout.unmarshal(cnabLine.data, l.lineSize)
+		if err != nil {
+			return nil, LineError{
+				Line: cnabLine.number,
+				Err:  err,
+			}
+		}
+
+		record.Line = cnabLine.number
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func (r dynamicRecordLayout) unmarshal(data []byte, lineSize int) (DynamicRecord, error) {
+	record := DynamicRecord{
+		Type:   r.name,
+		Fields: make(map[string]any),
+	}
+
+	if len(data) != lineSize {
+		return record, ErrInvalidLineSize
+	}
+
+	for _, field := range r.fields {
+		if field.options.hasConstant && !bytes.Equal(data[field.begin:field.end], field.constant) {
+			return record, UnmarshalFieldError{
+				Field: field.name,
+				Data:  data[field.begin:field.end],
+				Err:   ErrConstantMismatch,
+			}
+		}
+
+		if !field.exported {
+			continue
+		}
+
+		fieldValue, err := field.unmarshal(data)
+		if err == nil {
+			err = validateField(data, fieldValue, field.fieldLayout)
+		}
+
+		if err != nil {
+			return record, UnmarshalFieldError{
+				Field: field.name,
+				Data:  data[field.begin:field.end],
+				Err:   err,
+			}
+		}
+
+		record.Fields[field.name] = field.interfaceValue(fieldValue)
+	}
+
+	return record, nil
+}
@@ -0,0 +1,604 @@
+package gocnab_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rafaeljusto/gocnab"
+	"gopkg.in/yaml.v3"
+)
+
+const dynamicLayoutSpec = `{
+  "lineSize": 40,
+  "records": [
+    {
+      "name": "header",
+      "match": [{"begin": 0, "end": 1, "value": "0"}],
+      "fields": [
+        {"begin": 0, "end": 1, "options": "const=0"},
+        {"name": "company", "begin": 1, "end": 21, "options": "required"},
+        {"name": "date", "begin": 21, "end": 29, "type": "date", "format": "AAAAMMDD"},
+        {"name": "time", "begin": 29, "end": 33, "type": "time", "format": "HHMM"},
+        {"begin": 33, "end": 40}
+      ]
+    },
+    {
+      "name": "detail",
+      "match": [{"begin": 0, "end": 1, "value": "1"}],
+      "fields": [
+        {"begin": 0, "end": 1, "options": "const=1"},
+        {"name": "key", "begin": 1, "end": 11, "options": "keepcase"},
+        {"name": "amount", "begin": 11, "end": 21, "type": "float"},
+        {"name": "rate", "begin": 21, "end": 27, "type": "float", "format": "4"},
+        {"name": "installments", "begin": 27, "end": 29, "type": "uint", "options": "max=12"},
+        {"name": "kind", "begin": 29, "end": 31, "options": "enum=01|02,default=01"},
+        {"name": "paid", "begin": 31, "end": 32, "type": "bool"},
+        {"name": "due", "begin": 32, "end": 40, "type": "date"}
+      ]
+    },
+    {
+      "name": "trailer",
+      "match": [{"begin": 0, "end": 1, "value": "9"}],
+      "fields": [
+        {"begin": 0, "end": 1, "options": "const=9"},
+        {"name": "records", "begin": 1, "end": 7, "type": "int"},
+        {"begin": 7, "end": 40}
+      ]
+    }
+  ]
+}`
+
+func newDynamicLayout(t *testing.T) *gocnab.DynamicLayout {
+	t.Helper()
+
+	spec, err := gocnab.ParseLayoutSpec([]byte(dynamicLayoutSpec))
+	if err != nil {
+		t.Fatalf("error parsing the layout spec. details: %s", err)
+	}
+
+	layout, err := gocnab.NewDynamicLayout(spec)
+	if err != nil {
+		t.Fatalf("error building the layout. details: %s", err)
+	}
+
+	return layout
+}
+
+func TestDynamicLayout(t *testing.T) {
+	t.Parallel()
+
+	layout := newDynamicLayout(t)
+
+	records := []gocnab.DynamicRecord{
+		{
+			Type: "header",
+			Fields: map[string]any{
+				"company": "Empresa Exemplo",
+				"date":    time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+				"time":    time.Date(0, 1, 1, 10, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			Type: "detail",
+			Fields: map[string]any{
+				"key":          "chave@pix",
+				"amount":       1500.75,
+				"rate":         1.2345,
+				"installments": 3,
+				"paid":         true,
+				"due":          time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			Type: "detail",
+			Fields: map[string]any{
+				"amount": 99.9,
+				"kind":   "02",
+			},
+		},
+		{
+			Type: "trailer",
+			Fields: map[string]any{
+				// numbers decoded from JSON are float64
+				"records": float64(4),
+			},
+		},
+	}
+
+	data, err := layout.Marshal(records)
+	if err != nil {
+		t.Fatalf("error marshalling. details: %s", err)
+	}
+
+	expected := "0EMPRESA EXEMPLO     202610181030       \r\n" +
+		"1chave@pix 00001500750123450301110112026\r\n" +
+		"1          00000099900000000002000000000\r\n" +
+		fmt.Sprintf("9000004%33s\x1a", "")
+
+	if expected != string(data) {
+		t.Fatalf("expected data “%s” and got “%s”", expected, string(data))
+	}
+
+	decoded, err := layout.Unmarshal(data)
+	if err != nil {
+		t.Fatalf("error unmarshalling. details: %s", err)
+	}
+
+	expectedRecords := []gocnab.DynamicRecord{
+		{
+			Type: "header",
+			Line: 1,
+			Fields: map[string]any{
+				"company": "EMPRESA EXEMPLO",
+				"date":    time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+				"time":    time.Date(0, 1, 1, 10, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			Type: "detail",
+			Line: 2,
+			Fields: map[string]any{
+				"key":          "chave@pix",
+				"amount":       1500.75,
+				"rate":         1.2345,
+				"installments": uint64(3),
+				"kind":         "01",
+				"paid":         true,
+				"due":          time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			Type: "detail",
+			Line: 3,
+			Fields: map[string]any{
+				"key":          "",
+				"amount":       99.9,
+				"rate":         float64(0),
+				"installments": uint64(0),
+				"kind":         "02",
+				"paid":         false,
+				"due":          time.Time{},
+			},
+		},
+		{
+			Type: "trailer",
+			Line: 4,
+			Fields: map[string]any{
+				"records": int64(4),
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedRecords, decoded) {
+		t.Errorf("expected records “%#v” and got “%#v”", expectedRecords, decoded)
+	}
+}
+
+func TestNewDynamicLayout(t *testing.T) {
+	t.Parallel()
+
+	record := func(fields ...gocnab.FieldSpec) gocnab.LayoutSpec {
+		return gocnab.LayoutSpec{
+			LineSize: 20,
+			Records: []gocnab.RecordSpec{
+				{Name: "detail", Fields: fields},
+			},
+		}
+	}
+
+	scenarios := []struct {
+		description   string
+		spec          gocnab.LayoutSpec
+		expectedError error
+	}{
+		{
+			description: "it should accept a valid spec",
+			spec: record(
+				gocnab.FieldSpec{Name: "a", Begin: 0, End: 10, Type: "int", Options: "min=1"},
+				gocnab.FieldSpec{Name: "b", Begin: 10, End: 16, Type: "date", Format: "DDMMAA"},
+			),
+		},
+		{
+			description:   "it should detect an invalid line size",
+			spec:          gocnab.LayoutSpec{},
+			expectedError: gocnab.ErrInvalidLineSize,
+		},
+		{
+			description: "it should detect a record type without name",
+			spec: gocnab.LayoutSpec{
+				LineSize: 20,
+				Records:  []gocnab.RecordSpec{{}},
+			},
+			expectedError: gocnab.RecordError{
+				Err: gocnab.ErrInvalidSpecName,
+			},
+		},
+		{
+			description: "it should detect a duplicated field name",
+			spec: record(
+				gocnab.FieldSpec{Name: "a", Begin: 0, End: 10},
+				gocnab.FieldSpec{Name: "a", Begin: 10, End: 20},
+			),
+			expectedError: gocnab.RecordError{
+				Record: "detail",
+				Err: gocnab.FieldError{
+					Field: "a",
+					Err:   gocnab.ErrInvalidSpecName,
+				},
+			},
+		},
+		{
+			description: "it should detect a field out of the line",
+			spec:        record(gocnab.FieldSpec{Name: "a", Begin: 10, End: 21}),
+			expectedError: gocnab.RecordError{
+				Record: "detail",
+				Err: gocnab.FieldError{
+					Field: "a",
+					Err:   gocnab.ErrInvalidFieldTagRange,
+				},
+			},
+		},
+		{
+			description: "it should detect an unknown type",
+			spec:        record(gocnab.FieldSpec{Name: "a", Begin: 0, End: 10, Type: "decimal"}),
+			expectedError: gocnab.RecordError{
+				Record: "detail",
+				Err: gocnab.FieldError{
+					Field: "a",
+					Err:   gocnab.ErrUnsupportedType,
+				},
+			},
+		},
+		{
+			description: "it should detect an unknown format",
+			spec:        record(gocnab.FieldSpec{Name: "a", Begin: 0, End: 10, Type: "date", Format: "MMDDAAAA"}),
+			expectedError: gocnab.RecordError{
+				Record: "detail",
+				Err: gocnab.FieldError{
+					Field: "a",
+					Err:   gocnab.ErrInvalidFieldTagOption,
+				},
+			},
+		},
+		{
+			description: "it should detect a format in a string field",
+			spec:        record(gocnab.FieldSpec{Name: "a", Begin: 0, End: 10, Format: "2"}),
+			expectedError: gocnab.RecordError{
+				Record: "detail",
+				Err: gocnab.FieldError{
+					Field: "a",
+					Err:   gocnab.ErrInvalidFieldTagOption,
+				},
+			},
+		},
+		{
+			description: "it should detect an aggregate option",
+			spec:        record(gocnab.FieldSpec{Name: "a", Begin: 0, End: 10, Type: "int", Options: "count=detail"}),
+			expectedError: gocnab.RecordError{
+				Record: "detail",
+				Err: gocnab.FieldError{
+					Field: "a",
+					Err:   gocnab.ErrInvalidFieldTagOption,
+				},
+			},
+		},
+		{
+			description: "it should detect overlapping fields",
+			spec: record(
+				gocnab.FieldSpec{Name: "a", Begin: 0, End: 10},
+				gocnab.FieldSpec{Name: "b", Begin: 5, End: 15},
+			),
+			expectedError: gocnab.RecordError{
+				Record: "detail",
+				Err: gocnab.LayoutError{
+					Field:      "b",
+					OtherField: "a",
+					Err:        gocnab.ErrOverlappingFieldRange,
+				},
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			_, err := gocnab.NewDynamicLayout(scenario.spec)
+
+			if !reflect.DeepEqual(scenario.expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestDynamicLayout_MarshalRecord(t *testing.T) {
+	t.Parallel()
+
+	layout := newDynamicLayout(t)
+
+	scenarios := []struct {
+		description   string
+		record        gocnab.DynamicRecord
+		expectedError error
+	}{
+		{
+			description: "it should accept an integer number in a float field",
+			record: gocnab.DynamicRecord{
+				Type:   "detail",
+				Fields: map[string]any{"amount": 10},
+			},
+		},
+		{
+			description:   "it should detect an unknown record type",
+			record:        gocnab.DynamicRecord{Type: "other"},
+			expectedError: gocnab.ErrUnknownRecordType,
+		},
+		{
+			description: "it should detect an unknown field",
+			record: gocnab.DynamicRecord{
+				Type:   "detail",
+				Fields: map[string]any{"amout": 10.5},
+			},
+			expectedError: gocnab.FieldError{
+				Field: "amout",
+				Err:   gocnab.ErrUnknownField,
+			},
+		},
+		{
+			description: "it should detect a value of another type",
+			record: gocnab.DynamicRecord{
+				Type:   "detail",
+				Fields: map[string]any{"amount": "10.50"},
+			},
+			expectedError: gocnab.FieldError{
+				Field: "amount",
+				Err:   gocnab.ErrUnsupportedType,
+			},
+		},
+		{
+			description: "it should detect a fractional number in an integer field",
+			record: gocnab.DynamicRecord{
+				Type:   "trailer",
+				Fields: map[string]any{"records": 1.5},
+			},
+			expectedError: gocnab.FieldError{
+				Field: "records",
+				Err:   gocnab.ErrUnsupportedType,
+			},
+		},
+		{
+			description: "it should detect an unsigned number that doesn't fit in an integer field",
+			record: gocnab.DynamicRecord{
+				Type:   "trailer",
+				Fields: map[string]any{"records": uint64(math.MaxInt64) + 1},
+			},
+			expectedError: gocnab.FieldError{
+				Field: "records",
+				Err:   gocnab.ErrUnsupportedType,
+			},
+		},
+		{
+			description: "it should detect a negative number in an unsigned field",
+			record: gocnab.DynamicRecord{
+				Type:   "detail",
+				Fields: map[string]any{"installments": -1},
+			},
+			expectedError: gocnab.FieldError{
+				Field: "installments",
+				Err:   gocnab.ErrUnsupportedType,
+			},
+		},
+		{
+			description: "it should detect a missing required field",
+			record:      gocnab.DynamicRecord{Type: "header"},
+			expectedError: gocnab.FieldError{
+				Field: "company",
+				Err:   gocnab.ErrRequiredField,
+			},
+		},
+		{
+			description: "it should detect a value out of the limits",
+			record: gocnab.DynamicRecord{
+				Type:   "detail",
+				Fields: map[string]any{"installments": 13},
+			},
+			expectedError: gocnab.FieldError{
+				Field: "installments",
+				Err:   gocnab.ErrValueOutOfRange,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			_, err := layout.MarshalRecord(scenario.record)
+
+			if !reflect.DeepEqual(scenario.expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestDynamicLayout_largeIntegers(t *testing.T) {
+	t.Parallel()
+
+	spec, err := gocnab.ParseLayoutSpec([]byte(`{
+  "lineSize": 40,
+  "records": [
+    {
+      "name": "detail",
+      "fields": [
+        {"name": "signed", "begin": 0, "end": 19, "type": "int"},
+        {"name": "unsigned", "begin": 19, "end": 39, "type": "uint"},
+        {"begin": 39, "end": 40}
+      ]
+    }
+  ]
+}`))
+	if err != nil {
+		t.Fatalf("error parsing the layout spec. details: %s", err)
+	}
+
+	layout, err := gocnab.NewDynamicLayout(spec)
+	if err != nil {
+		t.Fatalf("error building the layout. details: %s", err)
+	}
+
+	// the numbers are above 2^53, so they can't be represented as float64
+	data, err := layout.MarshalRecord(gocnab.DynamicRecord{
+		Type: "detail",
+		Fields: map[string]any{
+			"signed":   json.Number("9007199254740993"),
+			"unsigned": json.Number("18446744073709551615"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error. details: %s", err)
+	}
+
+	if expected := "0009007199254740993" + "18446744073709551615" + " "; expected != string(data) {
+		t.Errorf("expected data “%s” and got “%s”", expected, string(data))
+	}
+
+	expectedError := gocnab.FieldError{
+		Field: "signed",
+		Err:   gocnab.ErrUnsupportedType,
+	}
+
+	_, err = layout.MarshalRecord(gocnab.DynamicRecord{
+		Type:   "detail",
+		Fields: map[string]any{"signed": json.Number("9223372036854775808")},
+	})
+	if !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected error “%v” and got “%v”", expectedError, err)
+	}
+}
+
+func TestDynamicLayout_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	layout := newDynamicLayout(t)
+
+	scenarios := []struct {
+		description   string
+		data          string
+		options       []gocnab.UnmarshalOptionFunc
+		expectedError error
+	}{
+		{
+			description: "it should ignore unknown lines",
+			data:        fmt.Sprintf("5%39s\r\n9000000%33s", "", ""),
+		},
+		{
+			description: "it should detect unknown lines when requested",
+			data:        fmt.Sprintf("5%39s\r\n9000000%33s", "", ""),
+			options:     []gocnab.UnmarshalOptionFunc{gocnab.WithUnknownLineError()},
+			expectedError: gocnab.LineError{
+				Line: 1,
+				Err:  gocnab.ErrUnknownLine,
+			},
+		},
+		{
+			description: "it should detect a line with a different size",
+			data:        "9000000",
+			expectedError: gocnab.LineError{
+				Line: 1,
+				Err:  gocnab.ErrInvalidLineSize,
+			},
+		},
+		{
+			description: "it should detect a content not allowed by the enum",
+			data:        "1          00000000000000000003000000000",
+			expectedError: gocnab.LineError{
+				Line: 1,
+				Err: gocnab.UnmarshalFieldError{
+					Field: "kind",
+					Data:  []byte("03"),
+					Err:   gocnab.ErrValueNotInEnum,
+				},
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			_, err := layout.Unmarshal([]byte(scenario.data), scenario.options...)
+
+			if !reflect.DeepEqual(scenario.expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestParseLayoutSpec(t *testing.T) {
+	t.Parallel()
+
+	if _, err := gocnab.ParseLayoutSpec([]byte(`{"lineSize": 240, "record": []}`)); err == nil {
+		t.Error("expected an error for an unknown property")
+	}
+}
+
+// dynamicLayoutSpecYAML is the same spec of dynamicLayoutSpec, in YAML.
+const dynamicLayoutSpecYAML = `
+lineSize: 40
+records:
+  - name: header
+    match:
+      - {begin: 0, end: 1, value: "0"}
+    fields:
+      - {begin: 0, end: 1, options: "const=0"}
+      - {name: company, begin: 1, end: 21, options: required}
+      - {name: date, begin: 21, end: 29, type: date, format: AAAAMMDD}
+      - {name: time, begin: 29, end: 33, type: time, format: HHMM}
+      - {begin: 33, end: 40}
+  - name: detail
+    match:
+      - {begin: 0, end: 1, value: "1"}
+    fields:
+      - {begin: 0, end: 1, options: "const=1"}
+      - {name: key, begin: 1, end: 11, options: keepcase}
+      - {name: amount, begin: 11, end: 21, type: float}
+      - {name: rate, begin: 21, end: 27, type: float, format: "4"}
+      - {name: installments, begin: 27, end: 29, type: uint, options: "max=12"}
+      - {name: kind, begin: 29, end: 31, options: "enum=01|02,default=01"}
+      - {name: paid, begin: 31, end: 32, type: bool}
+      - {name: due, begin: 32, end: 40, type: date}
+  - name: trailer
+    match:
+      - {begin: 0, end: 1, value: "9"}
+    fields:
+      - {begin: 0, end: 1, options: "const=9"}
+      - {name: records, begin: 1, end: 7, type: int}
+      - {begin: 7, end: 40}
+`
+
+func TestLayoutSpec_yaml(t *testing.T) {
+	t.Parallel()
+
+	expected, err := gocnab.ParseLayoutSpec([]byte(dynamicLayoutSpec))
+	if err != nil {
+		t.Fatalf("error parsing the layout spec. details: %s", err)
+	}
+
+	decoder := yaml.NewDecoder(strings.NewReader(dynamicLayoutSpecYAML))
+	decoder.KnownFields(true)
+
+	var spec gocnab.LayoutSpec
+	if err := decoder.Decode(&spec); err != nil {
+		t.Fatalf("error decoding the YAML layout spec. details: %s", err)
+	}
+
+	if !reflect.DeepEqual(expected, spec) {
+		t.Errorf("expected spec “%#v” and got “%#v”", expected, spec)
+	}
+
+	if _, err := gocnab.NewDynamicLayout(spec); err != nil {
+		t.Errorf("unexpected error. details: %s", err)
+	}
+}
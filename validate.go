@@ -0,0 +1,104 @@
+package gocnab
+
+import (
+	"reflect"
+	"strings"
+)
+
+// validateField checks the field against the validation options of the CNAB
+// tag. Options related to the CNAB representation (enum and regex) are checked
+// against the field content already in the CNAB line, while the other options
+// are checked against the field value.
+func validateField(data []byte, v reflect.Value, field fieldLayout) error {
+	if err := validateValue(v, field.options); err != nil {
+		return err
+	}
+
+	return validateContent(data, field)
+}
+
+// validateValue checks the options related to the field value (required, min
+// and max).
+func validateValue(v reflect.Value, options fieldOptions) error {
+	if options.required && v.IsZero() {
+		return ErrRequiredField
+	}
+
+	if options.hasMin || options.hasMax {
+		var number float64
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			number = float64(v.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			number = float64(v.Uint())
+		case reflect.Float32, reflect.Float64:
+			number = v.Float()
+		}
+
+		if (options.hasMin && number < options.min) || (options.hasMax && number > options.max) {
+			return ErrValueOutOfRange
+		}
+	}
+
+	return nil
+}
+
+// validateContent checks the options related to the CNAB representation (enum
+// and regex) against the field content in the line, without the spaces around
+// it. The content is compared as it would be written, in uppercase unless the
+// field keeps its case, so the same data is accepted when marshaling and
+// unmarshaling.
+func validateContent(data []byte, field fieldLayout) error {
+	options := field.options
+	if len(options.enum) == 0 && options.regex == nil {
+		return nil
+	}
+
+	content := normalizeContent(strings.TrimSpace(string(data[field.begin:field.end])), options)
+
+	if len(options.enum) > 0 {
+		var found bool
+		for _, value := range options.enum {
+			if normalizeContent(value, options) == content {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return ErrValueNotInEnum
+		}
+	}
+
+	if options.regex != nil && !options.regex.MatchString(content) {
+		return ErrValuePatternMismatch
+	}
+
+	return nil
+}
+
+// normalizeContent converts the content to uppercase, as it is written in the
+// CNAB line, unless the field keeps its case.
+func normalizeContent(content string, options fieldOptions) string {
+	if options.keepCase {
+		return content
+	}
+	return strings.ToUpper(content)
+}
+
+// validateDefault checks the default value written in the CNAB line against
+// the validation options of the field, decoding it back into the field type.
+// When the field type can't be decoded (a type that only implements
+// gocnab.Marshaler, for example) only the content is checked.
+func validateDefault(data []byte, fieldType reflect.Type, field fieldLayout) error {
+	v := reflect.New(fieldType).Elem()
+
+	switch err := unmarshalField(data, v, field.begin, field.end); err {
+	case nil:
+		return validateField(data, v, field)
+	case ErrUnsupportedType:
+		return validateContent(data, field)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,308 @@
+package gocnab_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/rafaeljusto/gocnab"
+)
+
+func TestValidation(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		Identifier string  `cnab:"0,1,required"`
+		Occurrence int     `cnab:"1,3,enum=01|02|06"`
+		Amount     float64 `cnab:"3,13,min=0.01,max=1000"`
+		Document   string  `cnab:"13,27,regex=^[0-9]{11,14}$"`
+	}
+
+	scenarios := []struct {
+		description            string
+		v                      record
+		data                   []byte
+		expectedError          error
+		expectedUnmarshalError error
+	}{
+		{
+			description: "it should accept valid fields",
+			v: record{
+				Identifier: "1",
+				Occurrence: 6,
+				Amount:     10.5,
+				Document:   "12345678901",
+			},
+			data: []byte(fmt.Sprintf("%-400s", "1"+"06"+"0000001050"+"12345678901")),
+		},
+		{
+			description: "it should detect a required field without value",
+			v: record{
+				Occurrence: 1,
+				Amount:     10.5,
+				Document:   "12345678901",
+			},
+			data: []byte(fmt.Sprintf("%-400s", " "+"01"+"0000001050"+"12345678901")),
+			expectedError: gocnab.FieldError{
+				Field: "Identifier",
+				Err:   gocnab.ErrRequiredField,
+			},
+			expectedUnmarshalError: gocnab.UnmarshalFieldError{
+				Field: "Identifier",
+				Data:  []byte(" "),
+				Err:   gocnab.ErrRequiredField,
+			},
+		},
+		{
+			description: "it should detect a value not allowed by the enum",
+			v: record{
+				Identifier: "1",
+				Occurrence: 3,
+				Amount:     10.5,
+				Document:   "12345678901",
+			},
+			data: []byte(fmt.Sprintf("%-400s", "1"+"03"+"0000001050"+"12345678901")),
+			expectedError: gocnab.FieldError{
+				Field: "Occurrence",
+				Err:   gocnab.ErrValueNotInEnum,
+			},
+			expectedUnmarshalError: gocnab.UnmarshalFieldError{
+				Field: "Occurrence",
+				Data:  []byte("03"),
+				Err:   gocnab.ErrValueNotInEnum,
+			},
+		},
+		{
+			description: "it should detect a value lower than the minimum",
+			v: record{
+				Identifier: "1",
+				Occurrence: 1,
+				Document:   "12345678901",
+			},
+			data: []byte(fmt.Sprintf("%-400s", "1"+"01"+"0000000000"+"12345678901")),
+			expectedError: gocnab.FieldError{
+				Field: "Amount",
+				Err:   gocnab.ErrValueOutOfRange,
+			},
+			expectedUnmarshalError: gocnab.UnmarshalFieldError{
+				Field: "Amount",
+				Data:  []byte("0000000000"),
+				Err:   gocnab.ErrValueOutOfRange,
+			},
+		},
+		{
+			description: "it should detect a value greater than the maximum",
+			v: record{
+				Identifier: "1",
+				Occurrence: 1,
+				Amount:     1000.01,
+				Document:   "12345678901",
+			},
+			data: []byte(fmt.Sprintf("%-400s", "1"+"01"+"0000100001"+"12345678901")),
+			expectedError: gocnab.FieldError{
+				Field: "Amount",
+				Err:   gocnab.ErrValueOutOfRange,
+			},
+			expectedUnmarshalError: gocnab.UnmarshalFieldError{
+				Field: "Amount",
+				Data:  []byte("0000100001"),
+				Err:   gocnab.ErrValueOutOfRange,
+			},
+		},
+		{
+			description: "it should detect a value that doesn't match the regex",
+			v: record{
+				Identifier: "1",
+				Occurrence: 1,
+				Amount:     10.5,
+				Document:   "1234",
+			},
+			data: []byte(fmt.Sprintf("%-400s", "1"+"01"+"0000001050"+"1234")),
+			expectedError: gocnab.FieldError{
+				Field: "Document",
+				Err:   gocnab.ErrValuePatternMismatch,
+			},
+			expectedUnmarshalError: gocnab.UnmarshalFieldError{
+				Field: "Document",
+				Data:  []byte("1234          "),
+				Err:   gocnab.ErrValuePatternMismatch,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			data, err := gocnab.Marshal400(scenario.v)
+
+			if !reflect.DeepEqual(scenario.expectedError, err) {
+				t.Errorf("expected marshal error “%v” and got “%v”", scenario.expectedError, err)
+			}
+
+			if err == nil && !reflect.DeepEqual(scenario.data, data) {
+				t.Errorf("expected data “%s” and got “%s”", string(scenario.data), string(data))
+			}
+
+			var v record
+			err = gocnab.Unmarshal(scenario.data, &v)
+
+			if !reflect.DeepEqual(scenario.expectedUnmarshalError, err) {
+				t.Errorf("expected unmarshal error “%v” and got “%v”", scenario.expectedUnmarshalError, err)
+			}
+		})
+	}
+}
+
+func TestValidation_defaultValue(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description   string
+		v             interface{}
+		expectedError error
+	}{
+		{
+			description: "it should accept a default value allowed by the enum",
+			v: struct {
+				FieldA string `cnab:"0,2,enum=01|02,default=01"`
+			}{},
+		},
+		{
+			description: "it should detect a default value not allowed by the enum",
+			v: struct {
+				FieldA string `cnab:"0,2,enum=01|02,default=03"`
+			}{},
+			expectedError: gocnab.FieldError{
+				Field: "FieldA",
+				Err:   gocnab.ErrValueNotInEnum,
+			},
+		},
+		{
+			description: "it should detect a default value out of the limits",
+			v: struct {
+				FieldA int `cnab:"0,2,max=10,default=20"`
+			}{},
+			expectedError: gocnab.FieldError{
+				Field: "FieldA",
+				Err:   gocnab.ErrValueOutOfRange,
+			},
+		},
+		{
+			description: "it should detect a default value of a not exported field not matching the regex",
+			v: struct {
+				fieldA string `cnab:"0,2,default=AB,regex=[0-9]+"`
+			}{},
+			expectedError: gocnab.FieldError{
+				Field: "fieldA",
+				Err:   gocnab.ErrValuePatternMismatch,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			_, err := gocnab.Marshal400(scenario.v)
+
+			if !reflect.DeepEqual(scenario.expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestValidation_content(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		Code string `cnab:"0,3,regex=[a-z]*"`
+		Key  string `cnab:"3,6,keepcase,regex=[a-z]+"`
+		Kind string `cnab:"6,8,regex=[0-9]"`
+	}
+
+	scenarios := []struct {
+		description   string
+		data          string
+		expectedError error
+	}{
+		{
+			description: "it should validate the content as it would be written",
+			data:        "abcdef1 ",
+			expectedError: gocnab.UnmarshalFieldError{
+				Field: "Code",
+				Data:  []byte("abc"),
+				Err:   gocnab.ErrValuePatternMismatch,
+			},
+		},
+		{
+			description: "it should anchor the regular expression",
+			data:        "   def12",
+			expectedError: gocnab.UnmarshalFieldError{
+				Field: "Kind",
+				Data:  []byte("12"),
+				Err:   gocnab.ErrValuePatternMismatch,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			var v record
+			err := gocnab.Unmarshal([]byte(scenario.data), &v)
+
+			if !reflect.DeepEqual(scenario.expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", scenario.expectedError, err)
+			}
+		})
+	}
+
+	// the same value must be rejected when marshaling, as it is written in
+	// uppercase
+	expectedError := gocnab.FieldError{
+		Field: "Code",
+		Err:   gocnab.ErrValuePatternMismatch,
+	}
+
+	if _, err := gocnab.Marshal400(record{Code: "abc", Key: "def", Kind: "1"}); !reflect.DeepEqual(expectedError, err) {
+		t.Errorf("expected error “%v” and got “%v”", expectedError, err)
+	}
+}
+
+func TestValidation_invalidOptions(t *testing.T) {
+	t.Parallel()
+
+	scenarios := []struct {
+		description string
+		v           interface{}
+	}{
+		{
+			description: "it should detect a min option in a non-numeric field",
+			v: struct {
+				FieldA string `cnab:"0,10,min=1"`
+			}{},
+		},
+		{
+			description: "it should detect an invalid max option",
+			v: struct {
+				FieldA int `cnab:"0,10,max=X"`
+			}{},
+		},
+		{
+			description: "it should detect an invalid regex option",
+			v: struct {
+				FieldA string `cnab:"0,10,regex=[0-9"`
+			}{},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		t.Run(scenario.description, func(t *testing.T) {
+			expectedError := gocnab.FieldError{
+				Field: "FieldA",
+				Err:   gocnab.ErrInvalidFieldTagOption,
+			}
+
+			if err := gocnab.ValidateLayout(scenario.v, 400); !reflect.DeepEqual(expectedError, err) {
+				t.Errorf("expected error “%v” and got “%v”", expectedError, err)
+			}
+		})
+	}
+}